@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"net"
@@ -21,14 +22,14 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+	"github.com/nginxinc/kubernetes-ingress/internal/webhook"
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
-	conf_scheme "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
 	"github.com/nginxinc/nginx-plus-go-sdk/client"
 	"github.com/prometheus/client_golang/prometheus"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -85,6 +86,10 @@ var (
 		`Path to the VirtualServer NGINX configuration template for a VirtualServer resource.
 	(default for NGINX "nginx.virtualserver.tmpl"; default for NGINX Plus "nginx-plus.virtualserver.tmpl")`)
 
+	transportServerTemplatePath = flag.String("transportserver-template-path", "",
+		`Path to the TransportServer NGINX configuration template for a TransportServer resource.
+	(default for NGINX "nginx.transportserver.tmpl"; default for NGINX Plus "nginx-plus.transportserver.tmpl")`)
+
 	wallarmTarantoolTemplatePath = flag.String("wallarm-tarantool-template-path", "",
 		`Path to the Wallarm Tarantool Service configuration template.
 	(default for NGINX "wallarm-tarantool.tmpl")`)
@@ -97,7 +102,7 @@ The external address of the service is used when reporting the status of Ingress
 		"Update the address field in the status of Ingresses resources. Requires the -external-service flag, or the 'external-status-address' key in the ConfigMap.")
 
 	leaderElectionEnabled = flag.Bool("enable-leader-election", false,
-		"Enable Leader election to avoid multiple replicas of the controller reporting the status of Ingress resources -- only one replica will report status. See -report-ingress-status flag.")
+		"Enable Leader election to avoid multiple replicas of the controller reporting the status of Ingress, VirtualServer and VirtualServerRoute resources -- only one replica will report status. See -report-ingress-status flag.")
 
 	leaderElectionLockName = flag.String("leader-election-lock-name", "nginx-ingress-leader-election",
 		`Specifies the name of the ConfigMap, within the same namespace as the controller, used as the lock for leader election. Requires -enable-leader-election.`)
@@ -129,6 +134,28 @@ The external address of the service is used when reporting the status of Ingress
 
 	enableCustomResources = flag.Bool("enable-custom-resources", false,
 		"Enable custom resources")
+
+	enableTLSPassthrough = flag.Bool("enable-tls-passthrough", false,
+		`Enable TLS Passthrough on port 443. Requires -enable-custom-resources. The HTTPS servers of the Ingress and
+		VirtualServer resources are moved behind an internal listener, so that they can share port 443 with the TLS Passthrough TransportServers`)
+
+	strictAnnotations = flag.Bool("strict-annotations", false,
+		`Refuse to apply an Ingress resource with invalid annotations instead of ignoring the invalid annotations.
+		Every invalid annotation is reported as a Warning event for the Ingress in both modes`)
+
+	useEndpointSlices = flag.Bool("use-endpoint-slices", false,
+		`Discover the endpoints of services through the discovery.k8s.io EndpointSlice resources instead of the Endpoints resources.
+		Requires Kubernetes 1.17 or newer. On older clusters, the Endpoints resources are used`)
+
+	enableAdmissionWebhook = flag.Bool("enable-admission-webhook", false,
+		`Enable the validating admission webhook for Ingress, VirtualServer and VirtualServerRoute resources.
+		Requires -admission-webhook-tls-secret`)
+
+	admissionWebhookListenPort = flag.Int("admission-webhook-listen-port", 8443,
+		"Set the port where the admission webhook is exposed. [1023 - 65535]")
+
+	admissionWebhookTLSSecret = flag.String("admission-webhook-tls-secret", "",
+		`A Secret with a TLS certificate and key for the HTTPS server of the admission webhook. Format: <namespace>/<name>`)
 )
 
 func main() {
@@ -154,6 +181,19 @@ func main() {
 		glog.Fatalf("Invalid value for prometheus-metrics-listen-port: %v", metricsPortValidationError)
 	}
 
+	if *enableTLSPassthrough && !*enableCustomResources {
+		glog.Fatal("enable-tls-passthrough flag requires -enable-custom-resources")
+	}
+
+	webhookPortValidationError := validatePort(*admissionWebhookListenPort)
+	if webhookPortValidationError != nil {
+		glog.Fatalf("Invalid value for admission-webhook-listen-port: %v", webhookPortValidationError)
+	}
+
+	if *enableAdmissionWebhook && *admissionWebhookTLSSecret == "" {
+		glog.Fatal("enable-admission-webhook flag requires -admission-webhook-tls-secret")
+	}
+
 	allowedCIDRs, err := parseNginxStatusAllowCIDRs(*nginxStatusAllowCIDRs)
 	if err != nil {
 		glog.Fatalf(`Invalid value for nginx-status-allow-cidrs: %v`, err)
@@ -184,27 +224,40 @@ func main() {
 		glog.Fatalf("Failed to create client: %v.", err)
 	}
 
-	var confClient k8s_nginx.Interface
-	if *enableCustomResources {
-		confClient, err = k8s_nginx.NewForConfig(config)
-		if err != nil {
-			glog.Fatalf("Failed to create a conf client: %v", err)
-		}
+	// the conf client is also used for the networking.k8s.io Ingress and IngressClass resources
+	confClient, err := k8s_nginx.NewForConfig(config)
+	if err != nil {
+		glog.Fatalf("Failed to create a conf client: %v", err)
+	}
+
+	isIngressAPIAvailable, isIngressClassAPIAvailable, err := k8s.DiscoverIngressAPIs(kubeClient.Discovery())
+	if err != nil {
+		glog.Fatalf("Error when discovering the Ingress APIs: %v", err)
+	}
+	if !isIngressAPIAvailable {
+		glog.Warning("The cluster doesn't serve the networking.k8s.io Ingress resources, using the extensions/v1beta1 API instead")
+	}
 
-		// required for emitting Events for VirtualServer
-		err = conf_scheme.AddToScheme(scheme.Scheme)
+	if *useEndpointSlices {
+		isEndpointSliceAPIAvailable, err := k8s.DiscoverEndpointSliceAPI(kubeClient.Discovery())
 		if err != nil {
-			glog.Fatalf("Failed to add configuration types to the scheme: %v", err)
+			glog.Fatalf("Error when discovering the EndpointSlice API: %v", err)
+		}
+		if !isEndpointSliceAPIAvailable {
+			glog.Warning("The cluster doesn't serve the discovery.k8s.io EndpointSlice resources, using the Endpoints resources instead")
+			*useEndpointSlices = false
 		}
 	}
 
 	nginxConfTemplatePath := "nginx.tmpl"
 	nginxIngressTemplatePath := "nginx.ingress.tmpl"
 	nginxVirtualServerTemplatePath := "nginx.virtualserver.tmpl"
+	nginxTransportServerTemplatePath := "nginx.transportserver.tmpl"
 	if *nginxPlus {
 		nginxConfTemplatePath = "nginx-plus.tmpl"
 		nginxIngressTemplatePath = "nginx-plus.ingress.tmpl"
 		nginxVirtualServerTemplatePath = "nginx-plus.virtualserver.tmpl"
+		nginxTransportServerTemplatePath = "nginx-plus.transportserver.tmpl"
 	}
 	nginxWallarmTarantoolTemplatePath := "wallarm-tarantool.tmpl"
 
@@ -217,6 +270,9 @@ func main() {
 	if *virtualServerTemplatePath != "" {
 		nginxVirtualServerTemplatePath = *virtualServerTemplatePath
 	}
+	if *transportServerTemplatePath != "" {
+		nginxTransportServerTemplatePath = *transportServerTemplatePath
+	}
 	if *wallarmTarantoolTemplatePath != "" {
 		nginxWallarmTarantoolTemplatePath = *wallarmTarantoolTemplatePath
 	}
@@ -231,7 +287,7 @@ func main() {
 		glog.Fatalf("Error creating TemplateExecutor: %v", err)
 	}
 
-	templateExecutorV2, err := version2.NewTemplateExecutor(nginxVirtualServerTemplatePath)
+	templateExecutorV2, err := version2.NewTemplateExecutor(nginxVirtualServerTemplatePath, nginxTransportServerTemplatePath)
 	if err != nil {
 		glog.Fatalf("Error cresting TemplateExecutorV2: %v", err)
 	}
@@ -301,7 +357,11 @@ func main() {
 		if err != nil {
 			glog.Fatalf("Error when getting %v: %v", *nginxConfigMaps, err)
 		}
-		cfgParams = configs.ParseConfigMap(cfm, *nginxPlus)
+		var cfgErrs field.ErrorList
+		cfgParams, cfgErrs = configs.ParseConfigMap(cfm, *nginxPlus)
+		for _, err := range cfgErrs {
+			glog.Errorf("Configmap %s/%s: %v", ns, name, err)
+		}
 		if cfgParams.MainServerSSLDHParamFileContent != nil {
 			fileName, err := nginxManager.CreateDHParam(*cfgParams.MainServerSSLDHParamFileContent)
 			if err != nil {
@@ -330,6 +390,7 @@ func main() {
 		NginxStatusAllowCIDRs:          allowedCIDRs,
 		NginxStatusPort:                *nginxStatusPort,
 		StubStatusOverUnixSocketForOSS: *enablePrometheusMetrics,
+		TLSPassthrough:                 *enableTLSPassthrough,
 	}
 
 	ngxConfig := configs.GenerateNginxMainConfig(staticCfgParams, cfgParams)
@@ -340,6 +401,12 @@ func main() {
 	nginxManager.CreateMainConfig(content)
 	nginxManager.UpdateConfigVersionFile()
 
+	if *enableTLSPassthrough {
+		// the main config includes the file with the hosts of the TLS Passthrough TransportServers
+		var emptyFile []byte
+		nginxManager.CreateTLSPassthroughHostsConfig(emptyFile)
+	}
+
 	nginxDone := make(chan error, 1)
 	nginxManager.Start(nginxDone)
 
@@ -370,6 +437,13 @@ func main() {
 	cnf := configs.NewConfigurator(nginxManager, staticCfgParams, cfgParams, templateExecutor, templateExecutorV2, *nginxPlus, isWildcardEnabled)
 	controllerNamespace := os.Getenv("POD_NAMESPACE")
 
+	controllerZone, err := k8s.GetControllerZone(kubeClient, controllerNamespace, os.Getenv("POD_NAME"))
+	if err != nil {
+		glog.Warningf("Couldn't determine the zone of the Ingress Controller, zone-aware routing is disabled: %v", err)
+	} else {
+		glog.V(3).Infof("Ingress Controller runs in the zone %v", controllerZone)
+	}
+
 	lbcInput := k8s.NewLoadBalancerControllerInput{
 		KubeClient:                kubeClient,
 		ConfClient:                confClient,
@@ -380,6 +454,10 @@ func main() {
 		IsNginxPlus:               *nginxPlus,
 		IngressClass:              *ingressClass,
 		UseIngressClassOnly:       *useIngressClassOnly,
+		UseExtensionsIngressAPI:   !isIngressAPIAvailable,
+		IsIngressClassAPIAvailable: isIngressClassAPIAvailable,
+		UseEndpointSlices:         *useEndpointSlices,
+		ControllerZone:            controllerZone,
 		ExternalServiceName:       *externalService,
 		ControllerNamespace:       controllerNamespace,
 		ReportIngressStatus:       *reportIngressStatus,
@@ -389,11 +467,28 @@ func main() {
 		WildcardTLSSecret:         *wildcardTLSSecret,
 		ConfigMaps:                *nginxConfigMaps,
 		AreCustomResourcesEnabled: *enableCustomResources,
+		IsTLSPassthroughEnabled:   *enableTLSPassthrough,
+		AreAnnotationsStrict:      *strictAnnotations,
 		MetricsCollector:          controllerCollector,
 	}
 
 	lbc := k8s.NewLoadBalancerController(lbcInput)
 
+	if *enableAdmissionWebhook {
+		secret, err := getAndValidateSecret(kubeClient, *admissionWebhookTLSSecret)
+		if err != nil {
+			glog.Fatalf("Error trying to get the admission webhook TLS secret %v: %v", *admissionWebhookTLSSecret, err)
+		}
+
+		cert, err := tls.X509KeyPair(secret.Data[api_v1.TLSCertKey], secret.Data[api_v1.TLSPrivateKeyKey])
+		if err != nil {
+			glog.Fatalf("Error loading the admission webhook TLS certificate and key: %v", err)
+		}
+
+		validator := webhook.NewValidator(*nginxPlus, lbc.IsNginxIngress, *nginxConfigMaps)
+		go webhook.RunWebhookServer(*admissionWebhookListenPort, cert, validator)
+	}
+
 	go handleTermination(lbc, nginxManager, nginxDone)
 	lbc.Run()
 
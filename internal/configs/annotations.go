@@ -9,11 +9,25 @@ import (
 	"strings"
 
 	"github.com/golang/glog"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // JWTKeyAnnotation is the annotation where the Secret with a JWK is specified.
 const JWTKeyAnnotation = "nginx.com/jwt-key"
 
+// ClientCertificateSecretAnnotation is the annotation where the Secret with a CA certificate bundle for the verification
+// of client certificates is specified.
+const ClientCertificateSecretAnnotation = "nginx.org/client-certificate-secret"
+
+// ProxySSLTrustedCertSecretAnnotation is the annotation where the Secret with a CA certificate bundle for the verification
+// of the certificates of the backends is specified.
+const ProxySSLTrustedCertSecretAnnotation = "nginx.org/proxy-ssl-trusted-cert-secret"
+
+// ProxySSLSecretAnnotation is the annotation where the TLS Secret with the client certificate that NGINX presents
+// to the backends is specified.
+const ProxySSLSecretAnnotation = "nginx.org/proxy-ssl-secret"
+
 var masterBlacklist = map[string]bool{
 	"nginx.org/rewrites":                      true,
 	"nginx.org/ssl-services":                  true,
@@ -23,50 +37,83 @@ var masterBlacklist = map[string]bool{
 	"nginx.com/health-checks":                 true,
 	"nginx.com/health-checks-mandatory":       true,
 	"nginx.com/health-checks-mandatory-queue": true,
+	"nginx.org/proxy-ssl-trusted-cert-secret": true,
+	"nginx.org/proxy-ssl-verify-depth":        true,
+	"nginx.org/proxy-ssl-name":                true,
+	"nginx.org/proxy-ssl-secret":              true,
 }
 
 var minionBlacklist = map[string]bool{
-	"nginx.org/proxy-hide-headers":       true,
-	"nginx.org/proxy-pass-headers":       true,
-	"nginx.org/redirect-to-https":        true,
-	"ingress.kubernetes.io/ssl-redirect": true,
-	"nginx.org/hsts":                     true,
-	"nginx.org/hsts-max-age":             true,
-	"nginx.org/hsts-include-subdomains":  true,
-	"nginx.org/server-tokens":            true,
-	"nginx.org/listen-ports":             true,
-	"nginx.org/listen-ports-ssl":         true,
-	"nginx.org/server-snippets":          true,
+	"nginx.org/proxy-hide-headers":              true,
+	"nginx.org/proxy-pass-headers":              true,
+	"nginx.org/redirect-to-https":               true,
+	"ingress.kubernetes.io/ssl-redirect":        true,
+	"nginx.org/hsts":                            true,
+	"nginx.org/hsts-max-age":                    true,
+	"nginx.org/hsts-include-subdomains":         true,
+	"nginx.org/server-tokens":                   true,
+	"nginx.org/listen-ports":                    true,
+	"nginx.org/listen-ports-ssl":                true,
+	"nginx.org/server-snippets":                 true,
+	"nginx.org/client-certificate-secret":       true,
+	"nginx.org/client-certificate-verify":       true,
+	"nginx.org/client-certificate-verify-depth": true,
+	"nginx.org/client-certificate-forward":      true,
 }
 
 var minionInheritanceList = map[string]bool{
-	"nginx.org/proxy-connect-timeout":    true,
-	"nginx.org/proxy-read-timeout":       true,
-	"nginx.org/client-max-body-size":     true,
-	"nginx.org/proxy-buffering":          true,
-	"nginx.org/proxy-buffers":            true,
-	"nginx.org/proxy-buffer-size":        true,
-	"nginx.org/proxy-max-temp-file-size": true,
-	"nginx.org/location-snippets":        true,
-	"nginx.org/lb-method":                true,
-	"nginx.org/keepalive":                true,
-	"nginx.org/max-fails":                true,
-	"nginx.org/fail-timeout":             true,
+	"nginx.org/proxy-connect-timeout":       true,
+	"nginx.org/proxy-read-timeout":          true,
+	"nginx.org/client-max-body-size":        true,
+	"nginx.org/proxy-buffering":             true,
+	"nginx.org/proxy-buffers":               true,
+	"nginx.org/proxy-buffer-size":           true,
+	"nginx.org/proxy-max-temp-file-size":    true,
+	"nginx.org/proxy-next-upstream":         true,
+	"nginx.org/proxy-next-upstream-timeout": true,
+	"nginx.org/proxy-next-upstream-tries":   true,
+	"nginx.org/location-snippets":           true,
+	"nginx.org/lb-method":                   true,
+	"nginx.org/keepalive":                   true,
+	"nginx.org/max-fails":                   true,
+	"nginx.org/fail-timeout":                true,
+	"nginx.org/zone-aware-routing":          true,
 }
 
-func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool) ConfigParams {
+var annotationsPath = field.NewPath("metadata", "annotations")
+
+// ValidateIngressAnnotations parses the annotations of an Ingress and returns the annotations with invalid values
+// as well as the annotations that are not supported by the NGINX flavor.
+func ValidateIngressAnnotations(ing *networking.Ingress, isPlus bool) field.ErrorList {
+	ingEx := &IngressEx{Ingress: ing}
+
+	_, allErrs := parseAnnotations(ingEx, NewDefaultConfigParams(), isPlus)
+
+	_, spErrs := getSessionPersistenceServices(ingEx)
+	allErrs = append(allErrs, spErrs...)
+
+	_, rewriteErrs := getRewrites(ingEx)
+	allErrs = append(allErrs, rewriteErrs...)
+
+	return allErrs
+}
+
+// parseAnnotations applies the annotations of an Ingress on top of baseCfgParams. The annotations with invalid values
+// are ignored and returned as errors.
+func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool) (ConfigParams, field.ErrorList) {
 	cfgParams := *baseCfgParams
+	allErrs := field.ErrorList{}
 
 	if lbMethod, exists := ingEx.Ingress.Annotations["nginx.org/lb-method"]; exists {
 		if isPlus {
 			if parsedMethod, err := ParseLBMethodForPlus(lbMethod); err != nil {
-				glog.Errorf("Ingress %s/%s: Invalid value for the nginx.org/lb-method: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), lbMethod, err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/lb-method"), lbMethod, err.Error()))
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
 		} else {
 			if parsedMethod, err := ParseLBMethod(lbMethod); err != nil {
-				glog.Errorf("Ingress %s/%s: Invalid value for the nginx.org/lb-method: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), lbMethod, err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/lb-method"), lbMethod, err.Error()))
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
@@ -75,19 +122,19 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if healthCheckEnabled, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.com/health-checks", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.com/health-checks"), ingEx.Ingress.Annotations["nginx.com/health-checks"], "must be a boolean"))
 		}
 		if isPlus {
 			cfgParams.HealthCheckEnabled = healthCheckEnabled
 		} else {
-			glog.Warning("Annotation 'nginx.com/health-checks' requires NGINX Plus")
+			allErrs = append(allErrs, field.Forbidden(annotationsPath.Key("nginx.com/health-checks"), "requires NGINX Plus"))
 		}
 	}
 
 	if cfgParams.HealthCheckEnabled {
 		if healthCheckMandatory, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.com/health-checks-mandatory", ingEx.Ingress); exists {
 			if err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.com/health-checks-mandatory"), ingEx.Ingress.Annotations["nginx.com/health-checks-mandatory"], "must be a boolean"))
 			}
 			cfgParams.HealthCheckMandatory = healthCheckMandatory
 		}
@@ -96,7 +143,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 	if cfgParams.HealthCheckMandatory {
 		if healthCheckQueue, exists, err := GetMapKeyAsInt64(ingEx.Ingress.Annotations, "nginx.com/health-checks-mandatory-queue", ingEx.Ingress); exists {
 			if err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.com/health-checks-mandatory-queue"), ingEx.Ingress.Annotations["nginx.com/health-checks-mandatory-queue"], "must be an integer"))
 			}
 			cfgParams.HealthCheckMandatoryQueue = healthCheckQueue
 		}
@@ -104,12 +151,12 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if slowStart, exists := ingEx.Ingress.Annotations["nginx.com/slow-start"]; exists {
 		if parsedSlowStart, err := parseSlowStart(slowStart); err != nil {
-			glog.Errorf("Ingress %s/%s: Invalid value nginx.org/slow-start: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), slowStart, err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.com/slow-start"), slowStart, err.Error()))
 		} else {
 			if isPlus {
 				cfgParams.SlowStart = parsedSlowStart
 			} else {
-				glog.Warning("Annotation 'nginx.com/slow-start' requires NGINX Plus")
+				allErrs = append(allErrs, field.Forbidden(annotationsPath.Key("nginx.com/slow-start"), "requires NGINX Plus"))
 			}
 		}
 	}
@@ -119,7 +166,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 			if isPlus {
 				cfgParams.ServerTokens = ingEx.Ingress.Annotations["nginx.org/server-tokens"]
 			} else {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/server-tokens"), ingEx.Ingress.Annotations["nginx.org/server-tokens"], "must be a boolean"))
 			}
 		} else {
 			cfgParams.ServerTokens = "off"
@@ -175,7 +222,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if redirectToHTTPS, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/redirect-to-https", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/redirect-to-https"), ingEx.Ingress.Annotations["nginx.org/redirect-to-https"], "must be a boolean"))
 		} else {
 			cfgParams.RedirectToHTTPS = redirectToHTTPS
 		}
@@ -183,7 +230,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if sslRedirect, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "ingress.kubernetes.io/ssl-redirect", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("ingress.kubernetes.io/ssl-redirect"), ingEx.Ingress.Annotations["ingress.kubernetes.io/ssl-redirect"], "must be a boolean"))
 		} else {
 			cfgParams.SSLRedirect = sslRedirect
 		}
@@ -191,7 +238,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if proxyBuffering, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/proxy-buffering", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/proxy-buffering"), ingEx.Ingress.Annotations["nginx.org/proxy-buffering"], "must be a boolean"))
 		} else {
 			cfgParams.ProxyBuffering = proxyBuffering
 		}
@@ -199,23 +246,23 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if hsts, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/hsts", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/hsts"), ingEx.Ingress.Annotations["nginx.org/hsts"], "must be a boolean"))
 		} else {
 			parsingErrors := false
 
 			hstsMaxAge, existsMA, err := GetMapKeyAsInt64(ingEx.Ingress.Annotations, "nginx.org/hsts-max-age", ingEx.Ingress)
 			if existsMA && err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/hsts-max-age"), ingEx.Ingress.Annotations["nginx.org/hsts-max-age"], "must be an integer"))
 				parsingErrors = true
 			}
 			hstsIncludeSubdomains, existsIS, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/hsts-include-subdomains", ingEx.Ingress)
 			if existsIS && err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/hsts-include-subdomains"), ingEx.Ingress.Annotations["nginx.org/hsts-include-subdomains"], "must be a boolean"))
 				parsingErrors = true
 			}
 			hstsBehindProxy, existsBP, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/hsts-behind-proxy", ingEx.Ingress)
 			if existsBP && err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/hsts-behind-proxy"), ingEx.Ingress.Annotations["nginx.org/hsts-behind-proxy"], "must be a boolean"))
 				parsingErrors = true
 			}
 
@@ -248,6 +295,32 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 		cfgParams.ProxyMaxTempFileSize = proxyMaxTempFileSize
 	}
 
+	if proxyNextUpstream, exists := ingEx.Ingress.Annotations["nginx.org/proxy-next-upstream"]; exists {
+		if parsedNextUpstream, err := ParseProxyNextUpstream(proxyNextUpstream); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/proxy-next-upstream"), proxyNextUpstream, err.Error()))
+		} else {
+			cfgParams.ProxyNextUpstream = parsedNextUpstream
+		}
+	}
+
+	if proxyNextUpstreamTimeout, exists := ingEx.Ingress.Annotations["nginx.org/proxy-next-upstream-timeout"]; exists {
+		if parsedTimeout, err := ParseTime(proxyNextUpstreamTimeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/proxy-next-upstream-timeout"), proxyNextUpstreamTimeout, err.Error()))
+		} else {
+			cfgParams.ProxyNextUpstreamTimeout = parsedTimeout
+		}
+	}
+
+	if proxyNextUpstreamTries, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/proxy-next-upstream-tries", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/proxy-next-upstream-tries"), ingEx.Ingress.Annotations["nginx.org/proxy-next-upstream-tries"], "must be an integer"))
+		} else if proxyNextUpstreamTries < 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/proxy-next-upstream-tries"), ingEx.Ingress.Annotations["nginx.org/proxy-next-upstream-tries"], "must not be negative"))
+		} else {
+			cfgParams.ProxyNextUpstreamTries = proxyNextUpstreamTries
+		}
+	}
+
 	if isPlus {
 		if jwtRealm, exists := ingEx.Ingress.Annotations["nginx.com/jwt-realm"]; exists {
 			cfgParams.JWTRealm = jwtRealm
@@ -263,7 +336,8 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 		}
 	}
 
-	ports, sslPorts := getServicesPorts(ingEx)
+	ports, sslPorts, portsErrs := getServicesPorts(ingEx)
+	allErrs = append(allErrs, portsErrs...)
 	if len(ports) > 0 {
 		cfgParams.Ports = ports
 	}
@@ -274,7 +348,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if keepalive, exists, err := GetMapKeyAsInt64(ingEx.Ingress.Annotations, "nginx.org/keepalive", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/keepalive"), ingEx.Ingress.Annotations["nginx.org/keepalive"], "must be an integer"))
 		} else {
 			cfgParams.Keepalive = keepalive
 		}
@@ -282,7 +356,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if maxFails, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/max-fails", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/max-fails"), ingEx.Ingress.Annotations["nginx.org/max-fails"], "must be an integer"))
 		} else {
 			cfgParams.MaxFails = maxFails
 		}
@@ -291,6 +365,134 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 	if failTimeout, exists := ingEx.Ingress.Annotations["nginx.org/fail-timeout"]; exists {
 		cfgParams.FailTimeout = failTimeout
 	}
+
+	if zoneAwareRouting, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/zone-aware-routing", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/zone-aware-routing"), ingEx.Ingress.Annotations["nginx.org/zone-aware-routing"], "must be a boolean"))
+		} else {
+			cfgParams.ZoneAwareRouting = zoneAwareRouting
+		}
+	}
+
+	if limitReqRate, exists := ingEx.Ingress.Annotations["nginx.org/limit-req-rate"]; exists {
+		if parsedRate, err := ParseRequestRate(limitReqRate); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-rate"), limitReqRate, err.Error()))
+		} else {
+			cfgParams.LimitReqRate = parsedRate
+		}
+	}
+
+	if limitReqKey, exists := ingEx.Ingress.Annotations["nginx.org/limit-req-key"]; exists {
+		if parsedKey, err := ParseStringWithVariables(limitReqKey); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-key"), limitReqKey, err.Error()))
+		} else {
+			cfgParams.LimitReqKey = parsedKey
+		}
+	}
+
+	if limitReqZoneSize, exists := ingEx.Ingress.Annotations["nginx.org/limit-req-zone-size"]; exists {
+		if parsedSize, err := ParseSize(limitReqZoneSize); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-zone-size"), limitReqZoneSize, err.Error()))
+		} else {
+			cfgParams.LimitReqZoneSize = parsedSize
+		}
+	}
+
+	if limitReqBurst, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/limit-req-burst", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-burst"), ingEx.Ingress.Annotations["nginx.org/limit-req-burst"], "must be an integer"))
+		} else if limitReqBurst < 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-burst"), ingEx.Ingress.Annotations["nginx.org/limit-req-burst"], "must not be negative"))
+		} else {
+			cfgParams.LimitReqBurst = limitReqBurst
+		}
+	}
+
+	if limitReqNoDelay, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/limit-req-nodelay", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-nodelay"), ingEx.Ingress.Annotations["nginx.org/limit-req-nodelay"], "must be a boolean"))
+		} else {
+			cfgParams.LimitReqNoDelay = limitReqNoDelay
+		}
+	}
+
+	if limitReqDryRun, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/limit-req-dry-run", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-dry-run"), ingEx.Ingress.Annotations["nginx.org/limit-req-dry-run"], "must be a boolean"))
+		} else {
+			cfgParams.LimitReqDryRun = limitReqDryRun
+		}
+	}
+
+	if limitReqLogLevel, exists := ingEx.Ingress.Annotations["nginx.org/limit-req-log-level"]; exists {
+		if parsedLogLevel, err := ParseLimitReqLogLevel(limitReqLogLevel); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-log-level"), limitReqLogLevel, err.Error()))
+		} else {
+			cfgParams.LimitReqLogLevel = parsedLogLevel
+		}
+	}
+
+	if limitReqRejectCode, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/limit-req-reject-code", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-reject-code"), ingEx.Ingress.Annotations["nginx.org/limit-req-reject-code"], "must be an integer"))
+		} else if limitReqRejectCode < 400 || limitReqRejectCode > 599 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/limit-req-reject-code"), ingEx.Ingress.Annotations["nginx.org/limit-req-reject-code"], "must be in the range 400-599"))
+		} else {
+			cfgParams.LimitReqRejectCode = limitReqRejectCode
+		}
+	}
+
+	if clientCertSecret, exists := ingEx.Ingress.Annotations[ClientCertificateSecretAnnotation]; exists {
+		cfgParams.ClientCertificateSecret = fmt.Sprintf("%v/%v", ingEx.Ingress.Namespace, clientCertSecret)
+	}
+
+	if clientCertVerify, exists := ingEx.Ingress.Annotations["nginx.org/client-certificate-verify"]; exists {
+		if parsedVerify, err := ParseSSLVerifyClient(clientCertVerify); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/client-certificate-verify"), clientCertVerify, err.Error()))
+		} else {
+			cfgParams.ClientCertificateVerify = parsedVerify
+		}
+	}
+
+	if clientCertVerifyDepth, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/client-certificate-verify-depth", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/client-certificate-verify-depth"), ingEx.Ingress.Annotations["nginx.org/client-certificate-verify-depth"], "must be an integer"))
+		} else if clientCertVerifyDepth < 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/client-certificate-verify-depth"), ingEx.Ingress.Annotations["nginx.org/client-certificate-verify-depth"], "must not be negative"))
+		} else {
+			cfgParams.ClientCertificateVerifyDepth = clientCertVerifyDepth
+		}
+	}
+
+	if clientCertForward, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/client-certificate-forward", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/client-certificate-forward"), ingEx.Ingress.Annotations["nginx.org/client-certificate-forward"], "must be a boolean"))
+		} else {
+			cfgParams.ClientCertificateForward = clientCertForward
+		}
+	}
+
+	if proxySSLTrustedCertSecret, exists := ingEx.Ingress.Annotations[ProxySSLTrustedCertSecretAnnotation]; exists {
+		cfgParams.ProxySSLTrustedCertSecret = fmt.Sprintf("%v/%v", ingEx.Ingress.Namespace, proxySSLTrustedCertSecret)
+	}
+
+	if proxySSLVerifyDepth, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/proxy-ssl-verify-depth", ingEx.Ingress); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/proxy-ssl-verify-depth"), ingEx.Ingress.Annotations["nginx.org/proxy-ssl-verify-depth"], "must be an integer"))
+		} else if proxySSLVerifyDepth < 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/proxy-ssl-verify-depth"), ingEx.Ingress.Annotations["nginx.org/proxy-ssl-verify-depth"], "must not be negative"))
+		} else {
+			cfgParams.ProxySSLVerifyDepth = proxySSLVerifyDepth
+		}
+	}
+
+	if proxySSLName, exists := ingEx.Ingress.Annotations["nginx.org/proxy-ssl-name"]; exists {
+		cfgParams.ProxySSLName = proxySSLName
+	}
+
+	if proxySSLSecret, exists := ingEx.Ingress.Annotations[ProxySSLSecretAnnotation]; exists {
+		cfgParams.ProxySSLSecret = fmt.Sprintf("%v/%v", ingEx.Ingress.Namespace, proxySSLSecret)
+	}
 	if cfgParams.MainEnableWallarm {
 		cfgParams.Wallarm = version1.NewWallarm()
 		if mode, exists := ingEx.Ingress.Annotations["wallarm.com/mode"]; exists {
@@ -329,7 +531,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 		}
 	}
 
-	return cfgParams
+	return cfgParams, allErrs
 }
 
 func getWebsocketServices(ingEx *IngressEx) map[string]bool {
@@ -344,20 +546,21 @@ func getWebsocketServices(ingEx *IngressEx) map[string]bool {
 	return wsServices
 }
 
-func getRewrites(ingEx *IngressEx) map[string]string {
+func getRewrites(ingEx *IngressEx) (map[string]string, field.ErrorList) {
 	rewrites := make(map[string]string)
+	allErrs := field.ErrorList{}
 
 	if services, exists := ingEx.Ingress.Annotations["nginx.org/rewrites"]; exists {
 		for _, svc := range strings.Split(services, ";") {
 			if serviceName, rewrite, err := parseRewrites(svc); err != nil {
-				glog.Errorf("In %v nginx.org/rewrites contains invalid declaration: %v, ignoring", ingEx.Ingress.Name, err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.org/rewrites"), services, err.Error()))
 			} else {
 				rewrites[serviceName] = rewrite
 			}
 		}
 	}
 
-	return rewrites
+	return rewrites, allErrs
 }
 
 func getSSLServices(ingEx *IngressEx) map[string]bool {
@@ -384,24 +587,26 @@ func getGrpcServices(ingEx *IngressEx) map[string]bool {
 	return grpcServices
 }
 
-func getSessionPersistenceServices(ingEx *IngressEx) map[string]string {
+func getSessionPersistenceServices(ingEx *IngressEx) (map[string]string, field.ErrorList) {
 	spServices := make(map[string]string)
+	allErrs := field.ErrorList{}
 
 	if services, exists := ingEx.Ingress.Annotations["nginx.com/sticky-cookie-services"]; exists {
 		for _, svc := range strings.Split(services, ";") {
 			if serviceName, sticky, err := parseStickyService(svc); err != nil {
-				glog.Errorf("In %v nginx.com/sticky-cookie-services contains invalid declaration: %v, ignoring", ingEx.Ingress.Name, err)
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key("nginx.com/sticky-cookie-services"), services, err.Error()))
 			} else {
 				spServices[serviceName] = sticky
 			}
 		}
 	}
 
-	return spServices
+	return spServices, allErrs
 }
 
-func getServicesPorts(ingEx *IngressEx) ([]int, []int) {
+func getServicesPorts(ingEx *IngressEx) ([]int, []int, field.ErrorList) {
 	ports := map[string][]int{}
+	allErrs := field.ErrorList{}
 
 	annotations := []string{
 		"nginx.org/listen-ports",
@@ -412,12 +617,7 @@ func getServicesPorts(ingEx *IngressEx) ([]int, []int) {
 		if values, exists := ingEx.Ingress.Annotations[annotation]; exists {
 			for _, value := range strings.Split(values, ",") {
 				if port, err := parsePort(value); err != nil {
-					glog.Errorf(
-						"In %v %s contains invalid declaration: %v, ignoring",
-						ingEx.Ingress.Name,
-						annotation,
-						err,
-					)
+					allErrs = append(allErrs, field.Invalid(annotationsPath.Key(annotation), values, err.Error()))
 				} else {
 					ports[annotation] = append(ports[annotation], port)
 				}
@@ -425,7 +625,7 @@ func getServicesPorts(ingEx *IngressEx) ([]int, []int) {
 		}
 	}
 
-	return ports[annotations[0]], ports[annotations[1]]
+	return ports[annotations[0]], ports[annotations[1]], allErrs
 }
 
 func filterMasterAnnotations(annotations map[string]string) []string {
@@ -4,6 +4,9 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestParseRewrites(t *testing.T) {
@@ -171,3 +174,105 @@ func TestParseSlowStart(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateIngressAnnotations(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"nginx.org/lb-method":         "least_conn",
+				"nginx.org/redirect-to-https": "true",
+				"nginx.org/max-fails":         "3",
+				"nginx.org/listen-ports":      "80,8080",
+				"nginx.org/limit-req-key":     "${binary_remote_addr}$http_x_user",
+			},
+		},
+	}
+
+	allErrs := ValidateIngressAnnotations(ing, false)
+	if len(allErrs) > 0 {
+		t.Errorf("ValidateIngressAnnotations() returned errors %v for valid input", allErrs)
+	}
+}
+
+func TestValidateIngressAnnotationsFails(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		isPlus      bool
+		expected    []string
+		msg         string
+	}{
+		{
+			annotations: map[string]string{
+				"nginx.org/redirect-to-https": "yes",
+			},
+			expected: []string{`metadata.annotations[nginx.org/redirect-to-https]: Invalid value: "yes": must be a boolean`},
+			msg:      "invalid bool",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/lb-method":  "least_time header",
+				"nginx.org/max-fails":  "three",
+				"nginx.com/slow-start": "10s",
+			},
+			expected: []string{
+				`metadata.annotations[nginx.org/lb-method]: Invalid value: "least_time header": Invalid load balancing method: "least_time header"`,
+				`metadata.annotations[nginx.com/slow-start]: Forbidden: requires NGINX Plus`,
+				`metadata.annotations[nginx.org/max-fails]: Invalid value: "three": must be an integer`,
+			},
+			msg: "several invalid annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/listen-ports": "80,http",
+			},
+			isPlus:   true,
+			expected: []string{`metadata.annotations[nginx.org/listen-ports]: Invalid value: "80,http": Unable to parse port as integer: strconv.ParseInt: parsing "http": invalid syntax`},
+			msg:      "invalid listen ports",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/rewrites":               "serviceName=tea-svc rewrite=/;serviceName=coffee-svc",
+				"nginx.com/sticky-cookie-services": "tea-svc srv_id expires=1h",
+			},
+			isPlus: true,
+			expected: []string{
+				`metadata.annotations[nginx.com/sticky-cookie-services]: Invalid value: "tea-svc srv_id expires=1h": Invalid sticky-cookie service format: [tea-svc]`,
+				`metadata.annotations[nginx.org/rewrites]: Invalid value: "serviceName=tea-svc rewrite=/;serviceName=coffee-svc": Invalid rewrite format: serviceName=coffee-svc`,
+			},
+			msg: "invalid rewrites and sticky cookie services",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/limit-req-key":   "${binary_remote_addr}$not_exists",
+				"nginx.org/limit-req-burst": "-1",
+			},
+			expected: []string{
+				`metadata.annotations[nginx.org/limit-req-key]: Invalid value: "${binary_remote_addr}$not_exists": Invalid string, the variable $not_exists is not supported`,
+				`metadata.annotations[nginx.org/limit-req-burst]: Invalid value: "-1": must not be negative`,
+			},
+			msg: "invalid rate limit key and burst",
+		},
+	}
+
+	for _, test := range tests {
+		ing := &networking.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        "cafe",
+				Namespace:   "default",
+				Annotations: test.annotations,
+			},
+		}
+
+		allErrs := ValidateIngressAnnotations(ing, test.isPlus)
+
+		var result []string
+		for _, err := range allErrs {
+			result = append(result, err.Error())
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("ValidateIngressAnnotations() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
@@ -28,6 +28,9 @@ type ConfigParams struct {
 	ProxyBuffers                  string
 	ProxyBufferSize               string
 	ProxyMaxTempFileSize          string
+	ProxyNextUpstream             string
+	ProxyNextUpstreamTimeout      string
+	ProxyNextUpstreamTries        int
 	ProxyProtocol                 bool
 	ProxyHideHeaders              []string
 	ProxyPassHeaders              []string
@@ -44,6 +47,8 @@ type ConfigParams struct {
 	Keepalive                     int64
 	MaxFails                      int
 	FailTimeout                   string
+	ZoneAwareRouting              bool
+	ZoneAwareRoutingMinEndpoints  int
 	HealthCheckEnabled            bool
 	HealthCheckMandatory          bool
 	HealthCheckMandatoryQueue     int64
@@ -76,6 +81,25 @@ type ConfigParams struct {
 	JWTToken    string
 	JWTLoginURL string
 
+	LimitReqRate       string
+	LimitReqKey        string
+	LimitReqZoneSize   string
+	LimitReqBurst      int
+	LimitReqNoDelay    bool
+	LimitReqDryRun     bool
+	LimitReqLogLevel   string
+	LimitReqRejectCode int
+
+	ClientCertificateSecret      string
+	ClientCertificateVerify      string
+	ClientCertificateVerifyDepth int
+	ClientCertificateForward     bool
+
+	ProxySSLTrustedCertSecret string
+	ProxySSLVerifyDepth       int
+	ProxySSLName              string
+	ProxySSLSecret            string
+
 	Ports    []int
 	SSLPorts []int
 
@@ -101,6 +125,7 @@ type StaticConfigParams struct {
 	NginxStatusAllowCIDRs          []string
 	NginxStatusPort                int
 	StubStatusOverUnixSocketForOSS bool
+	TLSPassthrough                 bool
 }
 
 // NewDefaultConfigParams creates a ConfigParams with default values.
@@ -121,12 +146,18 @@ func NewDefaultConfigParams() *ConfigParams {
 		MaxFails:                   1,
 		FailTimeout:                "10s",
 		LBMethod:                   "random two least_conn",
+		ZoneAwareRoutingMinEndpoints: 1,
 		MainErrorLogLevel:          "notice",
 		ResolverIPV6:               true,
 		MainKeepaliveTimeout:       "65s",
 		MainKeepaliveRequests:      100,
 		VariablesHashBucketSize:    256,
 		VariablesHashMaxSize:       1024,
+		LimitReqKey:                "${binary_remote_addr}",
+		LimitReqZoneSize:           "10m",
+		ClientCertificateVerify:              "on",
+		ClientCertificateVerifyDepth:         1,
+		ProxySSLVerifyDepth:                  1,
 		MainEnableWallarm:                    false,
 		MainWallarmUpstreamConnectAttempts:   10,
 		MainWallarmUpstreamReconnectInterval: "15s",
@@ -6,18 +6,22 @@ import (
 	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
-// ParseConfigMap parses ConfigMap into ConfigParams.
-func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
+var configMapDataPath = field.NewPath("data")
+
+// ParseConfigMap parses ConfigMap into ConfigParams. The keys with invalid values are ignored and returned as errors.
+func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) (*ConfigParams, field.ErrorList) {
 	cfgParams := NewDefaultConfigParams()
+	allErrs := field.ErrorList{}
 
 	if serverTokens, exists, err := GetMapKeyAsBool(cfgm.Data, "server-tokens", cfgm); exists {
 		if err != nil {
 			if nginxPlus {
 				cfgParams.ServerTokens = cfgm.Data["server-tokens"]
 			} else {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("server-tokens"), cfgm.Data["server-tokens"], "must be a boolean"))
 			}
 		} else {
 			cfgParams.ServerTokens = "off"
@@ -30,13 +34,13 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 	if lbMethod, exists := cfgm.Data["lb-method"]; exists {
 		if nginxPlus {
 			if parsedMethod, err := ParseLBMethodForPlus(lbMethod); err != nil {
-				glog.Errorf("Configmap %s/%s: Invalid value for the lb-method key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), lbMethod, err)
+				allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("lb-method"), lbMethod, err.Error()))
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
 		} else {
 			if parsedMethod, err := ParseLBMethod(lbMethod); err != nil {
-				glog.Errorf("Configmap %s/%s: Invalid value for the lb-method key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), lbMethod, err)
+				allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("lb-method"), lbMethod, err.Error()))
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
@@ -81,7 +85,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if HTTP2, exists, err := GetMapKeyAsBool(cfgm.Data, "http2", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("http2"), cfgm.Data["http2"], "must be a boolean"))
 		} else {
 			cfgParams.HTTP2 = HTTP2
 		}
@@ -89,7 +93,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if redirectToHTTPS, exists, err := GetMapKeyAsBool(cfgm.Data, "redirect-to-https", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("redirect-to-https"), cfgm.Data["redirect-to-https"], "must be a boolean"))
 		} else {
 			cfgParams.RedirectToHTTPS = redirectToHTTPS
 		}
@@ -97,7 +101,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if sslRedirect, exists, err := GetMapKeyAsBool(cfgm.Data, "ssl-redirect", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("ssl-redirect"), cfgm.Data["ssl-redirect"], "must be a boolean"))
 		} else {
 			cfgParams.SSLRedirect = sslRedirect
 		}
@@ -105,23 +109,23 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if hsts, exists, err := GetMapKeyAsBool(cfgm.Data, "hsts", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("hsts"), cfgm.Data["hsts"], "must be a boolean"))
 		} else {
 			parsingErrors := false
 
 			hstsMaxAge, existsMA, err := GetMapKeyAsInt64(cfgm.Data, "hsts-max-age", cfgm)
 			if existsMA && err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("hsts-max-age"), cfgm.Data["hsts-max-age"], "must be an integer"))
 				parsingErrors = true
 			}
 			hstsIncludeSubdomains, existsIS, err := GetMapKeyAsBool(cfgm.Data, "hsts-include-subdomains", cfgm)
 			if existsIS && err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("hsts-include-subdomains"), cfgm.Data["hsts-include-subdomains"], "must be a boolean"))
 				parsingErrors = true
 			}
 			hstsBehindProxy, existsBP, err := GetMapKeyAsBool(cfgm.Data, "hsts-behind-proxy", cfgm)
 			if existsBP && err != nil {
-				glog.Error(err)
+				allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("hsts-behind-proxy"), cfgm.Data["hsts-behind-proxy"], "must be a boolean"))
 				parsingErrors = true
 			}
 
@@ -144,7 +148,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if proxyProtocol, exists, err := GetMapKeyAsBool(cfgm.Data, "proxy-protocol", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("proxy-protocol"), cfgm.Data["proxy-protocol"], "must be a boolean"))
 		} else {
 			cfgParams.ProxyProtocol = proxyProtocol
 		}
@@ -164,7 +168,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if realIPRecursive, exists, err := GetMapKeyAsBool(cfgm.Data, "real-ip-recursive", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("real-ip-recursive"), cfgm.Data["real-ip-recursive"], "must be a boolean"))
 		} else {
 			cfgParams.RealIPRecursive = realIPRecursive
 		}
@@ -176,7 +180,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if sslPreferServerCiphers, exists, err := GetMapKeyAsBool(cfgm.Data, "ssl-prefer-server-ciphers", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("ssl-prefer-server-ciphers"), cfgm.Data["ssl-prefer-server-ciphers"], "must be a boolean"))
 		} else {
 			cfgParams.MainServerSSLPreferServerCiphers = sslPreferServerCiphers
 		}
@@ -197,7 +201,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if accessLogOff, exists, err := GetMapKeyAsBool(cfgm.Data, "access-log-off", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("access-log-off"), cfgm.Data["access-log-off"], "must be a boolean"))
 		} else {
 			cfgParams.MainAccessLogOff = accessLogOff
 		}
@@ -213,7 +217,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if proxyBuffering, exists, err := GetMapKeyAsBool(cfgm.Data, "proxy-buffering", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("proxy-buffering"), cfgm.Data["proxy-buffering"], "must be a boolean"))
 		} else {
 			cfgParams.ProxyBuffering = proxyBuffering
 		}
@@ -231,6 +235,32 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 		cfgParams.ProxyMaxTempFileSize = proxyMaxTempFileSize
 	}
 
+	if proxyNextUpstream, exists := cfgm.Data["proxy-next-upstream"]; exists {
+		if parsedNextUpstream, err := ParseProxyNextUpstream(proxyNextUpstream); err != nil {
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("proxy-next-upstream"), proxyNextUpstream, err.Error()))
+		} else {
+			cfgParams.ProxyNextUpstream = parsedNextUpstream
+		}
+	}
+
+	if proxyNextUpstreamTimeout, exists := cfgm.Data["proxy-next-upstream-timeout"]; exists {
+		if parsedTimeout, err := ParseTime(proxyNextUpstreamTimeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("proxy-next-upstream-timeout"), proxyNextUpstreamTimeout, err.Error()))
+		} else {
+			cfgParams.ProxyNextUpstreamTimeout = parsedTimeout
+		}
+	}
+
+	if proxyNextUpstreamTries, exists, err := GetMapKeyAsInt(cfgm.Data, "proxy-next-upstream-tries", cfgm); exists {
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("proxy-next-upstream-tries"), cfgm.Data["proxy-next-upstream-tries"], "must be an integer"))
+		} else if proxyNextUpstreamTries < 0 {
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("proxy-next-upstream-tries"), cfgm.Data["proxy-next-upstream-tries"], "must not be negative"))
+		} else {
+			cfgParams.ProxyNextUpstreamTries = proxyNextUpstreamTries
+		}
+	}
+
 	if mainMainSnippets, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "main-snippets", cfgm, "\n"); exists {
 		if err != nil {
 			glog.Error(err)
@@ -265,7 +295,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if _, exists, err := GetMapKeyAsInt(cfgm.Data, "worker-processes", cfgm); exists {
 		if err != nil && cfgm.Data["worker-processes"] != "auto" {
-			glog.Errorf("Configmap %s/%s: Invalid value for worker-processes key: must be an integer or the string 'auto', got %q", cfgm.GetNamespace(), cfgm.GetName(), cfgm.Data["worker-processes"])
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("worker-processes"), cfgm.Data["worker-processes"], "must be an integer or the string 'auto'"))
 		} else {
 			cfgParams.MainWorkerProcesses = cfgm.Data["worker-processes"]
 		}
@@ -289,7 +319,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if keepalive, exists, err := GetMapKeyAsInt64(cfgm.Data, "keepalive", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("keepalive"), cfgm.Data["keepalive"], "must be an integer"))
 		} else {
 			cfgParams.Keepalive = keepalive
 		}
@@ -297,7 +327,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if maxFails, exists, err := GetMapKeyAsInt(cfgm.Data, "max-fails", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("max-fails"), cfgm.Data["max-fails"], "must be an integer"))
 		} else {
 			cfgParams.MaxFails = maxFails
 		}
@@ -307,6 +337,14 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 		cfgParams.FailTimeout = failTimeout
 	}
 
+	if zoneAwareRoutingMinEndpoints, exists, err := GetMapKeyAsInt(cfgm.Data, "zone-aware-routing-min-endpoints", cfgm); exists {
+		if err != nil || zoneAwareRoutingMinEndpoints < 1 {
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("zone-aware-routing-min-endpoints"), cfgm.Data["zone-aware-routing-min-endpoints"], "must be a positive integer"))
+		} else {
+			cfgParams.ZoneAwareRoutingMinEndpoints = zoneAwareRoutingMinEndpoints
+		}
+	}
+
 	if mainTemplate, exists := cfgm.Data["main-template"]; exists {
 		cfgParams.MainTemplate = &mainTemplate
 	}
@@ -334,19 +372,19 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 			if nginxPlus {
 				cfgParams.ResolverAddresses = resolverAddresses
 			} else {
-				glog.Warning("ConfigMap key 'resolver-addresses' requires NGINX Plus")
+				allErrs = append(allErrs, field.Forbidden(configMapDataPath.Key("resolver-addresses"), "requires NGINX Plus"))
 			}
 		}
 	}
 
 	if resolverIpv6, exists, err := GetMapKeyAsBool(cfgm.Data, "resolver-ipv6", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("resolver-ipv6"), cfgm.Data["resolver-ipv6"], "must be a boolean"))
 		} else {
 			if nginxPlus {
 				cfgParams.ResolverIPV6 = resolverIpv6
 			} else {
-				glog.Warning("ConfigMap key 'resolver-ipv6' requires NGINX Plus")
+				allErrs = append(allErrs, field.Forbidden(configMapDataPath.Key("resolver-ipv6"), "requires NGINX Plus"))
 			}
 		}
 	}
@@ -355,7 +393,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 		if nginxPlus {
 			cfgParams.ResolverValid = resolverValid
 		} else {
-			glog.Warning("ConfigMap key 'resolver-valid' requires NGINX Plus")
+			allErrs = append(allErrs, field.Forbidden(configMapDataPath.Key("resolver-valid"), "requires NGINX Plus"))
 		}
 	}
 
@@ -363,7 +401,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 		if nginxPlus {
 			cfgParams.ResolverTimeout = resolverTimeout
 		} else {
-			glog.Warning("ConfigMap key 'resolver-timeout' requires NGINX Plus")
+			allErrs = append(allErrs, field.Forbidden(configMapDataPath.Key("resolver-timeout"), "requires NGINX Plus"))
 		}
 	}
 
@@ -373,7 +411,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if keepaliveRequests, exists, err := GetMapKeyAsInt64(cfgm.Data, "keepalive-requests", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("keepalive-requests"), cfgm.Data["keepalive-requests"], "must be an integer"))
 		} else {
 			cfgParams.MainKeepaliveRequests = keepaliveRequests
 		}
@@ -381,7 +419,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if varHashBucketSize, exists, err := GetMapKeyAsUint64(cfgm.Data, "variables-hash-bucket-size", cfgm, true); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("variables-hash-bucket-size"), cfgm.Data["variables-hash-bucket-size"], "must be a positive integer"))
 		} else {
 			cfgParams.VariablesHashBucketSize = varHashBucketSize
 		}
@@ -389,7 +427,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if varHashMaxSize, exists, err := GetMapKeyAsUint64(cfgm.Data, "variables-hash-max-size", cfgm, false); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("variables-hash-max-size"), cfgm.Data["variables-hash-max-size"], "must be a non-negative integer"))
 		} else {
 			cfgParams.VariablesHashMaxSize = varHashMaxSize
 		}
@@ -397,7 +435,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 
 	if enableWallarm, exists, err := GetMapKeyAsBool(cfgm.Data, "enable-wallarm", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("enable-wallarm"), cfgm.Data["enable-wallarm"], "must be a boolean"))
 		} else {
 			cfgParams.MainEnableWallarm = enableWallarm
 		}
@@ -407,7 +445,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 	}
 	if wallarmUpstreamConnectAttempts, exists, err := GetMapKeyAsInt(cfgm.Data, "wallarm-upstream-connect-attempts", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("wallarm-upstream-connect-attempts"), cfgm.Data["wallarm-upstream-connect-attempts"], "must be an integer"))
 		} else {
 			cfgParams.MainWallarmUpstreamConnectAttempts = wallarmUpstreamConnectAttempts
 		}
@@ -417,7 +455,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 	}
 	if wallarmUpstreamMaxFails, exists, err := GetMapKeyAsInt(cfgm.Data, "wallarm-upstream-max-fails", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("wallarm-upstream-max-fails"), cfgm.Data["wallarm-upstream-max-fails"], "must be an integer"))
 		} else {
 			cfgParams.MainWallarmUpstreamMaxFails = wallarmUpstreamMaxFails
 		}
@@ -431,7 +469,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 	}
 	if wallarmProcessTimeLimit, exists, err := GetMapKeyAsInt(cfgm.Data, "wallarm-process-time-limit", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			allErrs = append(allErrs, field.Invalid(configMapDataPath.Key("wallarm-process-time-limit"), cfgm.Data["wallarm-process-time-limit"], "must be an integer"))
 		} else {
 			cfgParams.MainWallarmProcessTimeLimit = wallarmProcessTimeLimit
 		}
@@ -446,7 +484,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool) *ConfigParams {
 		cfgParams.MainWallarmWorkerRlimitVmem = wallarmWorkerRlimitVmem
 	}
 
-	return cfgParams
+	return cfgParams, allErrs
 }
 
 // GenerateNginxMainConfig generates MainConfig.
@@ -457,6 +495,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		NginxStatusAllowCIDRs:          staticCfgParams.NginxStatusAllowCIDRs,
 		NginxStatusPort:                staticCfgParams.NginxStatusPort,
 		StubStatusOverUnixSocketForOSS: staticCfgParams.StubStatusOverUnixSocketForOSS,
+		TLSPassthrough:                 staticCfgParams.TLSPassthrough,
 		MainSnippets:                   config.MainMainSnippets,
 		HTTPSnippets:                   config.MainHTTPSnippets,
 		StreamSnippets:                 config.MainStreamSnippets,
@@ -473,6 +512,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		HTTP2:                          config.HTTP2,
 		ServerTokens:                   config.ServerTokens,
 		ProxyProtocol:                  config.ProxyProtocol,
+		SetRealIPFrom:                  config.SetRealIPFrom,
 		WorkerProcesses:                config.MainWorkerProcesses,
 		WorkerCPUAffinity:              config.MainWorkerCPUAffinity,
 		WorkerShutdownTimeout:          config.MainWorkerShutdownTimeout,
@@ -0,0 +1,42 @@
+package configs
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseConfigMapWithInvalidKeys(t *testing.T) {
+	cfgm := &v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "nginx-config",
+			Namespace: "nginx-ingress",
+		},
+		Data: map[string]string{
+			"proxy-buffering":  "yes",
+			"keepalive":        "32",
+			"worker-processes": "all",
+			"resolver-valid":   "10s",
+		},
+	}
+
+	cfgParams, allErrs := ParseConfigMap(cfgm, false)
+
+	if cfgParams.Keepalive != 32 {
+		t.Errorf("ParseConfigMap() returned keepalive %d but expected 32", cfgParams.Keepalive)
+	}
+	if !cfgParams.ProxyBuffering {
+		t.Errorf("ParseConfigMap() didn't keep the default value of proxy-buffering for an invalid value")
+	}
+
+	expectedFields := []string{"data[proxy-buffering]", "data[worker-processes]", "data[resolver-valid]"}
+	if len(allErrs) != len(expectedFields) {
+		t.Fatalf("ParseConfigMap() returned errors %v but expected errors for %v", allErrs, expectedFields)
+	}
+	for i, err := range allErrs {
+		if err.Field != expectedFields[i] {
+			t.Errorf("ParseConfigMap() returned an error for %s but expected for %s", err.Field, expectedFields[i])
+		}
+	}
+}
@@ -11,8 +11,8 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	api_v1 "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,32 +28,44 @@ const WildcardSecretName = "wildcard"
 // JWTKeyKey is the key of the data field of a Secret where the JWK must be stored.
 const JWTKeyKey = "jwk"
 
+// CAKey is the key of the data field of a Secret where the CA certificate bundle must be stored.
+const CAKey = "ca.crt"
+
+// CRLKey is the key of the data field of a Secret where the optional certificate revocation list is stored.
+const CRLKey = "ca.crl"
+
+// caFileNameSuffix is appended to the names of the files with CA certificates, so that they don't clash with the TLS
+// certificate and key files when a Secret stores both. The underscore is not allowed in the names of Secrets.
+const caFileNameSuffix = "_ca"
+
 // Configurator configures NGINX.
 type Configurator struct {
-	nginxManager       nginx.Manager
-	staticCfgParams    *StaticConfigParams
-	cfgParams          *ConfigParams
-	templateExecutor   *version1.TemplateExecutor
-	templateExecutorV2 *version2.TemplateExecutor
-	ingresses          map[string]*IngressEx
-	minions            map[string]map[string]bool
-	isWildcardEnabled  bool
-	isPlus             bool
+	nginxManager        nginx.Manager
+	staticCfgParams     *StaticConfigParams
+	cfgParams           *ConfigParams
+	templateExecutor    *version1.TemplateExecutor
+	templateExecutorV2  *version2.TemplateExecutor
+	ingresses           map[string]*IngressEx
+	minions             map[string]map[string]bool
+	tlsPassthroughPairs map[string]tlsPassthroughPair
+	isWildcardEnabled   bool
+	isPlus              bool
 }
 
 // NewConfigurator creates a new Configurator.
 func NewConfigurator(nginxManager nginx.Manager, staticCfgParams *StaticConfigParams, config *ConfigParams, templateExecutor *version1.TemplateExecutor,
 	templateExecutorV2 *version2.TemplateExecutor, isPlus bool, isWildcardEnabled bool) *Configurator {
 	cnf := Configurator{
-		nginxManager:       nginxManager,
-		staticCfgParams:    staticCfgParams,
-		cfgParams:          config,
-		ingresses:          make(map[string]*IngressEx),
-		templateExecutor:   templateExecutor,
-		templateExecutorV2: templateExecutorV2,
-		minions:            make(map[string]map[string]bool),
-		isPlus:             isPlus,
-		isWildcardEnabled:  isWildcardEnabled,
+		nginxManager:        nginxManager,
+		staticCfgParams:     staticCfgParams,
+		cfgParams:           config,
+		ingresses:           make(map[string]*IngressEx),
+		templateExecutor:    templateExecutor,
+		templateExecutorV2:  templateExecutorV2,
+		minions:             make(map[string]map[string]bool),
+		tlsPassthroughPairs: make(map[string]tlsPassthroughPair),
+		isPlus:              isPlus,
+		isWildcardEnabled:   isWildcardEnabled,
 	}
 	return &cnf
 }
@@ -79,9 +91,12 @@ func (cnf *Configurator) AddOrUpdateIngress(ingEx *IngressEx) error {
 func (cnf *Configurator) addOrUpdateIngress(ingEx *IngressEx) error {
 	pems := cnf.updateTLSSecrets(ingEx)
 	jwtKeyFileName := cnf.updateJWKSecret(ingEx)
+	clientCAFileName := cnf.updateClientCASecret(ingEx)
+	proxySSLFiles := cnf.updateProxySSLSecrets(ingEx)
 
 	isMinion := false
-	nginxCfg := generateNginxCfg(ingEx, pems, isMinion, cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(), jwtKeyFileName)
+	nginxCfg := generateNginxCfg(ingEx, pems, isMinion, cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(), jwtKeyFileName, clientCAFileName,
+		proxySSLFiles, cnf.staticCfgParams)
 
 	name := objectMetaToFileName(&ingEx.Ingress.ObjectMeta)
 	content, err := cnf.templateExecutor.ExecuteIngressConfigTemplate(&nginxCfg)
@@ -111,13 +126,17 @@ func (cnf *Configurator) AddOrUpdateMergeableIngress(mergeableIngs *MergeableIng
 func (cnf *Configurator) addOrUpdateMergeableIngress(mergeableIngs *MergeableIngresses) error {
 	masterPems := cnf.updateTLSSecrets(mergeableIngs.Master)
 	masterJwtKeyFileName := cnf.updateJWKSecret(mergeableIngs.Master)
+	masterClientCAFileName := cnf.updateClientCASecret(mergeableIngs.Master)
 	minionJwtKeyFileNames := make(map[string]string)
+	minionProxySSLFileNames := make(map[string]proxySSLFileNames)
 	for _, minion := range mergeableIngs.Minions {
 		minionName := objectMetaToFileName(&minion.Ingress.ObjectMeta)
 		minionJwtKeyFileNames[minionName] = cnf.updateJWKSecret(minion)
+		minionProxySSLFileNames[minionName] = cnf.updateProxySSLSecrets(minion)
 	}
 
-	nginxCfg := generateNginxCfgForMergeableIngresses(mergeableIngs, masterPems, masterJwtKeyFileName, minionJwtKeyFileNames, cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured())
+	nginxCfg := generateNginxCfgForMergeableIngresses(mergeableIngs, masterPems, masterJwtKeyFileName, masterClientCAFileName, minionJwtKeyFileNames,
+		minionProxySSLFileNames, cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(), cnf.staticCfgParams)
 
 	name := objectMetaToFileName(&mergeableIngs.Master.Ingress.ObjectMeta)
 	content, err := cnf.templateExecutor.ExecuteIngressConfigTemplate(&nginxCfg)
@@ -155,7 +174,28 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 		tlsPemFileName = cnf.addOrUpdateTLSSecret(virtualServerEx.TLSSecret)
 	}
 
-	vsCfg := generateVirtualServerConfig(virtualServerEx, tlsPemFileName, cnf.cfgParams, cnf.isPlus)
+	clientCAFileName := ""
+	if virtualServerEx.ClientCASecret != nil {
+		clientCAFileName = cnf.addOrUpdateCASecret(virtualServerEx.ClientCASecret)
+	}
+
+	jwtKeyFileNames := make(map[string]string)
+	for key, secret := range virtualServerEx.JWTKeys {
+		jwtKeyFileNames[key] = cnf.addOrUpdateJWKSecret(secret)
+	}
+
+	upstreamCAFileNames := make(map[string]string)
+	for key, secret := range virtualServerEx.UpstreamCASecrets {
+		upstreamCAFileNames[key] = cnf.addOrUpdateCASecret(secret)
+	}
+
+	upstreamPemFileNames := make(map[string]string)
+	for key, secret := range virtualServerEx.UpstreamTLSSecrets {
+		upstreamPemFileNames[key] = cnf.addOrUpdateTLSSecret(secret)
+	}
+
+	vsCfg := generateVirtualServerConfig(virtualServerEx, tlsPemFileName, clientCAFileName, jwtKeyFileNames, upstreamCAFileNames, upstreamPemFileNames,
+		cnf.cfgParams, cnf.isPlus, cnf.staticCfgParams)
 
 	name := getFileNameForVirtualServer(virtualServerEx.VirtualServer)
 	content, err := cnf.templateExecutorV2.ExecuteVirtualServerTemplate(&vsCfg)
@@ -167,6 +207,61 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 	return nil
 }
 
+// AddOrUpdateTransportServer adds or updates NGINX configuration for the TransportServer resource.
+func (cnf *Configurator) AddOrUpdateTransportServer(transportServerEx *TransportServerEx) error {
+	if err := cnf.addOrUpdateTransportServer(transportServerEx); err != nil {
+		return fmt.Errorf("Error adding or updating TransportServer %v/%v: %v", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name, err)
+	}
+
+	if err := cnf.nginxManager.Reload(); err != nil {
+		return fmt.Errorf("Error reloading NGINX for TransportServer %v/%v: %v", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name, err)
+	}
+
+	return nil
+}
+
+func (cnf *Configurator) addOrUpdateTransportServer(transportServerEx *TransportServerEx) error {
+	tsCfg := generateTransportServerConfig(transportServerEx, cnf.isPlus)
+
+	name := getFileNameForTransportServer(transportServerEx.TransportServer)
+	content, err := cnf.templateExecutorV2.ExecuteTransportServerTemplate(&tsCfg)
+	if err != nil {
+		return fmt.Errorf("Error generating TransportServer config: %v: %v", name, err)
+	}
+	cnf.nginxManager.CreateStreamConfig(name, content)
+
+	if transportServerEx.TransportServer.Spec.Listener.Protocol == conf_v1alpha1.TLSPassthroughProtocol {
+		cnf.tlsPassthroughPairs[name] = tlsPassthroughPair{
+			Host:       transportServerEx.TransportServer.Spec.Host,
+			UnixSocket: tsCfg.Server.UnixSocket,
+		}
+		return cnf.updateTLSPassthroughHostsConfig()
+	}
+
+	// the TransportServer might have been a TLS Passthrough TransportServer before the update
+	if _, exists := cnf.tlsPassthroughPairs[name]; exists {
+		delete(cnf.tlsPassthroughPairs, name)
+		return cnf.updateTLSPassthroughHostsConfig()
+	}
+
+	return nil
+}
+
+func (cnf *Configurator) updateTLSPassthroughHostsConfig() error {
+	cfg, duplicatedHosts := generateTLSPassthroughHostsConfig(cnf.tlsPassthroughPairs)
+	for _, host := range duplicatedHosts {
+		glog.Warningf("Host %v is used by more than one TLS Passthrough TransportServer", host)
+	}
+
+	content, err := cnf.templateExecutorV2.ExecuteTLSPassthroughHostsTemplate(cfg)
+	if err != nil {
+		return fmt.Errorf("Error generating TLS Passthrough hosts config: %v", err)
+	}
+	cnf.nginxManager.CreateTLSPassthroughHostsConfig(content)
+
+	return nil
+}
+
 func (cnf *Configurator) updateTLSSecrets(ingEx *IngressEx) map[string]string {
 	pems := make(map[string]string)
 
@@ -209,8 +304,103 @@ func (cnf *Configurator) addOrUpdateJWKSecret(secret *api_v1.Secret) string {
 	return cnf.nginxManager.CreateSecret(name, data, nginx.JWKSecretFileMode)
 }
 
-func (cnf *Configurator) AddOrUpdateJWKSecret(secret *api_v1.Secret) {
+// AddOrUpdateJWKSecret adds or updates a file with the content of the JWK secret and updates the configuration of the
+// Ingress and VirtualServer resources that use the secret.
+func (cnf *Configurator) AddOrUpdateJWKSecret(secret *api_v1.Secret, ingExes []IngressEx, mergeableIngresses []MergeableIngresses, virtualServerExes []*VirtualServerEx) error {
 	cnf.addOrUpdateJWKSecret(secret)
+
+	for i := range ingExes {
+		err := cnf.addOrUpdateIngress(&ingExes[i])
+		if err != nil {
+			return fmt.Errorf("Error adding or updating ingress %v/%v: %v", ingExes[i].Ingress.Namespace, ingExes[i].Ingress.Name, err)
+		}
+	}
+
+	for i := range mergeableIngresses {
+		err := cnf.addOrUpdateMergeableIngress(&mergeableIngresses[i])
+		if err != nil {
+			return fmt.Errorf("Error adding or updating mergeableIngress %v/%v: %v", mergeableIngresses[i].Master.Ingress.Namespace, mergeableIngresses[i].Master.Ingress.Name, err)
+		}
+	}
+
+	for _, vsEx := range virtualServerExes {
+		err := cnf.addOrUpdateVirtualServer(vsEx)
+		if err != nil {
+			return fmt.Errorf("Error adding or updating VirtualServer %v/%v: %v", vsEx.VirtualServer.Namespace, vsEx.VirtualServer.Name, err)
+		}
+	}
+
+	if err := cnf.nginxManager.Reload(); err != nil {
+		return fmt.Errorf("Error when reloading NGINX when updating Secret: %v", err)
+	}
+
+	return nil
+}
+
+func (cnf *Configurator) updateClientCASecret(ingEx *IngressEx) string {
+	if ingEx.ClientCASecret == nil {
+		return ""
+	}
+
+	return cnf.addOrUpdateCASecret(ingEx.ClientCASecret)
+}
+
+func (cnf *Configurator) updateProxySSLSecrets(ingEx *IngressEx) proxySSLFileNames {
+	var fileNames proxySSLFileNames
+
+	if ingEx.ProxySSLSecrets.TrustedCert != nil {
+		fileNames.TrustedCert = cnf.addOrUpdateCASecret(ingEx.ProxySSLSecrets.TrustedCert)
+		if len(ingEx.ProxySSLSecrets.TrustedCert.Data[CRLKey]) > 0 {
+			fileNames.CRL = fileNames.TrustedCert
+		}
+	}
+	if ingEx.ProxySSLSecrets.Client != nil {
+		fileNames.Client = cnf.addOrUpdateTLSSecret(ingEx.ProxySSLSecrets.Client)
+	}
+
+	return fileNames
+}
+
+// addOrUpdateCASecret writes the CA certificate bundle of the secret to a file. If the secret includes a certificate
+// revocation list, the list is appended to the bundle, so that the same file can be used for both the verification
+// of the client certificates and the revocation check.
+func (cnf *Configurator) addOrUpdateCASecret(secret *api_v1.Secret) string {
+	name := objectMetaToFileName(&secret.ObjectMeta) + caFileNameSuffix
+	data := generateCAFileContent(secret)
+	return cnf.nginxManager.CreateSecret(name, data, nginx.CASecretFileMode)
+}
+
+// AddOrUpdateCASecret adds or updates a file with the content of the CA secret and updates the configuration of the
+// Ingress and VirtualServer resources that use the secret.
+func (cnf *Configurator) AddOrUpdateCASecret(secret *api_v1.Secret, ingExes []IngressEx, mergeableIngresses []MergeableIngresses, virtualServerExes []*VirtualServerEx) error {
+	cnf.addOrUpdateCASecret(secret)
+
+	for i := range ingExes {
+		err := cnf.addOrUpdateIngress(&ingExes[i])
+		if err != nil {
+			return fmt.Errorf("Error adding or updating ingress %v/%v: %v", ingExes[i].Ingress.Namespace, ingExes[i].Ingress.Name, err)
+		}
+	}
+
+	for i := range mergeableIngresses {
+		err := cnf.addOrUpdateMergeableIngress(&mergeableIngresses[i])
+		if err != nil {
+			return fmt.Errorf("Error adding or updating mergeableIngress %v/%v: %v", mergeableIngresses[i].Master.Ingress.Namespace, mergeableIngresses[i].Master.Ingress.Name, err)
+		}
+	}
+
+	for _, vsEx := range virtualServerExes {
+		err := cnf.addOrUpdateVirtualServer(vsEx)
+		if err != nil {
+			return fmt.Errorf("Error adding or updating VirtualServer %v/%v: %v", vsEx.VirtualServer.Namespace, vsEx.VirtualServer.Name, err)
+		}
+	}
+
+	if err := cnf.nginxManager.Reload(); err != nil {
+		return fmt.Errorf("Error when reloading NGINX when updating Secret: %v", err)
+	}
+
+	return nil
 }
 
 // AddOrUpdateTLSSecret adds or updates a file with the content of the TLS secret.
@@ -277,10 +467,24 @@ func GenerateCertAndKeyFileContent(secret *api_v1.Secret) []byte {
 	return res.Bytes()
 }
 
+// generateCAFileContent generates a pem file content from the CA secret.
+func generateCAFileContent(secret *api_v1.Secret) []byte {
+	var res bytes.Buffer
+
+	res.Write(secret.Data[CAKey])
+	if crl, exists := secret.Data[CRLKey]; exists && len(crl) > 0 {
+		res.WriteString("\n")
+		res.Write(crl)
+	}
+
+	return res.Bytes()
+}
+
 // DeleteSecret deletes the file associated with the secret and the configuration files for Ingress and VirtualServer resources.
 // NGINX is reloaded only when the total number of the resources > 0.
 func (cnf *Configurator) DeleteSecret(key string, ingExes []IngressEx, mergeableIngresses []MergeableIngresses, virtualServerExes []*VirtualServerEx) error {
 	cnf.nginxManager.DeleteSecret(keyToFileName(key))
+	cnf.nginxManager.DeleteSecret(keyToFileName(key) + caFileNameSuffix)
 
 	for i := range ingExes {
 		err := cnf.addOrUpdateIngress(&ingExes[i])
@@ -339,6 +543,25 @@ func (cnf *Configurator) DeleteVirtualServer(key string) error {
 	return nil
 }
 
+// DeleteTransportServer deletes NGINX configuration for the TransportServer resource.
+func (cnf *Configurator) DeleteTransportServer(key string) error {
+	name := getFileNameForTransportServerFromKey(key)
+	cnf.nginxManager.DeleteStreamConfig(name)
+
+	if _, exists := cnf.tlsPassthroughPairs[name]; exists {
+		delete(cnf.tlsPassthroughPairs, name)
+		if err := cnf.updateTLSPassthroughHostsConfig(); err != nil {
+			return fmt.Errorf("Error when removing TransportServer %v: %v", key, err)
+		}
+	}
+
+	if err := cnf.nginxManager.Reload(); err != nil {
+		return fmt.Errorf("Error when removing TransportServer %v: %v", key, err)
+	}
+
+	return nil
+}
+
 // UpdateEndpoints updates endpoints in NGINX configuration for the Ingress resources.
 func (cnf *Configurator) UpdateEndpoints(ingExes []*IngressEx) error {
 	reloadPlus := false
@@ -435,13 +658,70 @@ func (cnf *Configurator) UpdateEndpointsForVirtualServers(virtualServerExes []*V
 }
 
 func (cnf *Configurator) updatePlusEndpointsForVirtualServer(virtualServerEx *VirtualServerEx) error {
-	serverCfg := createUpstreamServersConfig(cnf.cfgParams)
-	upstreamServers := createUpstreamServersForPlus(virtualServerEx)
+	if virtualServerEx.LocalZoneEndpoints != nil && hasZoneAwareRoutingUpstreams(virtualServerEx) {
+		return errBackupServersNotSupported
+	}
+
+	upstreams := createUpstreamsForPlus(virtualServerEx, cnf.cfgParams)
+	for _, upstream := range upstreams {
+		if hasMaxConnsServers(upstream) {
+			return fmt.Errorf("Couldn't update the endpoints for %v: %v", upstream.Name, errMaxConnsNotSupported)
+		}
+	}
 
-	for upstream, servers := range upstreamServers {
-		err := cnf.nginxManager.UpdateServersInPlus(upstream, servers, serverCfg)
+	for _, upstream := range upstreams {
+		serverCfg := createUpstreamServersConfigForPlus(upstream, cnf.cfgParams)
+		endpoints := createEndpointsFromUpstream(upstream)
+
+		err := cnf.nginxManager.UpdateServersInPlus(upstream.Name, endpoints, serverCfg)
 		if err != nil {
-			return fmt.Errorf("Couldn't update the endpoints for %v: %v", upstream, err)
+			return fmt.Errorf("Couldn't update the endpoints for %v: %v", upstream.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateEndpointsForTransportServers updates endpoints in NGINX configuration for the TransportServer resources.
+func (cnf *Configurator) UpdateEndpointsForTransportServers(transportServerExes []*TransportServerEx) error {
+	reloadPlus := false
+
+	for _, ts := range transportServerExes {
+		err := cnf.addOrUpdateTransportServer(ts)
+		if err != nil {
+			return fmt.Errorf("Error adding or updating TransportServer %v/%v: %v", ts.TransportServer.Namespace, ts.TransportServer.Name, err)
+		}
+
+		if cnf.isPlus {
+			err := cnf.updatePlusEndpointsForTransportServer(ts)
+			if err != nil {
+				glog.Warningf("Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
+				reloadPlus = true
+			}
+		}
+	}
+
+	if cnf.isPlus && !reloadPlus {
+		glog.V(3).Info("No need to reload nginx")
+		return nil
+	}
+
+	if err := cnf.nginxManager.Reload(); err != nil {
+		return fmt.Errorf("Error reloading NGINX when updating endpoints: %v", err)
+	}
+
+	return nil
+}
+
+func (cnf *Configurator) updatePlusEndpointsForTransportServer(transportServerEx *TransportServerEx) error {
+	tsCfg := generateTransportServerConfig(transportServerEx, cnf.isPlus)
+	for _, upstream := range tsCfg.Upstreams {
+		serverCfg := createStreamUpstreamServersConfigForPlus(upstream)
+		endpoints := createEndpointsFromStreamUpstream(upstream)
+
+		err := cnf.nginxManager.UpdateStreamServersInPlus(upstream.Name, endpoints, serverCfg)
+		if err != nil {
+			return fmt.Errorf("Couldn't update the endpoints for %v: %v", upstream.Name, err)
 		}
 	}
 
@@ -449,7 +729,11 @@ func (cnf *Configurator) updatePlusEndpointsForVirtualServer(virtualServerEx *Vi
 }
 
 func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
-	ingCfg := parseAnnotations(ingEx, cnf.cfgParams, cnf.isPlus)
+	ingCfg, _ := parseAnnotations(ingEx, cnf.cfgParams, cnf.isPlus)
+
+	if ingCfg.ZoneAwareRouting && ingEx.LocalZoneEndpoints != nil {
+		return errBackupServersNotSupported
+	}
 
 	cfg := nginx.ServerConfig{
 		MaxFails:    ingCfg.MaxFails,
@@ -570,15 +854,24 @@ func getFileNameForVirtualServerFromKey(key string) string {
 	return fmt.Sprintf("vs_%s", replaced)
 }
 
+func getFileNameForTransportServer(transportServer *conf_v1alpha1.TransportServer) string {
+	return fmt.Sprintf("ts_%s_%s", transportServer.Namespace, transportServer.Name)
+}
+
+func getFileNameForTransportServerFromKey(key string) string {
+	replaced := strings.Replace(key, "/", "_", -1)
+	return fmt.Sprintf("ts_%s", replaced)
+}
+
 // HasIngress checks if the Ingress resource is present in NGINX configuration.
-func (cnf *Configurator) HasIngress(ing *extensions.Ingress) bool {
+func (cnf *Configurator) HasIngress(ing *networking.Ingress) bool {
 	name := objectMetaToFileName(&ing.ObjectMeta)
 	_, exists := cnf.ingresses[name]
 	return exists
 }
 
 // HasMinion checks if the minion Ingress resource of the master is present in NGINX configuration.
-func (cnf *Configurator) HasMinion(master *extensions.Ingress, minion *extensions.Ingress) bool {
+func (cnf *Configurator) HasMinion(master *networking.Ingress, minion *networking.Ingress) bool {
 	masterName := objectMetaToFileName(&master.ObjectMeta)
 
 	if _, exists := cnf.minions[masterName]; !exists {
@@ -593,6 +886,12 @@ func (cnf *Configurator) IsResolverConfigured() bool {
 	return len(cnf.cfgParams.ResolverAddresses) != 0
 }
 
+// GetUpstreamsWithIgnoredGRPCType returns the upstreams of type grpc of a VirtualServer and its VirtualServerRoutes
+// that are configured as http, because gRPC requires TLS and HTTP/2.
+func (cnf *Configurator) GetUpstreamsWithIgnoredGRPCType(virtualServerEx *VirtualServerEx) []string {
+	return getUpstreamsWithIgnoredGRPCType(virtualServerEx, cnf.cfgParams.HTTP2)
+}
+
 // GetIngressCounts returns the total count of Ingress resources that are handled by the Ingress Controller grouped by their type
 func (cnf *Configurator) GetIngressCounts() map[string]int {
 	counters := map[string]int{
@@ -8,6 +8,7 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,7 +28,7 @@ func createTestConfigurator() (*Configurator, error) {
 		return nil, err
 	}
 
-	templateExecutorV2, err := version2.NewTemplateExecutor("version2/nginx-plus.virtualserver.tmpl")
+	templateExecutorV2, err := version2.NewTemplateExecutor("version2/nginx-plus.virtualserver.tmpl", "version2/nginx-plus.transportserver.tmpl")
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +190,63 @@ func TestUpdateEndpointsMergeableIngressFailsWithInvalidTemplate(t *testing.T) {
 	}
 }
 
+func TestUpdatePlusEndpointsForVirtualServer(t *testing.T) {
+	cnf, err := createTestConfigurator()
+	if err != nil {
+		t.Errorf("Failed to create a test configurator: %v", err)
+	}
+
+	maxConns := 10
+	tests := []struct {
+		upstream    conf_v1alpha1.Upstream
+		expectedErr bool
+		msg         string
+	}{
+		{
+			upstream: conf_v1alpha1.Upstream{
+				Name:    "tea",
+				Service: "tea-svc",
+				Port:    80,
+			},
+			expectedErr: false,
+			msg:         "upstream without max_conns",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{
+				Name:     "tea",
+				Service:  "tea-svc",
+				Port:     80,
+				MaxConns: &maxConns,
+			},
+			expectedErr: true,
+			msg:         "upstream with max_conns",
+		},
+	}
+
+	for _, test := range tests {
+		virtualServerEx := &VirtualServerEx{
+			VirtualServer: &conf_v1alpha1.VirtualServer{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "cafe",
+					Namespace: "default",
+				},
+				Spec: conf_v1alpha1.VirtualServerSpec{
+					Host:      "cafe.example.com",
+					Upstreams: []conf_v1alpha1.Upstream{test.upstream},
+				},
+			},
+			Endpoints: map[string][]string{
+				"default/tea-svc:80": {"10.0.0.20:80"},
+			},
+		}
+
+		err := cnf.updatePlusEndpointsForVirtualServer(virtualServerEx)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("updatePlusEndpointsForVirtualServer() returned the error %v for the case of %s", err, test.msg)
+		}
+	}
+}
+
 func TestGetVirtualServerConfigFileName(t *testing.T) {
 	vs := conf_v1alpha1.VirtualServer{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -215,3 +273,38 @@ func TestGetFileNameForVirtualServerFromKey(t *testing.T) {
 		t.Errorf("getFileNameForVirtualServerFromKey returned %v, but expected %v", result, expected)
 	}
 }
+
+func TestGenerateCAFileContent(t *testing.T) {
+	tests := []struct {
+		secret   *api_v1.Secret
+		expected string
+		msg      string
+	}{
+		{
+			secret: &api_v1.Secret{
+				Data: map[string][]byte{
+					CAKey: []byte("ca"),
+				},
+			},
+			expected: "ca",
+			msg:      "CA only",
+		},
+		{
+			secret: &api_v1.Secret{
+				Data: map[string][]byte{
+					CAKey:  []byte("ca"),
+					CRLKey: []byte("crl"),
+				},
+			},
+			expected: "ca\ncrl",
+			msg:      "CA with CRL",
+		},
+	}
+
+	for _, test := range tests {
+		result := string(generateCAFileContent(test.secret))
+		if result != test.expected {
+			t.Errorf("generateCAFileContent() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
@@ -8,20 +8,39 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	api_v1 "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 )
 
 const emptyHost = ""
 
 // IngressEx holds an Ingress along with the resources that are referenced in this Ingress.
 type IngressEx struct {
-	Ingress          *extensions.Ingress
+	Ingress          *networking.Ingress
 	TLSSecrets       map[string]*api_v1.Secret
 	JWTKey           JWTKey
+	ClientCASecret   *api_v1.Secret
+	ProxySSLSecrets  ProxySSLSecrets
 	Endpoints        map[string][]string
 	HealthChecks     map[string]*api_v1.Probe
 	ExternalNameSvcs map[string]bool
+	// LocalZoneEndpoints holds the endpoints from the zone of the Ingress Controller.
+	// It is nil if the zone of the Ingress Controller is unknown.
+	LocalZoneEndpoints map[string]bool
+}
+
+// ProxySSLSecrets holds the secrets for the TLS connections to the backends of an Ingress resource.
+type ProxySSLSecrets struct {
+	TrustedCert *api_v1.Secret
+	Client      *api_v1.Secret
+}
+
+// proxySSLFileNames holds the names of the files with the content of the ProxySSLSecrets.
+type proxySSLFileNames struct {
+	TrustedCert string
+	// CRL is the same file as TrustedCert. It is empty if the trusted cert Secret doesn't include a certificate revocation list.
+	CRL    string
+	Client string
 }
 
 // JWTKey represents a secret that holds JSON Web Key.
@@ -44,13 +63,16 @@ type MergeableIngresses struct {
 	Minions []*IngressEx
 }
 
-func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, baseCfgParams *ConfigParams, isPlus bool, isResolverConfigured bool, jwtKeyFileName string) version1.IngressNginxConfig {
-	cfgParams := parseAnnotations(ingEx, baseCfgParams, isPlus)
+func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, baseCfgParams *ConfigParams, isPlus bool, isResolverConfigured bool, jwtKeyFileName string,
+	clientCAFileName string, proxySSLFiles proxySSLFileNames, staticParams *StaticConfigParams) version1.IngressNginxConfig {
+	// the invalid annotations are ignored here and reported by the controller as events
+	cfgParams, _ := parseAnnotations(ingEx, baseCfgParams, isPlus)
 	wsServices := getWebsocketServices(ingEx)
-	spServices := getSessionPersistenceServices(ingEx)
-	rewrites := getRewrites(ingEx)
+	spServices, _ := getSessionPersistenceServices(ingEx)
+	rewrites, _ := getRewrites(ingEx)
 	sslServices := getSSLServices(ingEx)
 	grpcServices := getGrpcServices(ingEx)
+	proxySSL := generateIngressProxySSL(&cfgParams, proxySSLFiles)
 
 	upstreams := make(map[string]version1.Upstream)
 	healthChecks := make(map[string]version1.HealthCheck)
@@ -74,6 +96,27 @@ func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, b
 		}
 	}
 
+	var limitReqZones []version1.LimitReqZone
+	var limitReq *version1.LimitReq
+
+	if cfgParams.LimitReqRate != "" {
+		zoneName := getNameForLimitReqZone(ingEx.Ingress)
+		limitReqZones = append(limitReqZones, version1.LimitReqZone{
+			Name: zoneName,
+			Key:  cfgParams.LimitReqKey,
+			Size: cfgParams.LimitReqZoneSize,
+			Rate: cfgParams.LimitReqRate,
+		})
+		limitReq = &version1.LimitReq{
+			Zone:       zoneName,
+			Burst:      cfgParams.LimitReqBurst,
+			NoDelay:    cfgParams.LimitReqNoDelay,
+			DryRun:     cfgParams.LimitReqDryRun,
+			LogLevel:   cfgParams.LimitReqLogLevel,
+			RejectCode: cfgParams.LimitReqRejectCode,
+		}
+	}
+
 	var servers []version1.Server
 
 	for _, rule := range ingEx.Ingress.Spec.Rules {
@@ -92,6 +135,7 @@ func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, b
 			RedirectToHTTPS:       cfgParams.RedirectToHTTPS,
 			SSLRedirect:           cfgParams.SSLRedirect,
 			ProxyProtocol:         cfgParams.ProxyProtocol,
+			TLSPassthrough:        staticParams.TLSPassthrough,
 			HSTS:                  cfgParams.HSTS,
 			HSTSMaxAge:            cfgParams.HSTSMaxAge,
 			HSTSIncludeSubdomains: cfgParams.HSTSIncludeSubdomains,
@@ -115,6 +159,23 @@ func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, b
 			if pemFile == pemFileNameForMissingTLSSecret {
 				server.SSLCiphers = "NULL"
 			}
+
+			if !isMinion && cfgParams.ClientCertificateSecret != "" {
+				if clientCAFileName == "" {
+					// the client certificates can't be verified without the CA Secret, so we reject all TLS connections
+					server.SSLCiphers = "NULL"
+				} else {
+					server.ClientCertificate = &version1.ClientCertificate{
+						CAFile:            clientCAFileName,
+						Verify:            cfgParams.ClientCertificateVerify,
+						VerifyDepth:       cfgParams.ClientCertificateVerifyDepth,
+						ForwardToUpstream: cfgParams.ClientCertificateForward,
+					}
+					if ingEx.ClientCASecret != nil && len(ingEx.ClientCASecret.Data[CRLKey]) > 0 {
+						server.ClientCertificate.CRLFile = clientCAFileName
+					}
+				}
+			}
 		}
 
 		if !isMinion && ingEx.JWTKey.Name != "" {
@@ -133,7 +194,14 @@ func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, b
 			}
 		}
 
+		// the rate limiting of a Minion applies only to its locations, so that the Minions without their own
+		// rate limiting inherit the rate limiting of the Master from the server
+		if !isMinion {
+			server.LimitReq = limitReq
+		}
+
 		var locations []version1.Location
+		locationPaths := make(map[string]bool)
 		healthChecks := make(map[string]version1.HealthCheck)
 
 		rootLocation := false
@@ -164,27 +232,38 @@ func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, b
 				upstreams[upsName] = upstream
 			}
 
-			loc := createLocation(pathOrDefault(path.Path), upstreams[upsName], &cfgParams, wsServices[path.Backend.ServiceName], rewrites[path.Backend.ServiceName],
-				sslServices[path.Backend.ServiceName], grpcServices[path.Backend.ServiceName])
-			if isMinion && ingEx.JWTKey.Name != "" {
-				loc.JWTAuth = &version1.JWTAuth{
-					Key:   jwtKeyFileName,
-					Realm: cfgParams.JWTRealm,
-					Token: cfgParams.JWTToken,
+			for _, locPath := range generateLocationPaths(path, rewrites[path.Backend.ServiceName]) {
+				if locationPaths[locPath.path] {
+					glog.Warningf("Ingress %s/%s: location %v for host %v is duplicated, ignoring", ingEx.Ingress.Namespace, ingEx.Ingress.Name, locPath.path, rule.Host)
+					continue
 				}
-
-				if cfgParams.JWTLoginURL != "" {
-					loc.JWTAuth.RedirectLocationName = getNameForRedirectLocation(ingEx.Ingress)
-					server.JWTRedirectLocations = append(server.JWTRedirectLocations, version1.JWTRedirectLocation{
-						Name:     loc.JWTAuth.RedirectLocationName,
-						LoginURL: cfgParams.JWTLoginURL,
-					})
+				locationPaths[locPath.path] = true
+
+				loc := createLocation(locPath.path, upstreams[upsName], &cfgParams, wsServices[path.Backend.ServiceName], locPath.rewrite,
+					sslServices[path.Backend.ServiceName], proxySSL, grpcServices[path.Backend.ServiceName])
+				if isMinion && ingEx.JWTKey.Name != "" {
+					loc.JWTAuth = &version1.JWTAuth{
+						Key:   jwtKeyFileName,
+						Realm: cfgParams.JWTRealm,
+						Token: cfgParams.JWTToken,
+					}
+
+					if cfgParams.JWTLoginURL != "" {
+						loc.JWTAuth.RedirectLocationName = getNameForRedirectLocation(ingEx.Ingress)
+						server.JWTRedirectLocations = append(server.JWTRedirectLocations, version1.JWTRedirectLocation{
+							Name:     loc.JWTAuth.RedirectLocationName,
+							LoginURL: cfgParams.JWTLoginURL,
+						})
+					}
 				}
-			}
-			locations = append(locations, loc)
+				if isMinion {
+					loc.LimitReq = limitReq
+				}
+				locations = append(locations, loc)
 
-			if loc.Path == "/" {
-				rootLocation = true
+				if loc.Path == "/" {
+					rootLocation = true
+				}
 			}
 		}
 
@@ -192,7 +271,7 @@ func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, b
 			upsName := getNameForUpstream(ingEx.Ingress, emptyHost, ingEx.Ingress.Spec.Backend)
 
 			loc := createLocation(pathOrDefault("/"), upstreams[upsName], &cfgParams, wsServices[ingEx.Ingress.Spec.Backend.ServiceName], rewrites[ingEx.Ingress.Spec.Backend.ServiceName],
-				sslServices[ingEx.Ingress.Spec.Backend.ServiceName], grpcServices[ingEx.Ingress.Spec.Backend.ServiceName])
+				sslServices[ingEx.Ingress.Spec.Backend.ServiceName], proxySSL, grpcServices[ingEx.Ingress.Spec.Backend.ServiceName])
 			locations = append(locations, loc)
 
 			if cfgParams.HealthCheckEnabled {
@@ -227,30 +306,73 @@ func generateNginxCfg(ingEx *IngressEx, pems map[string]string, isMinion bool, b
 			Namespace:   ingEx.Ingress.Namespace,
 			Annotations: ingEx.Ingress.Annotations,
 		},
+		LimitReqZones: limitReqZones,
 	}
 }
 
-func createLocation(path string, upstream version1.Upstream, cfg *ConfigParams, websocket bool, rewrite string, ssl bool, grpc bool) version1.Location {
+func createLocation(path string, upstream version1.Upstream, cfg *ConfigParams, websocket bool, rewrite string, ssl bool, proxySSL *version1.ProxySSL,
+	grpc bool) version1.Location {
 	loc := version1.Location{
-		Path:                 path,
-		Upstream:             upstream,
-		ProxyConnectTimeout:  cfg.ProxyConnectTimeout,
-		ProxyReadTimeout:     cfg.ProxyReadTimeout,
-		ClientMaxBodySize:    cfg.ClientMaxBodySize,
-		Websocket:            websocket,
-		Rewrite:              rewrite,
-		SSL:                  ssl,
-		GRPC:                 grpc,
-		ProxyBuffering:       cfg.ProxyBuffering,
-		ProxyBuffers:         cfg.ProxyBuffers,
-		ProxyBufferSize:      cfg.ProxyBufferSize,
-		ProxyMaxTempFileSize: cfg.ProxyMaxTempFileSize,
-		LocationSnippets:     cfg.LocationSnippets,
+		Path:                     path,
+		Upstream:                 upstream,
+		ProxyConnectTimeout:      cfg.ProxyConnectTimeout,
+		ProxyReadTimeout:         cfg.ProxyReadTimeout,
+		ClientMaxBodySize:        cfg.ClientMaxBodySize,
+		Websocket:                websocket,
+		Rewrite:                  rewrite,
+		SSL:                      ssl,
+		GRPC:                     grpc,
+		ProxyBuffering:           cfg.ProxyBuffering,
+		ProxyBuffers:             cfg.ProxyBuffers,
+		ProxyBufferSize:          cfg.ProxyBufferSize,
+		ProxyMaxTempFileSize:     cfg.ProxyMaxTempFileSize,
+		ProxyNextUpstream:        cfg.ProxyNextUpstream,
+		ProxyNextUpstreamTimeout: cfg.ProxyNextUpstreamTimeout,
+		ProxyNextUpstreamTries:   cfg.ProxyNextUpstreamTries,
+		LocationSnippets:         cfg.LocationSnippets,
+	}
+
+	if ssl {
+		loc.ProxySSL = proxySSL
 	}
 
 	return loc
 }
 
+// generateIngressProxySSL generates the TLS configuration for the connections to the backends of the services
+// from the nginx.org/ssl-services annotation. If a referenced Secret doesn't exist or is invalid, no cipher is allowed,
+// so that NGINX fails to establish the connections instead of passing requests to a backend that it can't verify or authenticate to.
+func generateIngressProxySSL(cfgParams *ConfigParams, proxySSLFiles proxySSLFileNames) *version1.ProxySSL {
+	if cfgParams.ProxySSLTrustedCertSecret == "" && cfgParams.ProxySSLSecret == "" && cfgParams.ProxySSLName == "" {
+		return nil
+	}
+
+	proxySSL := &version1.ProxySSL{
+		Name: cfgParams.ProxySSLName,
+	}
+
+	if cfgParams.ProxySSLTrustedCertSecret != "" {
+		if proxySSLFiles.TrustedCert != "" {
+			proxySSL.TrustedCertificate = proxySSLFiles.TrustedCert
+			proxySSL.CRLFile = proxySSLFiles.CRL
+			proxySSL.VerifyDepth = cfgParams.ProxySSLVerifyDepth
+		} else {
+			proxySSL.Ciphers = "NULL"
+		}
+	}
+
+	if cfgParams.ProxySSLSecret != "" {
+		if proxySSLFiles.Client != "" {
+			proxySSL.Certificate = proxySSLFiles.Client
+			proxySSL.CertificateKey = proxySSLFiles.Client
+		} else {
+			proxySSL.Ciphers = "NULL"
+		}
+	}
+
+	return proxySSL
+}
+
 // upstreamRequiresQueue checks if the upstream requires a queue.
 // Mandatory Health Checks can cause nginx to return errors on reload, since all Upstreams start
 // Unhealthy. By adding a queue to the Upstream we can avoid returning errors, at the cost of a short delay.
@@ -263,7 +385,7 @@ func upstreamRequiresQueue(name string, ingEx *IngressEx, cfg *ConfigParams) (n
 	return 0, 0
 }
 
-func createUpstream(ingEx *IngressEx, name string, backend *extensions.IngressBackend, stickyCookie string, cfg *ConfigParams,
+func createUpstream(ingEx *IngressEx, name string, backend *networking.IngressBackend, stickyCookie string, cfg *ConfigParams,
 	isPlus bool, isResolverConfigured bool) version1.Upstream {
 	var ups version1.Upstream
 
@@ -274,6 +396,8 @@ func createUpstream(ingEx *IngressEx, name string, backend *extensions.IngressBa
 		ups = version1.NewUpstreamWithDefaultServer(name)
 	}
 
+	lbMethod := cfg.LBMethod
+
 	endps, exists := ingEx.Endpoints[backend.ServiceName+backend.ServicePort.String()]
 	if exists {
 		var upsServers []version1.UpstreamServer
@@ -284,6 +408,11 @@ func createUpstream(ingEx *IngressEx, name string, backend *extensions.IngressBa
 			endps = []string{}
 		}
 
+		var backupEndps map[string]bool
+		if cfg.ZoneAwareRouting && !isExternalNameSvc {
+			backupEndps, lbMethod = generateZoneAwareRouting(name, endps, ingEx.LocalZoneEndpoints, lbMethod, cfg.ZoneAwareRoutingMinEndpoints)
+		}
+
 		for _, endp := range endps {
 			addressport := strings.Split(endp, ":")
 			upsServers = append(upsServers, version1.UpstreamServer{
@@ -293,6 +422,7 @@ func createUpstream(ingEx *IngressEx, name string, backend *extensions.IngressBa
 				FailTimeout: cfg.FailTimeout,
 				SlowStart:   cfg.SlowStart,
 				Resolve:     isExternalNameSvc,
+				Backup:      backupEndps[endp],
 			})
 		}
 		if len(upsServers) > 0 {
@@ -300,7 +430,7 @@ func createUpstream(ingEx *IngressEx, name string, backend *extensions.IngressBa
 		}
 	}
 
-	ups.LBMethod = cfg.LBMethod
+	ups.LBMethod = lbMethod
 	return ups
 }
 
@@ -326,6 +456,48 @@ func headersToString(headers []api_v1.HTTPHeader) map[string]string {
 	return m
 }
 
+// locationPath is the path of an NGINX location generated for a path of an Ingress rule, along with the rewrite
+// of the location.
+type locationPath struct {
+	path    string
+	rewrite string
+}
+
+// generateLocationPaths generates the NGINX locations for a path of an Ingress rule according to its path type.
+// An Exact path becomes an exact match location. A Prefix path is matched element by element, so that /foo matches
+// /foo and /foo/bar, but not /foobar. Such a path becomes an exact match location for /foo and a prefix location
+// for /foo/. Because NGINX replaces the part of the URI that matches the prefix location, the rewrite of that
+// location gets a trailing slash as well. Any other path becomes a prefix location.
+func generateLocationPaths(path networking.HTTPIngressPath, rewrite string) []locationPath {
+	p := pathOrDefault(path.Path)
+
+	if path.PathType == nil {
+		return []locationPath{{path: p, rewrite: rewrite}}
+	}
+
+	switch *path.PathType {
+	case networking.PathTypeExact:
+		return []locationPath{{path: "= " + p, rewrite: rewrite}}
+	case networking.PathTypePrefix:
+		if p == "/" {
+			return []locationPath{{path: p, rewrite: rewrite}}
+		}
+
+		trimmedPath := strings.TrimRight(p, "/")
+		prefixRewrite := rewrite
+		if rewrite != "" && !strings.HasSuffix(rewrite, "/") && !strings.HasSuffix(p, "/") {
+			prefixRewrite = rewrite + "/"
+		}
+
+		return []locationPath{
+			{path: "= " + trimmedPath, rewrite: rewrite},
+			{path: trimmedPath + "/", rewrite: prefixRewrite},
+		}
+	}
+
+	return []locationPath{{path: p, rewrite: rewrite}}
+}
+
 func pathOrDefault(path string) string {
 	if path == "" {
 		return "/"
@@ -333,11 +505,15 @@ func pathOrDefault(path string) string {
 	return path
 }
 
-func getNameForUpstream(ing *extensions.Ingress, host string, backend *extensions.IngressBackend) string {
+func getNameForUpstream(ing *networking.Ingress, host string, backend *networking.IngressBackend) string {
 	return fmt.Sprintf("%v-%v-%v-%v-%v", ing.Namespace, ing.Name, host, backend.ServiceName, backend.ServicePort.String())
 }
 
-func getNameForRedirectLocation(ing *extensions.Ingress) string {
+func getNameForLimitReqZone(ing *networking.Ingress) string {
+	return fmt.Sprintf("ing_rl_%v_%v", ing.Namespace, ing.Name)
+}
+
+func getNameForRedirectLocation(ing *networking.Ingress) string {
 	return fmt.Sprintf("@login_url_%v-%v", ing.Namespace, ing.Name)
 }
 
@@ -362,12 +538,14 @@ func upstreamMapToSlice(upstreams map[string]version1.Upstream) []version1.Upstr
 }
 
 func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, masterPems map[string]string, masterJwtKeyFileName string,
-	minionJwtKeyFileNames map[string]string, baseCfgParams *ConfigParams, isPlus bool, isResolverConfigured bool) version1.IngressNginxConfig {
+	masterClientCAFileName string, minionJwtKeyFileNames map[string]string, minionProxySSLFileNames map[string]proxySSLFileNames, baseCfgParams *ConfigParams, isPlus bool, isResolverConfigured bool, staticParams *StaticConfigParams) version1.IngressNginxConfig {
 	var masterServer version1.Server
 	var locations []version1.Location
+	locationPaths := make(map[string]bool)
 	var upstreams []version1.Upstream
 	healthChecks := make(map[string]version1.HealthCheck)
 	var keepalive string
+	var limitReqZones []version1.LimitReqZone
 
 	removedAnnotations := filterMasterAnnotations(mergeableIngs.Master.Ingress.Annotations)
 	if len(removedAnnotations) != 0 {
@@ -376,12 +554,14 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 	}
 
 	isMinion := false
-	masterNginxCfg := generateNginxCfg(mergeableIngs.Master, masterPems, isMinion, baseCfgParams, isPlus, isResolverConfigured, masterJwtKeyFileName)
+	masterNginxCfg := generateNginxCfg(mergeableIngs.Master, masterPems, isMinion, baseCfgParams, isPlus, isResolverConfigured, masterJwtKeyFileName, masterClientCAFileName,
+		proxySSLFileNames{}, staticParams)
 
 	masterServer = masterNginxCfg.Servers[0]
 	masterServer.Locations = []version1.Location{}
 
 	upstreams = append(upstreams, masterNginxCfg.Upstreams...)
+	limitReqZones = append(limitReqZones, masterNginxCfg.LimitReqZones...)
 
 	if masterNginxCfg.Keepalive != "" {
 		keepalive = masterNginxCfg.Keepalive
@@ -402,12 +582,20 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 		}
 
 		pems := make(map[string]string)
-		jwtKeyFileName := minionJwtKeyFileNames[objectMetaToFileName(&minion.Ingress.ObjectMeta)]
+		minionName := objectMetaToFileName(&minion.Ingress.ObjectMeta)
+		jwtKeyFileName := minionJwtKeyFileNames[minionName]
 		isMinion := true
-		nginxCfg := generateNginxCfg(minion, pems, isMinion, baseCfgParams, isPlus, isResolverConfigured, jwtKeyFileName)
+		nginxCfg := generateNginxCfg(minion, pems, isMinion, baseCfgParams, isPlus, isResolverConfigured, jwtKeyFileName, "",
+			minionProxySSLFileNames[minionName], staticParams)
 
 		for _, server := range nginxCfg.Servers {
 			for _, loc := range server.Locations {
+				if locationPaths[loc.Path] {
+					glog.Warningf("Ingress %s/%s: location %v is already defined by another minion, ignoring", minion.Ingress.Namespace, minion.Ingress.Name, loc.Path)
+					continue
+				}
+				locationPaths[loc.Path] = true
+
 				loc.MinionIngress = &nginxCfg.Ingress
 				locations = append(locations, loc)
 			}
@@ -418,15 +606,17 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 		}
 
 		upstreams = append(upstreams, nginxCfg.Upstreams...)
+		limitReqZones = append(limitReqZones, nginxCfg.LimitReqZones...)
 	}
 
 	masterServer.HealthChecks = healthChecks
 	masterServer.Locations = locations
 
 	return version1.IngressNginxConfig{
-		Servers:   []version1.Server{masterServer},
-		Upstreams: upstreams,
-		Keepalive: keepalive,
-		Ingress:   masterNginxCfg.Ingress,
+		Servers:       []version1.Server{masterServer},
+		Upstreams:     upstreams,
+		Keepalive:     keepalive,
+		Ingress:       masterNginxCfg.Ingress,
+		LimitReqZones: limitReqZones,
 	}
 }
@@ -5,8 +5,8 @@ import (
 	"testing"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	"k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -21,7 +21,7 @@ func TestGenerateNginxCfg(t *testing.T) {
 		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
 	}
 
-	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "")
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "", proxySSLFileNames{}, &StaticConfigParams{})
 
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateNginxCfg returned \n%v,  but expected \n%v", result, expected)
@@ -62,7 +62,7 @@ func TestGenerateNginxCfgForJWT(t *testing.T) {
 		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
 	}
 
-	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, true, false, "/etc/nginx/secrets/default-cafe-jwk")
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, true, false, "/etc/nginx/secrets/default-cafe-jwk", "", proxySSLFileNames{}, &StaticConfigParams{})
 
 	if !reflect.DeepEqual(result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth) {
 		t.Errorf("generateNginxCfg returned \n%v,  but expected \n%v", result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth)
@@ -72,6 +72,49 @@ func TestGenerateNginxCfgForJWT(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgForLimitReq(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-rate"] = "10r/s"
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-burst"] = "20"
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-nodelay"] = "true"
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-reject-code"] = "429"
+
+	configParams := NewDefaultConfigParams()
+
+	expectedZones := []version1.LimitReqZone{
+		{
+			Name: "ing_rl_default_cafe-ingress",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+	}
+	expectedLimitReq := &version1.LimitReq{
+		Zone:       "ing_rl_default_cafe-ingress",
+		Burst:      20,
+		NoDelay:    true,
+		RejectCode: 429,
+	}
+
+	pems := map[string]string{
+		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
+	}
+
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "", proxySSLFileNames{}, &StaticConfigParams{})
+
+	if !reflect.DeepEqual(result.LimitReqZones, expectedZones) {
+		t.Errorf("generateNginxCfg returned \n%v,  but expected \n%v", result.LimitReqZones, expectedZones)
+	}
+	if !reflect.DeepEqual(result.Servers[0].LimitReq, expectedLimitReq) {
+		t.Errorf("generateNginxCfg returned \n%v,  but expected \n%v", result.Servers[0].LimitReq, expectedLimitReq)
+	}
+	for _, loc := range result.Servers[0].Locations {
+		if loc.LimitReq != nil {
+			t.Errorf("generateNginxCfg returned LimitReq %v for location %v, but expected nil", loc.LimitReq, loc.Path)
+		}
+	}
+}
+
 func TestGenerateNginxCfgWithMissingTLSSecret(t *testing.T) {
 	cafeIngressEx := createCafeIngressEx()
 	configParams := NewDefaultConfigParams()
@@ -79,7 +122,7 @@ func TestGenerateNginxCfgWithMissingTLSSecret(t *testing.T) {
 		"cafe.example.com": pemFileNameForMissingTLSSecret,
 	}
 
-	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "")
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "", proxySSLFileNames{}, &StaticConfigParams{})
 
 	expectedCiphers := "NULL"
 	resultCiphers := result.Servers[0].SSLCiphers
@@ -88,6 +131,128 @@ func TestGenerateNginxCfgWithMissingTLSSecret(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgForClientCertificate(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/client-certificate-secret"] = "cafe-ca"
+	cafeIngressEx.Ingress.Annotations["nginx.org/client-certificate-verify"] = "optional"
+	cafeIngressEx.Ingress.Annotations["nginx.org/client-certificate-verify-depth"] = "2"
+	cafeIngressEx.Ingress.Annotations["nginx.org/client-certificate-forward"] = "true"
+	cafeIngressEx.ClientCASecret = &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe-ca",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			CAKey:  []byte("ca"),
+			CRLKey: []byte("crl"),
+		},
+	}
+
+	configParams := NewDefaultConfigParams()
+	pems := map[string]string{
+		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
+	}
+
+	expected := &version1.ClientCertificate{
+		CAFile:            "/etc/nginx/secrets/default-cafe-ca",
+		CRLFile:           "/etc/nginx/secrets/default-cafe-ca",
+		Verify:            "optional",
+		VerifyDepth:       2,
+		ForwardToUpstream: true,
+	}
+
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "/etc/nginx/secrets/default-cafe-ca", proxySSLFileNames{}, &StaticConfigParams{})
+
+	if !reflect.DeepEqual(result.Servers[0].ClientCertificate, expected) {
+		t.Errorf("generateNginxCfg returned \n%v,  but expected \n%v", result.Servers[0].ClientCertificate, expected)
+	}
+	if result.Servers[0].SSLCiphers != "" {
+		t.Errorf("generateNginxCfg returned SSLCiphers %v,  but expected an empty string", result.Servers[0].SSLCiphers)
+	}
+}
+
+func TestGenerateNginxCfgWithMissingClientCASecret(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/client-certificate-secret"] = "cafe-ca"
+
+	configParams := NewDefaultConfigParams()
+	pems := map[string]string{
+		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
+	}
+
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "", proxySSLFileNames{}, &StaticConfigParams{})
+
+	if result.Servers[0].ClientCertificate != nil {
+		t.Errorf("generateNginxCfg returned ClientCertificate %v,  but expected nil", result.Servers[0].ClientCertificate)
+	}
+	expectedCiphers := "NULL"
+	if result.Servers[0].SSLCiphers != expectedCiphers {
+		t.Errorf("generateNginxCfg returned SSLCiphers %v,  but expected %v", result.Servers[0].SSLCiphers, expectedCiphers)
+	}
+}
+
+func TestGenerateNginxCfgWithProxySSL(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/ssl-services"] = "coffee-svc"
+	cafeIngressEx.Ingress.Annotations["nginx.org/proxy-ssl-trusted-cert-secret"] = "coffee-ca"
+	cafeIngressEx.Ingress.Annotations["nginx.org/proxy-ssl-verify-depth"] = "2"
+	cafeIngressEx.Ingress.Annotations["nginx.org/proxy-ssl-name"] = "coffee.example.com"
+	cafeIngressEx.Ingress.Annotations["nginx.org/proxy-ssl-secret"] = "coffee-client-secret"
+
+	configParams := NewDefaultConfigParams()
+	pems := map[string]string{
+		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
+	}
+	proxySSLFiles := proxySSLFileNames{
+		TrustedCert: "/etc/nginx/secrets/default-coffee-ca_ca",
+		CRL:         "/etc/nginx/secrets/default-coffee-ca_ca",
+		Client:      "/etc/nginx/secrets/default-coffee-client-secret",
+	}
+
+	expected := &version1.ProxySSL{
+		TrustedCertificate: "/etc/nginx/secrets/default-coffee-ca_ca",
+		CRLFile:            "/etc/nginx/secrets/default-coffee-ca_ca",
+		VerifyDepth:        2,
+		Name:               "coffee.example.com",
+		Certificate:        "/etc/nginx/secrets/default-coffee-client-secret",
+		CertificateKey:     "/etc/nginx/secrets/default-coffee-client-secret",
+	}
+
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "", proxySSLFiles, &StaticConfigParams{})
+
+	for _, loc := range result.Servers[0].Locations {
+		if loc.Path == "/coffee" && !reflect.DeepEqual(loc.ProxySSL, expected) {
+			t.Errorf("generateNginxCfg returned ProxySSL \n%+v for %v,  but expected \n%+v", loc.ProxySSL, loc.Path, expected)
+		}
+		if loc.Path == "/tea" && loc.ProxySSL != nil {
+			t.Errorf("generateNginxCfg returned ProxySSL %+v for %v,  but expected nil", loc.ProxySSL, loc.Path)
+		}
+	}
+}
+
+func TestGenerateNginxCfgWithMissingProxySSLSecrets(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/ssl-services"] = "coffee-svc"
+	cafeIngressEx.Ingress.Annotations["nginx.org/proxy-ssl-trusted-cert-secret"] = "coffee-ca"
+
+	configParams := NewDefaultConfigParams()
+	pems := map[string]string{
+		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
+	}
+
+	expected := &version1.ProxySSL{
+		Ciphers: "NULL",
+	}
+
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "", proxySSLFileNames{}, &StaticConfigParams{})
+
+	for _, loc := range result.Servers[0].Locations {
+		if loc.Path == "/coffee" && !reflect.DeepEqual(loc.ProxySSL, expected) {
+			t.Errorf("generateNginxCfg returned ProxySSL \n%+v for %v,  but expected \n%+v", loc.ProxySSL, loc.Path, expected)
+		}
+	}
+}
+
 func TestGenerateNginxCfgWithWildcardTLSSecret(t *testing.T) {
 	cafeIngressEx := createCafeIngressEx()
 	configParams := NewDefaultConfigParams()
@@ -95,7 +260,7 @@ func TestGenerateNginxCfgWithWildcardTLSSecret(t *testing.T) {
 		"cafe.example.com": pemFileNameForWildcardTLSSecret,
 	}
 
-	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "")
+	result := generateNginxCfg(&cafeIngressEx, pems, false, configParams, false, false, "", "", proxySSLFileNames{}, &StaticConfigParams{})
 
 	resultServer := result.Servers[0]
 	if !reflect.DeepEqual(resultServer.SSLCertificate, pemFileNameForWildcardTLSSecret) {
@@ -121,6 +286,74 @@ func TestPathOrDefaultReturnActual(t *testing.T) {
 	}
 }
 
+func TestGenerateLocationPaths(t *testing.T) {
+	exact := networking.PathTypeExact
+	prefix := networking.PathTypePrefix
+	implementationSpecific := networking.PathTypeImplementationSpecific
+
+	tests := []struct {
+		path     networking.HTTPIngressPath
+		rewrite  string
+		expected []locationPath
+		msg      string
+	}{
+		{
+			path:     networking.HTTPIngressPath{Path: "/tea"},
+			expected: []locationPath{{path: "/tea"}},
+			msg:      "path without a path type",
+		},
+		{
+			path:     networking.HTTPIngressPath{Path: "/tea", PathType: &implementationSpecific},
+			expected: []locationPath{{path: "/tea"}},
+			msg:      "implementation specific path",
+		},
+		{
+			path:     networking.HTTPIngressPath{Path: "/tea", PathType: &exact},
+			rewrite:  "/",
+			expected: []locationPath{{path: "= /tea", rewrite: "/"}},
+			msg:      "exact path",
+		},
+		{
+			path:     networking.HTTPIngressPath{PathType: &prefix},
+			expected: []locationPath{{path: "/"}},
+			msg:      "prefix root path",
+		},
+		{
+			path: networking.HTTPIngressPath{Path: "/tea", PathType: &prefix},
+			expected: []locationPath{
+				{path: "= /tea"},
+				{path: "/tea/"},
+			},
+			msg: "prefix path",
+		},
+		{
+			path:    networking.HTTPIngressPath{Path: "/tea/", PathType: &prefix},
+			rewrite: "/green",
+			expected: []locationPath{
+				{path: "= /tea", rewrite: "/green"},
+				{path: "/tea/", rewrite: "/green"},
+			},
+			msg: "prefix path with a trailing slash and a rewrite",
+		},
+		{
+			path:    networking.HTTPIngressPath{Path: "/tea", PathType: &prefix},
+			rewrite: "/green",
+			expected: []locationPath{
+				{path: "= /tea", rewrite: "/green"},
+				{path: "/tea/", rewrite: "/green/"},
+			},
+			msg: "prefix path with a rewrite",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateLocationPaths(test.path, test.rewrite)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateLocationPaths() returned %+v but expected %+v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
 func createExpectedConfigForCafeIngressEx() version1.IngressNginxConfig {
 	coffeeUpstream := version1.Upstream{
 		Name:     "default-cafe-ingress-cafe.example.com-coffee-svc-80",
@@ -196,7 +429,7 @@ func createExpectedConfigForCafeIngressEx() version1.IngressNginxConfig {
 }
 
 func createCafeIngressEx() IngressEx {
-	cafeIngress := v1beta1.Ingress{
+	cafeIngress := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "cafe-ingress",
 			Namespace: "default",
@@ -204,29 +437,29 @@ func createCafeIngressEx() IngressEx {
 				"kubernetes.io/ingress.class": "nginx",
 			},
 		},
-		Spec: v1beta1.IngressSpec{
-			TLS: []v1beta1.IngressTLS{
+		Spec: networking.IngressSpec{
+			TLS: []networking.IngressTLS{
 				{
 					Hosts:      []string{"cafe.example.com"},
 					SecretName: "cafe-secret",
 				},
 			},
-			Rules: []v1beta1.IngressRule{
+			Rules: []networking.IngressRule{
 				{
 					Host: "cafe.example.com",
-					IngressRuleValue: v1beta1.IngressRuleValue{
-						HTTP: &v1beta1.HTTPIngressRuleValue{
-							Paths: []v1beta1.HTTPIngressPath{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
 								{
 									Path: "/coffee",
-									Backend: v1beta1.IngressBackend{
+									Backend: networking.IngressBackend{
 										ServiceName: "coffee-svc",
 										ServicePort: intstr.FromString("80"),
 									},
 								},
 								{
 									Path: "/tea",
-									Backend: v1beta1.IngressBackend{
+									Backend: networking.IngressBackend{
 										ServiceName: "tea-svc",
 										ServicePort: intstr.FromString("80"),
 									},
@@ -262,7 +495,7 @@ func TestGenerateNginxCfgForMergeableIngresses(t *testing.T) {
 	minionJwtKeyFileNames := make(map[string]string)
 	configParams := NewDefaultConfigParams()
 
-	result := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterPems, "", minionJwtKeyFileNames, configParams, false, false)
+	result := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterPems, "", "", minionJwtKeyFileNames, nil, configParams, false, false, &StaticConfigParams{})
 
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateNginxCfgForMergeableIngresses returned \n%v,  but expected \n%v", result, expected)
@@ -331,7 +564,7 @@ func TestGenerateNginxCfgForMergeableIngressesForJWT(t *testing.T) {
 	configParams := NewDefaultConfigParams()
 	isPlus := true
 
-	result := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterPems, "/etc/nginx/secrets/default-cafe-jwk", minionJwtKeyFileNames, configParams, isPlus, false)
+	result := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterPems, "/etc/nginx/secrets/default-cafe-jwk", "", minionJwtKeyFileNames, nil, configParams, isPlus, false, &StaticConfigParams{})
 
 	if !reflect.DeepEqual(result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth) {
 		t.Errorf("generateNginxCfgForMergeableIngresses returned \n%v,  but expected \n%v", result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth)
@@ -344,8 +577,58 @@ func TestGenerateNginxCfgForMergeableIngressesForJWT(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgForMergeableIngressesForLimitReq(t *testing.T) {
+	mergeableIngresses := createMergeableCafeIngress()
+	mergeableIngresses.Master.Ingress.Annotations["nginx.org/limit-req-rate"] = "10r/s"
+	mergeableIngresses.Minions[0].Ingress.Annotations["nginx.org/limit-req-rate"] = "100r/m"
+	mergeableIngresses.Minions[0].Ingress.Annotations["nginx.org/limit-req-dry-run"] = "true"
+
+	expectedZones := []version1.LimitReqZone{
+		{
+			Name: "ing_rl_default_cafe-ingress-master",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+		{
+			Name: "ing_rl_default_cafe-ingress-coffee-minion",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "100r/m",
+		},
+	}
+	expectedServerLimitReq := &version1.LimitReq{
+		Zone: "ing_rl_default_cafe-ingress-master",
+	}
+	expectedCoffeeLimitReq := &version1.LimitReq{
+		Zone:   "ing_rl_default_cafe-ingress-coffee-minion",
+		DryRun: true,
+	}
+
+	masterPems := map[string]string{
+		"cafe.example.com": "/etc/nginx/secrets/default-cafe-secret",
+	}
+	minionJwtKeyFileNames := make(map[string]string)
+	configParams := NewDefaultConfigParams()
+
+	result := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterPems, "", "", minionJwtKeyFileNames, nil, configParams, false, false, &StaticConfigParams{})
+
+	if !reflect.DeepEqual(result.LimitReqZones, expectedZones) {
+		t.Errorf("generateNginxCfgForMergeableIngresses returned \n%v,  but expected \n%v", result.LimitReqZones, expectedZones)
+	}
+	if !reflect.DeepEqual(result.Servers[0].LimitReq, expectedServerLimitReq) {
+		t.Errorf("generateNginxCfgForMergeableIngresses returned \n%v,  but expected \n%v", result.Servers[0].LimitReq, expectedServerLimitReq)
+	}
+	if !reflect.DeepEqual(result.Servers[0].Locations[0].LimitReq, expectedCoffeeLimitReq) {
+		t.Errorf("generateNginxCfgForMergeableIngresses returned \n%v,  but expected \n%v", result.Servers[0].Locations[0].LimitReq, expectedCoffeeLimitReq)
+	}
+	if result.Servers[0].Locations[1].LimitReq != nil {
+		t.Errorf("generateNginxCfgForMergeableIngresses returned %v for the tea location, but expected nil", result.Servers[0].Locations[1].LimitReq)
+	}
+}
+
 func createMergeableCafeIngress() *MergeableIngresses {
-	master := v1beta1.Ingress{
+	master := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "cafe-ingress-master",
 			Namespace: "default",
@@ -354,19 +637,19 @@ func createMergeableCafeIngress() *MergeableIngresses {
 				"nginx.org/mergeable-ingress-type": "master",
 			},
 		},
-		Spec: v1beta1.IngressSpec{
-			TLS: []v1beta1.IngressTLS{
+		Spec: networking.IngressSpec{
+			TLS: []networking.IngressTLS{
 				{
 					Hosts:      []string{"cafe.example.com"},
 					SecretName: "cafe-secret",
 				},
 			},
-			Rules: []v1beta1.IngressRule{
+			Rules: []networking.IngressRule{
 				{
 					Host: "cafe.example.com",
-					IngressRuleValue: v1beta1.IngressRuleValue{
-						HTTP: &v1beta1.HTTPIngressRuleValue{ // HTTP must not be nil for Master
-							Paths: []v1beta1.HTTPIngressPath{},
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{ // HTTP must not be nil for Master
+							Paths: []networking.HTTPIngressPath{},
 						},
 					},
 				},
@@ -374,7 +657,7 @@ func createMergeableCafeIngress() *MergeableIngresses {
 		},
 	}
 
-	coffeeMinion := v1beta1.Ingress{
+	coffeeMinion := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "cafe-ingress-coffee-minion",
 			Namespace: "default",
@@ -383,16 +666,16 @@ func createMergeableCafeIngress() *MergeableIngresses {
 				"nginx.org/mergeable-ingress-type": "minion",
 			},
 		},
-		Spec: v1beta1.IngressSpec{
-			Rules: []v1beta1.IngressRule{
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
 				{
 					Host: "cafe.example.com",
-					IngressRuleValue: v1beta1.IngressRuleValue{
-						HTTP: &v1beta1.HTTPIngressRuleValue{
-							Paths: []v1beta1.HTTPIngressPath{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
 								{
 									Path: "/coffee",
-									Backend: v1beta1.IngressBackend{
+									Backend: networking.IngressBackend{
 										ServiceName: "coffee-svc",
 										ServicePort: intstr.FromString("80"),
 									},
@@ -405,7 +688,7 @@ func createMergeableCafeIngress() *MergeableIngresses {
 		},
 	}
 
-	teaMinion := v1beta1.Ingress{
+	teaMinion := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "cafe-ingress-tea-minion",
 			Namespace: "default",
@@ -414,16 +697,16 @@ func createMergeableCafeIngress() *MergeableIngresses {
 				"nginx.org/mergeable-ingress-type": "minion",
 			},
 		},
-		Spec: v1beta1.IngressSpec{
-			Rules: []v1beta1.IngressRule{
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
 				{
 					Host: "cafe.example.com",
-					IngressRuleValue: v1beta1.IngressRuleValue{
-						HTTP: &v1beta1.HTTPIngressRuleValue{
-							Paths: []v1beta1.HTTPIngressPath{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
 								{
 									Path: "/tea",
-									Backend: v1beta1.IngressBackend{
+									Backend: networking.IngressBackend{
 										ServiceName: "tea-svc",
 										ServicePort: intstr.FromString("80"),
 									},
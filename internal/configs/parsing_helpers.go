@@ -1,7 +1,9 @@
 package configs
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -88,22 +90,7 @@ func GetMapKeyAsStringSlice(m map[string]string, key string, context apiObject,
 
 // ParseLBMethod parses method and matches it to a corresponding load balancing method in NGINX. An error is returned if method is not valid.
 func ParseLBMethod(method string) (string, error) {
-	method = strings.TrimSpace(method)
-
-	if method == "round_robin" {
-		return "", nil
-	}
-
-	if strings.HasPrefix(method, "hash") {
-		method, err := validateHashLBMethod(method)
-		return method, err
-	}
-
-	if _, exists := nginxLBValidInput[method]; exists {
-		return method, nil
-	}
-
-	return "", fmt.Errorf("Invalid load balancing method: %q", method)
+	return parseLBMethodWithValidInput(method, nginxLBValidInput)
 }
 
 var nginxLBValidInput = map[string]bool{
@@ -130,6 +117,43 @@ var nginxPlusLBValidInput = map[string]bool{
 
 // ParseLBMethodForPlus parses method and matches it to a corresponding load balancing method in NGINX Plus. An error is returned if method is not valid.
 func ParseLBMethodForPlus(method string) (string, error) {
+	return parseLBMethodWithValidInput(method, nginxPlusLBValidInput)
+}
+
+var nginxStreamLBValidInput = map[string]bool{
+	"least_conn":            true,
+	"random":                true,
+	"random two":            true,
+	"random two least_conn": true,
+}
+
+var nginxPlusStreamLBValidInput = map[string]bool{
+	"least_conn":                       true,
+	"random":                           true,
+	"random two":                       true,
+	"random two least_conn":            true,
+	"random two least_time=connect":    true,
+	"random two least_time=first_byte": true,
+	"random two least_time=last_byte":  true,
+	"least_time connect":               true,
+	"least_time first_byte":            true,
+	"least_time last_byte":             true,
+	"least_time last_byte inflight":    true,
+}
+
+// ParseStreamLBMethod parses method and matches it to a corresponding load balancing method of the NGINX stream module.
+// An error is returned if method is not valid.
+func ParseStreamLBMethod(method string) (string, error) {
+	return parseLBMethodWithValidInput(method, nginxStreamLBValidInput)
+}
+
+// ParseStreamLBMethodForPlus parses method and matches it to a corresponding load balancing method of the NGINX Plus stream module.
+// An error is returned if method is not valid.
+func ParseStreamLBMethodForPlus(method string) (string, error) {
+	return parseLBMethodWithValidInput(method, nginxPlusStreamLBValidInput)
+}
+
+func parseLBMethodWithValidInput(method string, validInput map[string]bool) (string, error) {
 	method = strings.TrimSpace(method)
 
 	if method == "round_robin" {
@@ -141,7 +165,7 @@ func ParseLBMethodForPlus(method string) (string, error) {
 		return method, err
 	}
 
-	if _, exists := nginxPlusLBValidInput[method]; exists {
+	if _, exists := validInput[method]; exists {
 		return method, nil
 	}
 
@@ -159,3 +183,228 @@ func validateHashLBMethod(method string) (string, error) {
 
 	return "", fmt.Errorf("Invalid load balancing method: %q", method)
 }
+
+// ParseTime ensures that the string value is a valid time in the NGINX format. For example, 10s or 1m 30s.
+func ParseTime(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if validNginxTime.MatchString(s) {
+		return s, nil
+	}
+	return "", errors.New("Invalid time string")
+}
+
+var validNginxRequestRate = regexp.MustCompile(`^[1-9]\d*r/[sm]$`)
+
+// ParseRequestRate ensures that the string value is a valid request rate in the NGINX format. For example, 10r/s or 100r/m.
+func ParseRequestRate(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if validNginxRequestRate.MatchString(s) {
+		return s, nil
+	}
+	return "", errors.New("Invalid request rate string")
+}
+
+var validLimitReqLogLevels = map[string]bool{
+	"info":   true,
+	"notice": true,
+	"warn":   true,
+	"error":  true,
+}
+
+// ParseLimitReqLogLevel ensures that the string value is a valid logging level for the cases when the server refuses
+// to process requests due to the rate exceeding. For example, info or warn.
+func ParseLimitReqLogLevel(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if validLimitReqLogLevels[s] {
+		return s, nil
+	}
+	return "", errors.New("Invalid logging level, must be one of: info, notice, warn or error")
+}
+
+// validVariables are the NGINX variables that are allowed in header values, rate limit keys and other strings of the resources.
+var validVariables = map[string]bool{
+	"args":                   true,
+	"binary_remote_addr":     true,
+	"content_length":         true,
+	"content_type":           true,
+	"host":                   true,
+	"hostname":               true,
+	"is_args":                true,
+	"msec":                   true,
+	"query_string":           true,
+	"remote_addr":            true,
+	"remote_port":            true,
+	"remote_user":            true,
+	"request_id":             true,
+	"request_method":         true,
+	"request_time":           true,
+	"request_uri":            true,
+	"scheme":                 true,
+	"server_addr":            true,
+	"server_name":            true,
+	"server_port":            true,
+	"server_protocol":        true,
+	"status":                 true,
+	"time_iso8601":           true,
+	"upstream_addr":          true,
+	"upstream_response_time": true,
+	"upstream_status":        true,
+	"uri":                    true,
+}
+
+// validVariablePrefixes are the prefixes of the NGINX variables that are allowed in the strings of the resources.
+var validVariablePrefixes = []string{
+	"arg_",
+	"cookie_",
+	"http_",
+}
+
+// GetVariableNames returns the names of the variables in a string, which can be referenced as $name or ${name}.
+// An empty name is returned for a '$' that is not followed by a variable name.
+func GetVariableNames(value string) []string {
+	var names []string
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' {
+			continue
+		}
+
+		if i+1 < len(value) && value[i+1] == '{' {
+			end := strings.IndexByte(value[i+1:], '}')
+			if end == -1 {
+				names = append(names, "")
+				break
+			}
+			names = append(names, value[i+2:i+1+end])
+			i += end + 1
+			continue
+		}
+
+		j := i + 1
+		for j < len(value) && isVariableNameChar(value[j]) {
+			j++
+		}
+		names = append(names, value[i+1:j])
+		i = j - 1
+	}
+
+	return names
+}
+
+func isVariableNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// IsValidVariable checks if the NGINX variable is in the allowlist of the variables that can be used in the resources.
+func IsValidVariable(name string) bool {
+	if validVariables[name] {
+		return true
+	}
+
+	for _, prefix := range validVariablePrefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var validQuotedString = regexp.MustCompile(`^([^"\\]|\\.)*$`)
+
+// ParseStringWithVariables ensures that the string value can be used as a quoted parameter of an NGINX directive
+// and includes only the variables from the allowlist. For example, ${binary_remote_addr} or $http_x_user.
+func ParseStringWithVariables(s string) (string, error) {
+	if !validQuotedString.MatchString(s) {
+		return "", errors.New(`Invalid string, all '"' must be escaped with '\'`)
+	}
+
+	for _, name := range GetVariableNames(s) {
+		if name == "" {
+			return "", errors.New("Invalid string, '$' must be followed by a variable name")
+		}
+		if !IsValidVariable(name) {
+			return "", fmt.Errorf("Invalid string, the variable $%s is not supported", name)
+		}
+	}
+
+	return s, nil
+}
+
+var validSSLVerifyClientModes = map[string]bool{
+	"on":             true,
+	"optional":       true,
+	"optional_no_ca": true,
+}
+
+// ParseSSLVerifyClient ensures that the string value is a valid mode of the verification of client certificates.
+// For example, on or optional.
+func ParseSSLVerifyClient(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if validSSLVerifyClientModes[s] {
+		return s, nil
+	}
+	return "", errors.New("Invalid verification mode, must be one of: on, optional or optional_no_ca")
+}
+
+var validNextUpstreamConditions = map[string]bool{
+	"error":          true,
+	"timeout":        true,
+	"invalid_header": true,
+	"http_500":       true,
+	"http_502":       true,
+	"http_503":       true,
+	"http_504":       true,
+	"http_403":       true,
+	"http_404":       true,
+	"http_429":       true,
+	"off":            true,
+}
+
+// ParseProxyNextUpstream ensures that the string value is a valid list of space-separated conditions for passing
+// a request to the next upstream server. For example, error timeout http_502.
+// The non_idempotent condition is rejected, because retrying non-idempotent requests, such as POST, can apply them
+// more than once.
+func ParseProxyNextUpstream(s string) (string, error) {
+	conditions := strings.Fields(s)
+	if len(conditions) == 0 {
+		return "", errors.New("Invalid next upstream conditions, must include at least one condition")
+	}
+
+	seen := make(map[string]bool)
+
+	for _, c := range conditions {
+		if c == "non_idempotent" {
+			return "", errors.New("Invalid next upstream condition non_idempotent: retrying non-idempotent requests is not allowed")
+		}
+		if !validNextUpstreamConditions[c] {
+			return "", fmt.Errorf("Invalid next upstream condition %q, must be one of: error, timeout, invalid_header, http_500, http_502, http_503, http_504, http_403, http_404, http_429 or off", c)
+		}
+		if seen[c] {
+			return "", fmt.Errorf("Invalid next upstream conditions: duplicated condition %q", c)
+		}
+		seen[c] = true
+	}
+
+	if seen["off"] && len(conditions) > 1 {
+		return "", errors.New("Invalid next upstream conditions: off cannot be combined with other conditions")
+	}
+
+	return strings.Join(conditions, " "), nil
+}
+
+var validNginxSize = regexp.MustCompile(`^\d+[kKmM]?$`)
+
+// ParseSize ensures that the string value is a valid size in the NGINX format. For example, 1024, 4k or 1m.
+func ParseSize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if validNginxSize.MatchString(s) {
+		return s, nil
+	}
+	return "", errors.New("Invalid size string")
+}
@@ -4,8 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	"k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -19,7 +19,7 @@ var configMap = v1.ConfigMap{
 		APIVersion: "v1",
 	},
 }
-var ingress = v1beta1.Ingress{
+var ingress = networking.Ingress{
 	ObjectMeta: meta_v1.ObjectMeta{
 		Name:      "test",
 		Namespace: "kube-system",
@@ -362,3 +362,233 @@ func TestParseLBMethodForPlus(t *testing.T) {
 		}
 	}
 }
+
+func TestParseStreamLBMethod(t *testing.T) {
+	var testsWithValidInput = []struct {
+		input    string
+		expected string
+	}{
+		{"least_conn", "least_conn"},
+		{"round_robin", ""},
+		{"random", "random"},
+		{"random two least_conn", "random two least_conn"},
+		{"hash $remote_addr consistent", "hash $remote_addr consistent"},
+	}
+
+	var invalidInput = []string{
+		"",
+		"ip_hash",
+		"least_time connect",
+		"random two least_time=connect",
+	}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseStreamLBMethod(test.input)
+		if err != nil {
+			t.Errorf("ParseStreamLBMethod(%q) returned an error for valid input", test.input)
+		}
+
+		if result != test.expected {
+			t.Errorf("ParseStreamLBMethod(%q) returned %q expected %q", test.input, result, test.expected)
+		}
+	}
+
+	for _, input := range invalidInput {
+		_, err := ParseStreamLBMethod(input)
+		if err == nil {
+			t.Errorf("ParseStreamLBMethod(%q) does not return an error for invalid input", input)
+		}
+	}
+}
+
+func TestParseStreamLBMethodForPlus(t *testing.T) {
+	var testsWithValidInput = []struct {
+		input    string
+		expected string
+	}{
+		{"least_conn", "least_conn"},
+		{"round_robin", ""},
+		{"least_time connect", "least_time connect"},
+		{"least_time last_byte inflight", "least_time last_byte inflight"},
+		{"random two least_time=first_byte", "random two least_time=first_byte"},
+	}
+
+	var invalidInput = []string{
+		"",
+		"ip_hash",
+		"least_time header",
+		"random two least_time=header",
+	}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseStreamLBMethodForPlus(test.input)
+		if err != nil {
+			t.Errorf("ParseStreamLBMethodForPlus(%q) returned an error for valid input", test.input)
+		}
+
+		if result != test.expected {
+			t.Errorf("ParseStreamLBMethodForPlus(%q) returned %q expected %q", test.input, result, test.expected)
+		}
+	}
+
+	for _, input := range invalidInput {
+		_, err := ParseStreamLBMethodForPlus(input)
+		if err == nil {
+			t.Errorf("ParseStreamLBMethodForPlus(%q) does not return an error for invalid input", input)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	var testsWithValidInput = []string{"1", "1s", "1m 30s", "5h", "1y"}
+	var invalidInput = []string{"", "-1s", "1x", "s", "1 minute"}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseTime(test)
+		if err != nil {
+			t.Errorf("TestParseTime(%q) returned an error for valid input", test)
+		}
+		if result != test {
+			t.Errorf("TestParseTime(%q) returned %q expected %q", test, result, test)
+		}
+	}
+
+	for _, test := range invalidInput {
+		_, err := ParseTime(test)
+		if err == nil {
+			t.Errorf("TestParseTime(%q) didn't return an error for invalid input", test)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	var testsWithValidInput = []string{"1", "1024", "4k", "4K", "1m", "1M"}
+	var invalidInput = []string{"", "-1", "1g", "k", "1 m", "1.5m"}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseSize(test)
+		if err != nil {
+			t.Errorf("TestParseSize(%q) returned an error for valid input", test)
+		}
+		if result != test {
+			t.Errorf("TestParseSize(%q) returned %q expected %q", test, result, test)
+		}
+	}
+
+	for _, test := range invalidInput {
+		_, err := ParseSize(test)
+		if err == nil {
+			t.Errorf("TestParseSize(%q) didn't return an error for invalid input", test)
+		}
+	}
+}
+
+func TestParseRequestRate(t *testing.T) {
+	var testsWithValidInput = []string{"1r/s", "10r/s", "100r/m"}
+	var invalidInput = []string{"", "0r/s", "-1r/s", "10", "10r", "10r/h", "10 r/s", "1.5r/s"}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseRequestRate(test)
+		if err != nil {
+			t.Errorf("TestParseRequestRate(%q) returned an error for valid input", test)
+		}
+		if result != test {
+			t.Errorf("TestParseRequestRate(%q) returned %q expected %q", test, result, test)
+		}
+	}
+
+	for _, test := range invalidInput {
+		_, err := ParseRequestRate(test)
+		if err == nil {
+			t.Errorf("TestParseRequestRate(%q) didn't return an error for invalid input", test)
+		}
+	}
+}
+
+func TestParseStringWithVariables(t *testing.T) {
+	var testsWithValidInput = []string{"", "key", "${binary_remote_addr}", "$http_x_user:$arg_id", `\"quoted\"`}
+	var invalidInput = []string{"$", "${binary_remote_addr", "$not_exists", "$http_", `"key"`, `key\`}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseStringWithVariables(test)
+		if err != nil {
+			t.Errorf("TestParseStringWithVariables(%q) returned an error for valid input", test)
+		}
+		if result != test {
+			t.Errorf("TestParseStringWithVariables(%q) returned %q expected %q", test, result, test)
+		}
+	}
+
+	for _, test := range invalidInput {
+		_, err := ParseStringWithVariables(test)
+		if err == nil {
+			t.Errorf("TestParseStringWithVariables(%q) didn't return an error for invalid input", test)
+		}
+	}
+}
+
+func TestParseLimitReqLogLevel(t *testing.T) {
+	var testsWithValidInput = []string{"info", "notice", "warn", "error"}
+	var invalidInput = []string{"", "debug", "crit", "Warn"}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseLimitReqLogLevel(test)
+		if err != nil {
+			t.Errorf("TestParseLimitReqLogLevel(%q) returned an error for valid input", test)
+		}
+		if result != test {
+			t.Errorf("TestParseLimitReqLogLevel(%q) returned %q expected %q", test, result, test)
+		}
+	}
+
+	for _, test := range invalidInput {
+		_, err := ParseLimitReqLogLevel(test)
+		if err == nil {
+			t.Errorf("TestParseLimitReqLogLevel(%q) didn't return an error for invalid input", test)
+		}
+	}
+}
+
+func TestParseSSLVerifyClient(t *testing.T) {
+	var testsWithValidInput = []string{"on", "optional", "optional_no_ca"}
+	var invalidInput = []string{"", "off", "On", "optional-no-ca"}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseSSLVerifyClient(test)
+		if err != nil {
+			t.Errorf("TestParseSSLVerifyClient(%q) returned an error for valid input", test)
+		}
+		if result != test {
+			t.Errorf("TestParseSSLVerifyClient(%q) returned %q expected %q", test, result, test)
+		}
+	}
+
+	for _, test := range invalidInput {
+		_, err := ParseSSLVerifyClient(test)
+		if err == nil {
+			t.Errorf("TestParseSSLVerifyClient(%q) didn't return an error for invalid input", test)
+		}
+	}
+}
+
+func TestParseProxyNextUpstream(t *testing.T) {
+	var testsWithValidInput = []string{"error", "error timeout", "error timeout http_502 http_503", "off", "invalid_header http_429"}
+	var invalidInput = []string{"", " ", "http_501", "error error", "off error", "non_idempotent", "error timeout non_idempotent", "Error"}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseProxyNextUpstream(test)
+		if err != nil {
+			t.Errorf("TestParseProxyNextUpstream(%q) returned an error for valid input", test)
+		}
+		if result != test {
+			t.Errorf("TestParseProxyNextUpstream(%q) returned %q expected %q", test, result, test)
+		}
+	}
+
+	for _, test := range invalidInput {
+		_, err := ParseProxyNextUpstream(test)
+		if err == nil {
+			t.Errorf("TestParseProxyNextUpstream(%q) didn't return an error for invalid input", test)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package configs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+const nginxNonExistingUnixSocket = "unix:/var/lib/nginx/non-existing-unix-socket.sock"
+
+const (
+	defaultStreamLBMethod    = "random two least_conn"
+	defaultStreamMaxFails    = 1
+	defaultStreamFailTimeout = "10s"
+)
+
+// TransportServerEx holds a TransportServer along with the resources that are referenced in this TransportServer.
+type TransportServerEx struct {
+	TransportServer *conf_v1alpha1.TransportServer
+	Endpoints       map[string][]string
+}
+
+func (tsEx *TransportServerEx) String() string {
+	if tsEx == nil {
+		return "<nil>"
+	}
+
+	if tsEx.TransportServer == nil {
+		return "TransportServerEx has no TransportServer"
+	}
+
+	return fmt.Sprintf("%s/%s", tsEx.TransportServer.Namespace, tsEx.TransportServer.Name)
+}
+
+// tlsPassthroughPair holds the host of a TLS Passthrough TransportServer and the unix socket of its server.
+type tlsPassthroughPair struct {
+	Host       string
+	UnixSocket string
+}
+
+// generateTLSPassthroughHostsConfig generates the config that maps the hosts to the unix sockets.
+// If several TransportServers use the same host, the one with the lexicographically last name wins
+// and the host is returned in the list of the duplicated hosts. Duplicated hosts are not expected,
+// because the controller rejects the TransportServers with a host that is already used.
+func generateTLSPassthroughHostsConfig(tlsPassthroughPairs map[string]tlsPassthroughPair) (*version2.TLSPassthroughHostsConfig, []string) {
+	var names []string
+	for name := range tlsPassthroughPairs {
+		names = append(names, name)
+	}
+	// sort the names so that the same input always produces the same config
+	sort.Strings(names)
+
+	cfg := version2.TLSPassthroughHostsConfig{}
+	var duplicatedHosts []string
+
+	for _, name := range names {
+		pair := tlsPassthroughPairs[name]
+		if _, exists := cfg[pair.Host]; exists {
+			duplicatedHosts = append(duplicatedHosts, pair.Host)
+		}
+		cfg[pair.Host] = pair.UnixSocket
+	}
+
+	return &cfg, duplicatedHosts
+}
+
+func newUpstreamNamerForTransportServer(transportServer *conf_v1alpha1.TransportServer) *upstreamNamer {
+	return &upstreamNamer{
+		prefix: fmt.Sprintf("ts_%s_%s", transportServer.Namespace, transportServer.Name),
+	}
+}
+
+func generateTransportServerConfig(transportServerEx *TransportServerEx, isPlus bool) version2.TransportServerConfig {
+	upstreamNamer := newUpstreamNamerForTransportServer(transportServerEx.TransportServer)
+
+	var upstreams []version2.StreamUpstream
+	var passedUpstream conf_v1alpha1.TransportServerUpstream
+
+	for _, u := range transportServerEx.TransportServer.Spec.Upstreams {
+		upstreamName := upstreamNamer.GetNameForUpstream(u.Name)
+		endpointsKey := GenerateEndpointsKey(transportServerEx.TransportServer.Namespace, u.Service, nil, u.Port)
+
+		ups := generateStreamUpstream(upstreamName, u, transportServerEx.Endpoints[endpointsKey], isPlus)
+		upstreams = append(upstreams, ups)
+
+		if transportServerEx.TransportServer.Spec.Action != nil && transportServerEx.TransportServer.Spec.Action.Pass == u.Name {
+			passedUpstream = u
+		}
+	}
+
+	listener := transportServerEx.TransportServer.Spec.Listener
+
+	var proxyPass string
+	if transportServerEx.TransportServer.Spec.Action != nil {
+		proxyPass = upstreamNamer.GetNameForUpstream(transportServerEx.TransportServer.Spec.Action.Pass)
+	}
+
+	var port int
+	var unixSocket string
+	if listener.Protocol == conf_v1alpha1.TLSPassthroughProtocol {
+		unixSocket = generateUnixSocketForTLSPassthrough(transportServerEx.TransportServer)
+	} else {
+		port = listener.Port
+	}
+
+	return version2.TransportServerConfig{
+		Server: version2.StreamServer{
+			Port:                port,
+			UDP:                 listener.Protocol == "UDP",
+			UnixSocket:          unixSocket,
+			ProxyPass:           proxyPass,
+			ProxyConnectTimeout: passedUpstream.ProxyConnectTimeout,
+			ProxyTimeout:        passedUpstream.ProxyTimeout,
+		},
+		Upstreams: upstreams,
+	}
+}
+
+// generateUnixSocketForTLSPassthrough generates the unix socket that the server of a TLS Passthrough TransportServer
+// listens on. The TLS Passthrough server on port 443 passes the connections for the host of the TransportServer to that socket.
+func generateUnixSocketForTLSPassthrough(transportServer *conf_v1alpha1.TransportServer) string {
+	return fmt.Sprintf("unix:/var/lib/nginx/passthrough-ts_%s_%s.sock", transportServer.Namespace, transportServer.Name)
+}
+
+func generateStreamUpstream(upstreamName string, upstream conf_v1alpha1.TransportServerUpstream, endpoints []string, isPlus bool) version2.StreamUpstream {
+	var upsServers []version2.StreamUpstreamServer
+
+	maxFails := generateIntFromPointer(upstream.MaxFails, defaultStreamMaxFails)
+	failTimeout := generateString(upstream.FailTimeout, defaultStreamFailTimeout)
+
+	for _, e := range endpoints {
+		s := version2.StreamUpstreamServer{
+			Address:     e,
+			MaxFails:    maxFails,
+			FailTimeout: failTimeout,
+		}
+		upsServers = append(upsServers, s)
+	}
+
+	// NGINX requires at least one server in an upstream without a shared memory zone
+	if !isPlus && len(upsServers) == 0 {
+		s := version2.StreamUpstreamServer{
+			Address:     nginxNonExistingUnixSocket,
+			MaxFails:    maxFails,
+			FailTimeout: failTimeout,
+		}
+		upsServers = append(upsServers, s)
+	}
+
+	return version2.StreamUpstream{
+		Name:     upstreamName,
+		Servers:  upsServers,
+		LBMethod: generateStreamLBMethod(upstream.LBMethod, isPlus),
+	}
+}
+
+// generateStreamLBMethod returns the load balancing method of a stream upstream or the default one if the method is not set.
+// The method is expected to be validated.
+func generateStreamLBMethod(method string, isPlus bool) string {
+	if method == "" {
+		return defaultStreamLBMethod
+	}
+
+	if isPlus {
+		method, _ = ParseStreamLBMethodForPlus(method)
+	} else {
+		method, _ = ParseStreamLBMethod(method)
+	}
+
+	return method
+}
+
+func createStreamUpstreamServersConfigForPlus(upstream version2.StreamUpstream) nginx.ServerConfig {
+	if len(upstream.Servers) == 0 {
+		return nginx.ServerConfig{
+			MaxFails:    defaultStreamMaxFails,
+			FailTimeout: defaultStreamFailTimeout,
+		}
+	}
+
+	return nginx.ServerConfig{
+		MaxFails:    upstream.Servers[0].MaxFails,
+		FailTimeout: upstream.Servers[0].FailTimeout,
+	}
+}
+
+func createEndpointsFromStreamUpstream(upstream version2.StreamUpstream) []string {
+	var endpoints []string
+
+	for _, server := range upstream.Servers {
+		endpoints = append(endpoints, server.Address)
+	}
+
+	return endpoints
+}
@@ -0,0 +1,285 @@
+package configs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransportServerExString(t *testing.T) {
+	tests := []struct {
+		input    *TransportServerEx
+		expected string
+	}{
+		{
+			input: &TransportServerEx{
+				TransportServer: &conf_v1alpha1.TransportServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "dns-tcp",
+						Namespace: "default",
+					},
+				},
+			},
+			expected: "default/dns-tcp",
+		},
+		{
+			input:    &TransportServerEx{},
+			expected: "TransportServerEx has no TransportServer",
+		},
+		{
+			input:    nil,
+			expected: "<nil>",
+		},
+	}
+
+	for _, test := range tests {
+		result := test.input.String()
+		if result != test.expected {
+			t.Errorf("TransportServerEx.String() returned %v but expected %v", result, test.expected)
+		}
+	}
+}
+
+func TestGenerateTransportServerConfig(t *testing.T) {
+	maxFails := 3
+	transportServerEx := TransportServerEx{
+		TransportServer: &conf_v1alpha1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "dns",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.TransportServerSpec{
+				Listener: conf_v1alpha1.TransportServerListener{
+					Port:     5353,
+					Protocol: "UDP",
+				},
+				Upstreams: []conf_v1alpha1.TransportServerUpstream{
+					{
+						Name:                "dns-app",
+						Service:             "dns-svc",
+						Port:                53,
+						LBMethod:            "least_conn",
+						MaxFails:            &maxFails,
+						FailTimeout:         "30s",
+						ProxyConnectTimeout: "10s",
+						ProxyTimeout:        "1m",
+					},
+					{
+						Name:    "dns-backup",
+						Service: "dns-backup-svc",
+						Port:    53,
+					},
+				},
+				Action: &conf_v1alpha1.TransportServerAction{
+					Pass: "dns-app",
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/dns-svc:53": {
+				"10.0.0.20:53",
+				"10.0.0.21:53",
+			},
+		},
+	}
+
+	expected := version2.TransportServerConfig{
+		Upstreams: []version2.StreamUpstream{
+			{
+				Name: "ts_default_dns_dns-app",
+				Servers: []version2.StreamUpstreamServer{
+					{
+						Address:     "10.0.0.20:53",
+						MaxFails:    3,
+						FailTimeout: "30s",
+					},
+					{
+						Address:     "10.0.0.21:53",
+						MaxFails:    3,
+						FailTimeout: "30s",
+					},
+				},
+				LBMethod: "least_conn",
+			},
+			{
+				Name: "ts_default_dns_dns-backup",
+				Servers: []version2.StreamUpstreamServer{
+					{
+						Address:     nginxNonExistingUnixSocket,
+						MaxFails:    1,
+						FailTimeout: "10s",
+					},
+				},
+				LBMethod: "random two least_conn",
+			},
+		},
+		Server: version2.StreamServer{
+			Port:                5353,
+			UDP:                 true,
+			ProxyPass:           "ts_default_dns_dns-app",
+			ProxyConnectTimeout: "10s",
+			ProxyTimeout:        "1m",
+		},
+	}
+
+	isPlus := false
+	result := generateTransportServerConfig(&transportServerEx, isPlus)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateTransportServerConfig() returned \n%v but expected \n%v", result, expected)
+	}
+}
+
+func TestGenerateTransportServerConfigForTLSPassthrough(t *testing.T) {
+	transportServerEx := TransportServerEx{
+		TransportServer: &conf_v1alpha1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "secure-app",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.TransportServerSpec{
+				Listener: conf_v1alpha1.TransportServerListener{
+					Port:     443,
+					Protocol: "TLS_PASSTHROUGH",
+				},
+				Host: "app.example.com",
+				Upstreams: []conf_v1alpha1.TransportServerUpstream{
+					{
+						Name:    "secure-app",
+						Service: "secure-app",
+						Port:    8443,
+					},
+				},
+				Action: &conf_v1alpha1.TransportServerAction{
+					Pass: "secure-app",
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/secure-app:8443": {
+				"10.0.0.20:8443",
+			},
+		},
+	}
+
+	expected := version2.TransportServerConfig{
+		Upstreams: []version2.StreamUpstream{
+			{
+				Name: "ts_default_secure-app_secure-app",
+				Servers: []version2.StreamUpstreamServer{
+					{
+						Address:     "10.0.0.20:8443",
+						MaxFails:    1,
+						FailTimeout: "10s",
+					},
+				},
+				LBMethod: "random two least_conn",
+			},
+		},
+		Server: version2.StreamServer{
+			UnixSocket: "unix:/var/lib/nginx/passthrough-ts_default_secure-app.sock",
+			ProxyPass:  "ts_default_secure-app_secure-app",
+		},
+	}
+
+	isPlus := false
+	result := generateTransportServerConfig(&transportServerEx, isPlus)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateTransportServerConfig() returned \n%v but expected \n%v", result, expected)
+	}
+}
+
+func TestGenerateTLSPassthroughHostsConfig(t *testing.T) {
+	tlsPassthroughPairs := map[string]tlsPassthroughPair{
+		"ts_default_app-1": {
+			Host:       "app.example.com",
+			UnixSocket: "unix:/var/lib/nginx/passthrough-ts_default_app-1.sock",
+		},
+		"ts_default_app-2": {
+			Host:       "app.example.com",
+			UnixSocket: "unix:/var/lib/nginx/passthrough-ts_default_app-2.sock",
+		},
+		"ts_default_other": {
+			Host:       "other.example.com",
+			UnixSocket: "unix:/var/lib/nginx/passthrough-ts_default_other.sock",
+		},
+	}
+
+	expectedCfg := &version2.TLSPassthroughHostsConfig{
+		"app.example.com":   "unix:/var/lib/nginx/passthrough-ts_default_app-2.sock",
+		"other.example.com": "unix:/var/lib/nginx/passthrough-ts_default_other.sock",
+	}
+	expectedDuplicatedHosts := []string{"app.example.com"}
+
+	resultCfg, resultDuplicatedHosts := generateTLSPassthroughHostsConfig(tlsPassthroughPairs)
+	if !reflect.DeepEqual(resultCfg, expectedCfg) {
+		t.Errorf("generateTLSPassthroughHostsConfig() returned %v but expected %v", resultCfg, expectedCfg)
+	}
+	if !reflect.DeepEqual(resultDuplicatedHosts, expectedDuplicatedHosts) {
+		t.Errorf("generateTLSPassthroughHostsConfig() returned %v but expected %v", resultDuplicatedHosts, expectedDuplicatedHosts)
+	}
+}
+
+func TestGenerateStreamUpstreamForZeroEndpointsForPlus(t *testing.T) {
+	upstream := conf_v1alpha1.TransportServerUpstream{
+		Name:     "dns-app",
+		Service:  "dns-svc",
+		Port:     53,
+		LBMethod: "least_time connect",
+	}
+
+	expected := version2.StreamUpstream{
+		Name:     "ts_default_dns_dns-app",
+		Servers:  nil,
+		LBMethod: "least_time connect",
+	}
+
+	isPlus := true
+	result := generateStreamUpstream("ts_default_dns_dns-app", upstream, nil, isPlus)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateStreamUpstream() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestCreateStreamUpstreamServersConfigForPlus(t *testing.T) {
+	upstream := version2.StreamUpstream{
+		Servers: []version2.StreamUpstreamServer{
+			{
+				Address:     "10.0.0.20:53",
+				MaxFails:    3,
+				FailTimeout: "30s",
+			},
+		},
+	}
+
+	expected := nginx.ServerConfig{
+		MaxFails:    3,
+		FailTimeout: "30s",
+	}
+
+	result := createStreamUpstreamServersConfigForPlus(upstream)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("createStreamUpstreamServersConfigForPlus returned %v but expected %v", result, expected)
+	}
+
+	expected = nginx.ServerConfig{
+		MaxFails:    defaultStreamMaxFails,
+		FailTimeout: defaultStreamFailTimeout,
+	}
+
+	result = createStreamUpstreamServersConfigForPlus(version2.StreamUpstream{})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("createStreamUpstreamServersConfigForPlus returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGetFileNameForTransportServerFromKey(t *testing.T) {
+	result := getFileNameForTransportServerFromKey("default/dns")
+	expected := "ts_default_dns"
+	if result != expected {
+		t.Errorf("getFileNameForTransportServerFromKey returned %v but expected %v", result, expected)
+	}
+}
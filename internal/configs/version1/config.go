@@ -2,10 +2,11 @@ package version1
 
 // IngressNginxConfig describes an NGINX configuration.
 type IngressNginxConfig struct {
-	Upstreams []Upstream
-	Servers   []Server
-	Keepalive string
-	Ingress   Ingress
+	Upstreams     []Upstream
+	Servers       []Server
+	Keepalive     string
+	Ingress       Ingress
+	LimitReqZones []LimitReqZone
 }
 
 // Ingress holds information about an Ingress resource.
@@ -33,6 +34,7 @@ type UpstreamServer struct {
 	FailTimeout string
 	SlowStart   string
 	Resolve     bool
+	Backup      bool
 }
 
 // HealthCheck describes an active HTTP health check.
@@ -76,6 +78,7 @@ type Server struct {
 	RedirectToHTTPS       bool
 	SSLRedirect           bool
 	ProxyProtocol         bool
+	TLSPassthrough        bool
 	HSTS                  bool
 	HSTSMaxAge            int64
 	HSTSIncludeSubdomains bool
@@ -92,11 +95,42 @@ type Server struct {
 	JWTAuth              *JWTAuth
 	JWTRedirectLocations []JWTRedirectLocation
 
+	LimitReq *LimitReq
+
+	ClientCertificate *ClientCertificate
+
 	Ports    []int
 	SSLPorts []int
 	Wallarm *Wallarm
 }
 
+// ClientCertificate describes the verification of client certificates.
+type ClientCertificate struct {
+	CAFile            string
+	CRLFile           string
+	Verify            string
+	VerifyDepth       int
+	ForwardToUpstream bool
+}
+
+// LimitReqZone describes a zone for limiting the request rate.
+type LimitReqZone struct {
+	Name string
+	Key  string
+	Size string
+	Rate string
+}
+
+// LimitReq describes the request rate limiting with a LimitReqZone.
+type LimitReq struct {
+	Zone       string
+	Burst      int
+	NoDelay    bool
+	DryRun     bool
+	LogLevel   string
+	RejectCode int
+}
+
 // JWTRedirectLocation describes a location for redirecting client requests to a login URL for JWT Authentication.
 type JWTRedirectLocation struct {
 	Name     string
@@ -113,26 +147,42 @@ type JWTAuth struct {
 
 // Location describes an NGINX location.
 type Location struct {
-	LocationSnippets     []string
-	Path                 string
-	Upstream             Upstream
-	ProxyConnectTimeout  string
-	ProxyReadTimeout     string
-	ClientMaxBodySize    string
-	Websocket            bool
-	Rewrite              string
-	SSL                  bool
-	GRPC                 bool
-	ProxyBuffering       bool
-	ProxyBuffers         string
-	ProxyBufferSize      string
-	ProxyMaxTempFileSize string
-	JWTAuth              *JWTAuth
-	Wallarm              *Wallarm
+	LocationSnippets         []string
+	Path                     string
+	Upstream                 Upstream
+	ProxyConnectTimeout      string
+	ProxyReadTimeout         string
+	ClientMaxBodySize        string
+	Websocket                bool
+	Rewrite                  string
+	SSL                      bool
+	GRPC                     bool
+	ProxyBuffering           bool
+	ProxyBuffers             string
+	ProxyBufferSize          string
+	ProxyMaxTempFileSize     string
+	ProxyNextUpstream        string
+	ProxyNextUpstreamTimeout string
+	ProxyNextUpstreamTries   int
+	JWTAuth                  *JWTAuth
+	LimitReq                 *LimitReq
+	ProxySSL                 *ProxySSL
+	Wallarm                  *Wallarm
 
 	MinionIngress *Ingress
 }
 
+// ProxySSL holds the TLS configuration for the connections to the backends of a location.
+type ProxySSL struct {
+	TrustedCertificate string
+	CRLFile            string
+	VerifyDepth        int
+	Name               string
+	Certificate        string
+	CertificateKey     string
+	Ciphers            string
+}
+
 // MainConfig describe the main NGINX configuration file.
 type MainConfig struct {
 	ServerNamesHashBucketSize      string
@@ -156,6 +206,8 @@ type MainConfig struct {
 	HTTP2                          bool
 	ServerTokens                   string
 	ProxyProtocol                  bool
+	SetRealIPFrom                  []string
+	TLSPassthrough                 bool
 	WorkerProcesses                string
 	WorkerCPUAffinity              string
 	WorkerShutdownTimeout          string
@@ -2,6 +2,7 @@ package version1
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"text/template"
 )
@@ -53,6 +54,20 @@ var ingCfg = IngressNginxConfig{
 			SSLCiphers:        "NULL",
 			SSLPorts:          []int{443},
 			SSLRedirect:       true,
+			LimitReq: &LimitReq{
+				Zone:       "ing_rl_default_cafe-ingress",
+				Burst:      10,
+				NoDelay:    true,
+				LogLevel:   "warn",
+				RejectCode: 429,
+			},
+			ClientCertificate: &ClientCertificate{
+				CAFile:            "/etc/nginx/secrets/default-cafe-ca",
+				CRLFile:           "/etc/nginx/secrets/default-cafe-ca",
+				Verify:            "on",
+				VerifyDepth:       2,
+				ForwardToUpstream: true,
+			},
 			Locations: []Location{
 				{
 					Path:                "/tea",
@@ -65,11 +80,33 @@ var ingCfg = IngressNginxConfig{
 						Realm: "closed site",
 						Token: "$cookie_auth_token",
 					},
+					LimitReq: &LimitReq{
+						Zone:   "ing_rl_default_tea-minion",
+						DryRun: true,
+					},
 					MinionIngress: &Ingress{
 						Name:      "tea-minion",
 						Namespace: "default",
 					},
 				},
+				{
+					Path:                     "/coffee",
+					Upstream:                 testUps,
+					ProxyConnectTimeout:      "10s",
+					ProxyReadTimeout:         "10s",
+					ClientMaxBodySize:        "2m",
+					ProxyNextUpstream:        "error timeout http_502",
+					ProxyNextUpstreamTimeout: "10s",
+					ProxyNextUpstreamTries:   3,
+					SSL:                      true,
+					ProxySSL: &ProxySSL{
+						TrustedCertificate: "/etc/nginx/secrets/default-coffee-ca_ca",
+						VerifyDepth:        2,
+						Name:               "coffee.example.com",
+						Certificate:        "/etc/nginx/secrets/default-coffee-client-secret",
+						CertificateKey:     "/etc/nginx/secrets/default-coffee-client-secret",
+					},
+				},
 			},
 			HealthChecks: map[string]HealthCheck{"test": healthCheck},
 			JWTRedirectLocations: []JWTRedirectLocation{
@@ -86,6 +123,14 @@ var ingCfg = IngressNginxConfig{
 		Name:      "cafe-ingress",
 		Namespace: "default",
 	},
+	LimitReqZones: []LimitReqZone{
+		{
+			Name: "ing_rl_default_cafe-ingress",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+	},
 }
 
 var mainCfg = MainConfig{
@@ -193,3 +238,61 @@ func TestWallarmTarantool(t *testing.T) {
 		t.Fatalf("Failed to write template %v", err)
 	}
 }
+
+func TestIngressClientCertificate(t *testing.T) {
+	tests := []struct {
+		clientCertificate *ClientCertificate
+		expected          []string
+		unexpected        []string
+		msg               string
+	}{
+		{
+			clientCertificate: &ClientCertificate{CAFile: "/etc/nginx/secrets/default-cafe-ca", Verify: "on", VerifyDepth: 1, ForwardToUpstream: true},
+			expected:          []string{"return 403;", "proxy_set_header X-SSL-Client-Verify $ssl_client_verify;"},
+			unexpected:        []string{`X-SSL-Client-Verify "";`},
+			msg:               "required client certificate forwarded to upstreams",
+		},
+		{
+			clientCertificate: &ClientCertificate{CAFile: "/etc/nginx/secrets/default-cafe-ca", Verify: "optional", VerifyDepth: 1},
+			expected:          []string{`proxy_set_header X-SSL-Client-Verify "";`},
+			unexpected:        []string{"return 403;", "$ssl_client_verify"},
+			msg:               "optional client certificate not forwarded to upstreams",
+		},
+		{
+			expected:   []string{`proxy_set_header X-SSL-Client-Cert "";`, `proxy_set_header X-SSL-Client-S-DN "";`},
+			unexpected: []string{"return 403;", "$ssl_client_verify"},
+			msg:        "no client certificate",
+		},
+	}
+
+	for _, tmplName := range []string{nginxIngressTmpl, nginxPlusIngressTmpl} {
+		tmpl, err := template.New(tmplName).ParseFiles(tmplName)
+		if err != nil {
+			t.Fatalf("Failed to parse template file: %v", err)
+		}
+
+		for _, test := range tests {
+			cfg := ingCfg
+			server := cfg.Servers[0]
+			server.ClientCertificate = test.clientCertificate
+			cfg.Servers = []Server{server}
+
+			var buf bytes.Buffer
+			err = tmpl.Execute(&buf, cfg)
+			if err != nil {
+				t.Fatalf("Failed to write template %v: %v", tmplName, err)
+			}
+
+			for _, s := range test.expected {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("Template %v doesn't include %q for the case of %s", tmplName, s, test.msg)
+				}
+			}
+			for _, s := range test.unexpected {
+				if strings.Contains(buf.String(), s) {
+					t.Errorf("Template %v includes %q for the case of %s", tmplName, s, test.msg)
+				}
+			}
+		}
+	}
+}
@@ -2,31 +2,47 @@ package version2
 
 // VirtualServerConfig holds NGINX configuration for a VirtualServer.
 type VirtualServerConfig struct {
-	Server       Server
-	Upstreams    []Upstream
-	SplitClients []SplitClient
-	Maps         []Map
-	Keepalive    string
+	Server        Server
+	Upstreams     []Upstream
+	SplitClients  []SplitClient
+	Maps          []Map
+	Matches       []Match
+	LimitReqZones []LimitReqZone
 }
 
 // Upstream defines an upstream.
 type Upstream struct {
+	Name          string
+	Servers       []UpstreamServer
+	LBMethod      string
+	Keepalive     int
+	SessionCookie *SessionCookie
+}
+
+// SessionCookie defines a session cookie for an upstream. NGINX Plus only.
+type SessionCookie struct {
 	Name     string
-	Servers  []UpstreamServer
-	LBMethod string
+	Path     string
+	Expires  string
+	Domain   string
+	HTTPOnly bool
+	Secure   bool
 }
 
 // UpstreamServer defines an upstream server.
 type UpstreamServer struct {
 	Address     string
 	MaxFails    int
+	MaxConns    int
 	FailTimeout string
+	Backup      bool
 }
 
 // Server defines a server.
 type Server struct {
 	ServerName                            string
 	ProxyProtocol                         bool
+	TLSPassthrough                        bool
 	SSL                                   *SSL
 	RedirectToHTTPSBasedOnXForwarderProto bool
 	ServerTokens                          string
@@ -35,30 +51,172 @@ type Server struct {
 	RealIPRecursive                       bool
 	Snippets                              []string
 	InternalRedirectLocations             []InternalRedirectLocation
+	JWTRedirectLocations                  []JWTRedirectLocation
 	Locations                             []Location
+	HealthChecks                          []HealthCheck
+	Wallarm                               *Wallarm
 }
 
 // SSL defines SSL configuration for a server.
 type SSL struct {
-	HTTP2           bool
-	Certificate     string
-	CertificateKey  string
-	Ciphers         string
-	RedirectToHTTPS bool
+	HTTP2             bool
+	Certificate       string
+	CertificateKey    string
+	Ciphers           string
+	RedirectToHTTPS   bool
+	ClientCertificate *ClientCertificate
+}
+
+// ClientCertificate defines the verification of client certificates for a server.
+type ClientCertificate struct {
+	CAFile            string
+	CRLFile           string
+	Verify            string
+	VerifyDepth       int
+	ForwardToUpstream bool
 }
 
 // Location defines a location.
 type Location struct {
-	Path                 string
-	Snippets             []string
-	ProxyConnectTimeout  string
-	ProxyReadTimeout     string
-	ClientMaxBodySize    string
-	ProxyMaxTempFileSize string
-	ProxyBuffering       bool
-	ProxyBuffers         string
-	ProxyBufferSize      string
-	ProxyPass            string
+	Path                     string
+	Snippets                 []string
+	ProxyConnectTimeout      string
+	ProxyReadTimeout         string
+	ProxySendTimeout         string
+	ClientMaxBodySize        string
+	ProxyMaxTempFileSize     string
+	ProxyBuffering           bool
+	ProxyBuffers             string
+	ProxyBufferSize          string
+	ProxyNextUpstream        string
+	ProxyNextUpstreamTimeout string
+	ProxyNextUpstreamTries   int
+	ProxyPass                string
+	GRPCPass                 string
+	HasKeepalive             bool
+	ProxySetHeaders          []Header
+	ProxyPassRequestHeaders  bool
+	ProxyHideHeaders         []string
+	ProxyPassHeaders         []string
+	ProxyIgnoreHeaders       string
+	AddHeaders               []AddHeader
+	Rewrite                  *Rewrite
+	Return                   *Return
+	Allow                    []string
+	Deny                     []string
+	LimitReq                 *LimitReq
+	JWTAuth                  *JWTAuth
+	PoliciesErrorReturn      *Return
+	ProxySSL                 *ProxySSL
+	Wallarm                  *Wallarm
+}
+
+// Header defines a header to use with the proxy_set_header directive.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// AddHeader defines a header to use with the add_header directive.
+type AddHeader struct {
+	Header
+	Always bool
+}
+
+// ProxySSL defines the TLS configuration for the connections to an upstream.
+type ProxySSL struct {
+	TrustedCertificate string
+	CRLFile            string
+	VerifyDepth        int
+	Name               string
+	Certificate        string
+	CertificateKey     string
+	Ciphers            string
+}
+
+// Rewrite defines a rewrite of the URI of a request passed to an upstream.
+type Rewrite struct {
+	Regex       string
+	Replacement string
+}
+
+// Return defines a response returned by a location instead of passing the request to an upstream.
+// For redirects, the text is the URL.
+type Return struct {
+	Code int
+	Type string
+	Text string
+}
+
+// LimitReqZone defines a zone for limiting the request rate.
+type LimitReqZone struct {
+	Name string
+	Key  string
+	Size string
+	Rate string
+}
+
+// LimitReq defines the request rate limiting with a LimitReqZone.
+type LimitReq struct {
+	Zone       string
+	Burst      int
+	NoDelay    bool
+	DryRun     bool
+	LogLevel   string
+	RejectCode int
+}
+
+// JWTAuth defines a JWT authentication for a location. NGINX Plus only.
+type JWTAuth struct {
+	Secret               string
+	Realm                string
+	Token                string
+	RedirectLocationName string
+}
+
+// JWTRedirectLocation defines a location for redirecting the clients with an invalid or missing JWT to a login URL.
+// NGINX Plus only.
+type JWTRedirectLocation struct {
+	Name     string
+	LoginURL string
+}
+
+// HealthCheck defines an active health check of an upstream. NGINX Plus only.
+type HealthCheck struct {
+	Name                string
+	URI                 string
+	Interval            string
+	Jitter              string
+	Fails               int
+	Passes              int
+	Port                int
+	ProxyPass           string
+	ProxyConnectTimeout string
+	ProxyReadTimeout    string
+	ProxySendTimeout    string
+	Headers             map[string]string
+	Match               string
+	ProxySSL            *ProxySSL
+}
+
+// Match defines a match block for health checks. NGINX Plus only.
+type Match struct {
+	Name   string
+	Status string
+	Body   string
+}
+
+// Wallarm defines Wallarm configuration for a server or a location.
+type Wallarm struct {
+	Mode              string
+	ModeAllowOverride string
+	Fallback          string
+	Instance          string
+	BlockPage         string
+	ParseResponse     string
+	ParseWebsocket    string
+	UnpackResponse    string
+	ParserDisable     []string
 }
 
 // SplitClient defines a split_clients.
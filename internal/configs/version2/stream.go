@@ -0,0 +1,34 @@
+package version2
+
+// TransportServerConfig holds NGINX configuration for a TransportServer.
+type TransportServerConfig struct {
+	Server    StreamServer
+	Upstreams []StreamUpstream
+}
+
+// StreamUpstream defines a stream upstream.
+type StreamUpstream struct {
+	Name     string
+	Servers  []StreamUpstreamServer
+	LBMethod string
+}
+
+// StreamUpstreamServer defines a stream upstream server.
+type StreamUpstreamServer struct {
+	Address     string
+	MaxFails    int
+	FailTimeout string
+}
+
+// StreamServer defines a server in the stream module.
+type StreamServer struct {
+	Port                int
+	UDP                 bool
+	UnixSocket          string
+	ProxyPass           string
+	ProxyConnectTimeout string
+	ProxyTimeout        string
+}
+
+// TLSPassthroughHostsConfig maps the hosts of the TLS Passthrough TransportServers to the unix sockets of their servers.
+type TLSPassthroughHostsConfig map[string]string
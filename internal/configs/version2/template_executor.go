@@ -6,21 +6,38 @@ import (
 	"text/template"
 )
 
+const tlsPassthroughHostsTemplateString = `{{ range $h, $u := . }}{{ $h }} {{ $u }};
+{{ end }}`
+
 // TemplateExecutor executes NGINX configuration templates.
 type TemplateExecutor struct {
-	virtualServerTemplate *template.Template
+	virtualServerTemplate       *template.Template
+	transportServerTemplate     *template.Template
+	tlsPassthroughHostsTemplate *template.Template
 }
 
 // NewTemplateExecutor creates a TemplateExecutor.
-func NewTemplateExecutor(virtualServerTemplatePath string) (*TemplateExecutor, error) {
+func NewTemplateExecutor(virtualServerTemplatePath string, transportServerTemplatePath string) (*TemplateExecutor, error) {
 	// template name must be the base name of the template file https://golang.org/pkg/text/template/#Template.ParseFiles
 	vsTemplate, err := template.New(path.Base(virtualServerTemplatePath)).ParseFiles(virtualServerTemplatePath)
 	if err != nil {
 		return nil, err
 	}
 
+	tsTemplate, err := template.New(path.Base(transportServerTemplatePath)).ParseFiles(transportServerTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsPassthroughHostsTemplate, err := template.New("unnamed").Parse(tlsPassthroughHostsTemplateString)
+	if err != nil {
+		return nil, err
+	}
+
 	return &TemplateExecutor{
-		virtualServerTemplate: vsTemplate,
+		virtualServerTemplate:       vsTemplate,
+		transportServerTemplate:     tsTemplate,
+		tlsPassthroughHostsTemplate: tlsPassthroughHostsTemplate,
 	}, nil
 }
 
@@ -31,3 +48,20 @@ func (te *TemplateExecutor) ExecuteVirtualServerTemplate(cfg *VirtualServerConfi
 
 	return configBuffer.Bytes(), err
 }
+
+// ExecuteTransportServerTemplate generates the content of an NGINX configuration file for a TransportServer resource.
+func (te *TemplateExecutor) ExecuteTransportServerTemplate(cfg *TransportServerConfig) ([]byte, error) {
+	var configBuffer bytes.Buffer
+	err := te.transportServerTemplate.Execute(&configBuffer, cfg)
+
+	return configBuffer.Bytes(), err
+}
+
+// ExecuteTLSPassthroughHostsTemplate generates the content of an NGINX configuration file that maps the hosts of
+// the TLS Passthrough TransportServers to the unix sockets of their servers.
+func (te *TemplateExecutor) ExecuteTLSPassthroughHostsTemplate(cfg *TLSPassthroughHostsConfig) ([]byte, error) {
+	var configBuffer bytes.Buffer
+	err := te.tlsPassthroughHostsTemplate.Execute(&configBuffer, cfg)
+
+	return configBuffer.Bytes(), err
+}
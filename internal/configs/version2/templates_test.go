@@ -1,9 +1,14 @@
 package version2
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 const nginxPlusVirtualServerTmpl = "nginx-plus.virtualserver.tmpl"
 const nginxVirtualServerTmpl = "nginx.virtualserver.tmpl"
+const nginxPlusTransportServerTmpl = "nginx-plus.transportserver.tmpl"
+const nginxTransportServerTmpl = "nginx.transportserver.tmpl"
 
 var virtualServerCfg = VirtualServerConfig{
 	Upstreams: []Upstream{
@@ -13,10 +18,20 @@ var virtualServerCfg = VirtualServerConfig{
 				{
 					Address:     "10.0.0.20:8001",
 					MaxFails:    5,
+					MaxConns:    100,
 					FailTimeout: "10s",
 				},
 			},
-			LBMethod: "random",
+			LBMethod:  "random",
+			Keepalive: 32,
+			SessionCookie: &SessionCookie{
+				Name:     "srv_id",
+				Path:     "/",
+				Expires:  "1h",
+				Domain:   ".example.com",
+				HTTPOnly: true,
+				Secure:   true,
+			},
 		},
 		{
 			Name: "coffee-v1",
@@ -85,6 +100,21 @@ var virtualServerCfg = VirtualServerConfig{
 			},
 		},
 	},
+	Matches: []Match{
+		{
+			Name:   "test-upstream_match",
+			Status: "200-399",
+			Body:   "ok",
+		},
+	},
+	LimitReqZones: []LimitReqZone{
+		{
+			Name: "pol_rl_default_rate-limit_default_cafe",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+	},
 	Server: Server{
 		ServerName:    "example.com",
 		ProxyProtocol: true,
@@ -94,6 +124,13 @@ var virtualServerCfg = VirtualServerConfig{
 			CertificateKey:  "cafe-secret.pem",
 			Ciphers:         "NULL",
 			RedirectToHTTPS: true,
+			ClientCertificate: &ClientCertificate{
+				CAFile:            "cafe-ca.pem",
+				CRLFile:           "cafe-ca.pem",
+				Verify:            "on",
+				VerifyDepth:       1,
+				ForwardToUpstream: true,
+			},
 		},
 		RedirectToHTTPSBasedOnXForwarderProto: true,
 		ServerTokens:                          "off",
@@ -111,18 +148,173 @@ var virtualServerCfg = VirtualServerConfig{
 				Destination: "@match",
 			},
 		},
+		JWTRedirectLocations: []JWTRedirectLocation{
+			{
+				Name:     "@login_url_default_jwt-policy",
+				LoginURL: "https://login.example.com",
+			},
+		},
+		HealthChecks: []HealthCheck{
+			{
+				Name:                "test-upstream",
+				URI:                 "/healthz",
+				Interval:            "5s",
+				Jitter:              "0s",
+				Fails:               1,
+				Passes:              1,
+				Port:                8080,
+				ProxyPass:           "http://test-upstream",
+				ProxyConnectTimeout: "10s",
+				ProxyReadTimeout:    "10s",
+				ProxySendTimeout:    "10s",
+				Headers: map[string]string{
+					"Host": "my.service",
+				},
+				Match: "test-upstream_match",
+				ProxySSL: &ProxySSL{
+					Name: "my.service",
+				},
+			},
+		},
+		Wallarm: &Wallarm{
+			Mode:              "monitoring",
+			ModeAllowOverride: "on",
+			Fallback:          "on",
+			Instance:          "1",
+			ParseResponse:     "on",
+			ParseWebsocket:    "off",
+			UnpackResponse:    "on",
+			ParserDisable:     []string{"base64"},
+		},
 		Locations: []Location{
+			{
+				Path:      `~* "\.(jpg|png)$"`,
+				ProxyPass: "http://images",
+			},
+			{
+				Path:      "=/exact",
+				ProxyPass: "http://exact",
+			},
+			{
+				Path:      "/internal",
+				ProxyPass: "http://internal",
+				Allow:     []string{"10.0.0.0/8", "192.168.1.1"},
+			},
+			{
+				Path:      "/public",
+				ProxyPass: "http://public",
+				Deny:      []string{"10.0.0.0/8"},
+			},
+			{
+				Path:      "/protected",
+				ProxyPass: "http://protected",
+				JWTAuth: &JWTAuth{
+					Secret:               "/etc/nginx/secrets/default-jwk-secret",
+					Realm:                "My Product API",
+					Token:                "$http_token",
+					RedirectLocationName: "@login_url_default_jwt-policy",
+				},
+			},
+			{
+				Path:      "/secure",
+				ProxyPass: "https://secure",
+				ProxySSL: &ProxySSL{
+					TrustedCertificate: "/etc/nginx/secrets/default-backend-ca_ca",
+					VerifyDepth:        2,
+					Name:               "secure.example.com",
+					Certificate:        "/etc/nginx/secrets/default-backend-client-secret",
+					CertificateKey:     "/etc/nginx/secrets/default-backend-client-secret",
+				},
+			},
+			{
+				Path:                     "/grpc",
+				GRPCPass:                 "grpc://grpc-backend",
+				ProxyConnectTimeout:      "30s",
+				ProxyReadTimeout:         "31s",
+				ClientMaxBodySize:        "1m",
+				ProxyNextUpstream:        "error timeout",
+				ProxyNextUpstreamTimeout: "5s",
+				ProxyNextUpstreamTries:   2,
+				ProxySetHeaders: []Header{
+					{
+						Name:  "Host",
+						Value: "$host",
+					},
+				},
+			},
+			{
+				Path:      "/limited",
+				ProxyPass: "http://limited",
+				LimitReq: &LimitReq{
+					Zone:       "pol_rl_default_rate-limit_default_cafe",
+					Burst:      10,
+					NoDelay:    true,
+					DryRun:     true,
+					LogLevel:   "warn",
+					RejectCode: 429,
+				},
+			},
+			{
+				Path: "/restricted",
+				PoliciesErrorReturn: &Return{
+					Code: 500,
+				},
+			},
+			{
+				Path: "/maintenance",
+				Return: &Return{
+					Code: 503,
+					Type: "text/plain",
+					Text: "Service is under maintenance",
+				},
+			},
+			{
+				Path: "/old-coffee",
+				Return: &Return{
+					Code: 301,
+					Text: "https://example.com/coffee",
+				},
+			},
 			{
 				Path:                 "/",
 				Snippets:             []string{"# location snippet"},
 				ProxyConnectTimeout:  "30s",
 				ProxyReadTimeout:     "31s",
+				ProxySendTimeout:     "32s",
 				ClientMaxBodySize:    "1m",
 				ProxyBuffering:       true,
 				ProxyBuffers:         "8 4k",
 				ProxyBufferSize:      "4k",
 				ProxyMaxTempFileSize: "1024m",
 				ProxyPass:            "http://test-upstream",
+				HasKeepalive:         true,
+				ProxySetHeaders: []Header{
+					{Name: "Host", Value: "$host"},
+					{Name: "X-Request-Info", Value: "$request_method $request_uri"},
+				},
+				ProxyPassRequestHeaders: true,
+				ProxyHideHeaders:        []string{"X-Powered-By"},
+				ProxyPassHeaders:        []string{"Server"},
+				ProxyIgnoreHeaders:      "Expires Vary",
+				Rewrite: &Rewrite{
+					Regex:       "^/(.*)$",
+					Replacement: "/api/$1",
+				},
+				AddHeaders: []AddHeader{
+					{
+						Header: Header{Name: "X-Upstream", Value: "$upstream_addr"},
+						Always: true,
+					},
+				},
+				Wallarm: &Wallarm{
+					Mode:              "block",
+					ModeAllowOverride: "off",
+					Fallback:          "on",
+					BlockPage:         "/usr/share/nginx/html/wallarm_blocked.html",
+					ParseResponse:     "on",
+					ParseWebsocket:    "off",
+					UnpackResponse:    "on",
+				},
 			},
 			{
 				Path:                "@loc0",
@@ -154,11 +346,33 @@ var virtualServerCfg = VirtualServerConfig{
 			},
 		},
 	},
-	Keepalive: "10",
+}
+
+var transportServerCfg = TransportServerConfig{
+	Upstreams: []StreamUpstream{
+		{
+			Name: "udp-upstream",
+			Servers: []StreamUpstreamServer{
+				{
+					Address:     "10.0.0.20:5001",
+					MaxFails:    1,
+					FailTimeout: "10s",
+				},
+			},
+			LBMethod: "least_conn",
+		},
+	},
+	Server: StreamServer{
+		Port:                53,
+		UDP:                 true,
+		ProxyPass:           "udp-upstream",
+		ProxyConnectTimeout: "30s",
+		ProxyTimeout:        "10m",
+	},
 }
 
 func TestVirtualServerForNginxPlus(t *testing.T) {
-	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl)
+	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl)
 	if err != nil {
 		t.Fatalf("Failed to create template executor: %v", err)
 	}
@@ -172,7 +386,7 @@ func TestVirtualServerForNginxPlus(t *testing.T) {
 }
 
 func TestVirtualServerForNginx(t *testing.T) {
-	executor, err := NewTemplateExecutor(nginxVirtualServerTmpl)
+	executor, err := NewTemplateExecutor(nginxVirtualServerTmpl, nginxTransportServerTmpl)
 	if err != nil {
 		t.Fatalf("Failed to create template executor: %v", err)
 	}
@@ -184,3 +398,122 @@ func TestVirtualServerForNginx(t *testing.T) {
 
 	t.Log(string(data))
 }
+
+func TestTransportServerForNginxPlus(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	t.Log(string(data))
+}
+
+func TestTransportServerForNginx(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxVirtualServerTmpl, nginxTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	t.Log(string(data))
+}
+
+func TestExecuteTLSPassthroughHostsTemplate(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxVirtualServerTmpl, nginxTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	cfg := TLSPassthroughHostsConfig{
+		"app.example.com":   "unix:/var/lib/nginx/passthrough-ts_default_app.sock",
+		"other.example.com": "unix:/var/lib/nginx/passthrough-ts_default_other.sock",
+	}
+	expected := `app.example.com unix:/var/lib/nginx/passthrough-ts_default_app.sock;
+other.example.com unix:/var/lib/nginx/passthrough-ts_default_other.sock;
+`
+
+	data, err := executor.ExecuteTLSPassthroughHostsTemplate(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	if string(data) != expected {
+		t.Errorf("ExecuteTLSPassthroughHostsTemplate() returned %q but expected %q", string(data), expected)
+	}
+}
+
+func TestVirtualServerClientCertificate(t *testing.T) {
+	tests := []struct {
+		clientCertificate *ClientCertificate
+		expected          []string
+		unexpected        []string
+		msg               string
+	}{
+		{
+			clientCertificate: &ClientCertificate{CAFile: "cafe-ca.pem", Verify: "on", VerifyDepth: 1, ForwardToUpstream: true},
+			expected: []string{
+				"return 403;",
+				"proxy_set_header X-SSL-Client-Verify $ssl_client_verify;",
+				"grpc_set_header X-SSL-Client-Verify $ssl_client_verify;",
+			},
+			unexpected: []string{`X-SSL-Client-Verify "";`},
+			msg:        "required client certificate forwarded to upstreams",
+		},
+		{
+			clientCertificate: &ClientCertificate{CAFile: "cafe-ca.pem", Verify: "optional", VerifyDepth: 1},
+			expected: []string{
+				`proxy_set_header X-SSL-Client-Verify "";`,
+				`grpc_set_header X-SSL-Client-Verify "";`,
+			},
+			unexpected: []string{"return 403;", "$ssl_client_verify"},
+			msg:        "optional client certificate not forwarded to upstreams",
+		},
+		{
+			expected: []string{
+				`proxy_set_header X-SSL-Client-Cert "";`,
+				`proxy_set_header X-SSL-Client-S-DN "";`,
+			},
+			unexpected: []string{"return 403;", "$ssl_client_verify"},
+			msg:        "no client certificate",
+		},
+	}
+
+	for _, tmpl := range []string{nginxVirtualServerTmpl, nginxPlusVirtualServerTmpl} {
+		executor, err := NewTemplateExecutor(tmpl, nginxTransportServerTmpl)
+		if err != nil {
+			t.Fatalf("Failed to create template executor: %v", err)
+		}
+
+		for _, test := range tests {
+			cfg := virtualServerCfg
+			ssl := *cfg.Server.SSL
+			ssl.ClientCertificate = test.clientCertificate
+			cfg.Server.SSL = &ssl
+
+			data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+			if err != nil {
+				t.Fatalf("Failed to execute template %v: %v", tmpl, err)
+			}
+
+			for _, s := range test.expected {
+				if !strings.Contains(string(data), s) {
+					t.Errorf("Template %v doesn't include %q for the case of %s", tmpl, s, test.msg)
+				}
+			}
+			for _, s := range test.unexpected {
+				if strings.Contains(string(data), s) {
+					t.Errorf("Template %v includes %q for the case of %s", tmpl, s, test.msg)
+				}
+			}
+		}
+	}
+}
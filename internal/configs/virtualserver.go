@@ -1,24 +1,52 @@
 package configs
 
 import (
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 )
 
 const nginx502Server = "unix:/var/run/nginx-502-server.sock"
 
+// defaultProxySendTimeout is the default value of the proxy_send_timeout directive.
+const defaultProxySendTimeout = "60s"
+
+// The defaults for the redirect and return actions of routes.
+const (
+	defaultRedirectCode = 301
+	defaultReturnCode   = 200
+	defaultReturnType   = "text/plain"
+)
+
+// defaultLimitReqZoneSize is the default size of the zones for the rate limit policies.
+const defaultLimitReqZoneSize = "10m"
+
 // VirtualServerEx holds a VirtualServer along with the resources that are referenced in this VirtualServer.
 type VirtualServerEx struct {
 	VirtualServer       *conf_v1alpha1.VirtualServer
 	Endpoints           map[string][]string
 	TLSSecret           *api_v1.Secret
 	VirtualServerRoutes []*conf_v1alpha1.VirtualServerRoute
+	HealthChecks        map[string]*api_v1.Probe
+	Policies            map[string]*conf_v1alpha1.Policy
+	JWTKeys             map[string]*api_v1.Secret
+	ClientCASecret      *api_v1.Secret
+	UpstreamCASecrets   map[string]*api_v1.Secret
+	UpstreamTLSSecrets  map[string]*api_v1.Secret
+	// LocalZoneEndpoints holds the endpoints from the zone of the Ingress Controller.
+	// It is nil if the zone of the Ingress Controller is unknown.
+	LocalZoneEndpoints map[string]bool
 }
 
 func (vsx *VirtualServerEx) String() string {
@@ -33,28 +61,64 @@ func (vsx *VirtualServerEx) String() string {
 	return fmt.Sprintf("%s/%s", vsx.VirtualServer.Namespace, vsx.VirtualServer.Name)
 }
 
+// GeneratePolicyKey generates a key for the Policies map in VirtualServerEx.
+// If the reference doesn't specify a namespace, the namespace of the resource that defines the reference is used.
+func GeneratePolicyKey(policyRef conf_v1alpha1.PolicyReference, ownerNamespace string) string {
+	namespace := policyRef.Namespace
+	if namespace == "" {
+		namespace = ownerNamespace
+	}
+	return fmt.Sprintf("%s/%s", namespace, policyRef.Name)
+}
+
 // GenerateEndpointsKey generates a key for the Endpoints map in VirtualServerEx.
-func GenerateEndpointsKey(serviceNamespace string, serviceName string, port uint16) string {
+// Upstreams of the same service with different subselectors get different keys.
+func GenerateEndpointsKey(serviceNamespace string, serviceName string, subselector map[string]string, port uint16) string {
+	if len(subselector) > 0 {
+		return fmt.Sprintf("%s/%s_%s:%d", serviceNamespace, serviceName, labels.Set(subselector).String(), port)
+	}
 	return fmt.Sprintf("%s/%s:%d", serviceNamespace, serviceName, port)
 }
 
 type upstreamNamer struct {
 	prefix string
+	// subselectors holds the subselectors of the upstreams, keyed by the name of the upstream.
+	subselectors map[string]map[string]string
 }
 
 func newUpstreamNamerForVirtualServer(virtualServer *conf_v1alpha1.VirtualServer) *upstreamNamer {
 	return &upstreamNamer{
-		prefix: fmt.Sprintf("vs_%s_%s", virtualServer.Namespace, virtualServer.Name),
+		prefix:       fmt.Sprintf("vs_%s_%s", virtualServer.Namespace, virtualServer.Name),
+		subselectors: getUpstreamSubselectors(virtualServer.Spec.Upstreams),
 	}
 }
 
 func newUpstreamNamerForVirtualServerRoute(virtualServer *conf_v1alpha1.VirtualServer, virtualServerRoute *conf_v1alpha1.VirtualServerRoute) *upstreamNamer {
 	return &upstreamNamer{
-		prefix: fmt.Sprintf("vs_%s_%s_vsr_%s_%s", virtualServer.Namespace, virtualServer.Name, virtualServerRoute.Namespace, virtualServerRoute.Name),
+		prefix:       fmt.Sprintf("vs_%s_%s_vsr_%s_%s", virtualServer.Namespace, virtualServer.Name, virtualServerRoute.Namespace, virtualServerRoute.Name),
+		subselectors: getUpstreamSubselectors(virtualServerRoute.Spec.Upstreams),
+	}
+}
+
+func getUpstreamSubselectors(upstreams []conf_v1alpha1.Upstream) map[string]map[string]string {
+	subselectors := make(map[string]map[string]string)
+	for _, u := range upstreams {
+		if len(u.Subselector) > 0 {
+			subselectors[u.Name] = u.Subselector
+		}
 	}
+	return subselectors
 }
 
+// GetNameForUpstream returns the name of the NGINX upstream. The name of an upstream with a subselector includes
+// a hash of the subselector, so that a change of the subselector creates a new upstream instead of reusing the state,
+// such as the servers and the health checks, of the pods that the upstream selected before.
 func (namer *upstreamNamer) GetNameForUpstream(upstream string) string {
+	if subselector, exists := namer.subselectors[upstream]; exists {
+		h := fnv.New32a()
+		h.Write([]byte(labels.Set(subselector).String()))
+		return fmt.Sprintf("%s_%s_%08x", namer.prefix, upstream, h.Sum32())
+	}
 	return fmt.Sprintf("%s_%s", namer.prefix, upstream)
 }
 
@@ -73,6 +137,10 @@ func (namer *variableNamer) GetNameForSplitClientVariable(index int) string {
 	return fmt.Sprintf("$vs_%s_splits_%d", namer.safeNsName, index)
 }
 
+func (namer *variableNamer) GetNameForSplitCookieMapVariable(index int) string {
+	return fmt.Sprintf("$vs_%s_splits_%d_cookie", namer.safeNsName, index)
+}
+
 func (namer *variableNamer) GetNameForVariableForRulesRouteMap(rulesIndex int, matchIndex int, conditionIndex int) string {
 	return fmt.Sprintf("$vs_%s_rules_%d_match_%d_cond_%d", namer.safeNsName, rulesIndex, matchIndex, conditionIndex)
 }
@@ -81,55 +149,118 @@ func (namer *variableNamer) GetNameForVariableForRulesRouteMainMap(rulesIndex in
 	return fmt.Sprintf("$vs_%s_rules_%d", namer.safeNsName, rulesIndex)
 }
 
-func generateVirtualServerConfig(virtualServerEx *VirtualServerEx, tlsPemFileName string, baseCfgParams *ConfigParams, isPlus bool) version2.VirtualServerConfig {
-	ssl := generateSSLConfig(virtualServerEx.VirtualServer.Spec.TLS, tlsPemFileName, baseCfgParams)
+func generateVirtualServerConfig(virtualServerEx *VirtualServerEx, tlsPemFileName string, clientCAFileName string, jwtKeyFileNames map[string]string,
+	upstreamCAFileNames map[string]string, upstreamPemFileNames map[string]string, baseCfgParams *ConfigParams, isPlus bool,
+	staticParams *StaticConfigParams) version2.VirtualServerConfig {
+	ssl := generateSSLConfig(virtualServerEx.VirtualServer.Spec.TLS, tlsPemFileName, virtualServerEx.ClientCASecret, clientCAFileName, baseCfgParams)
 
 	virtualServerUpstreamNamer := newUpstreamNamerForVirtualServer(virtualServerEx.VirtualServer)
 
 	var upstreams []version2.Upstream
+	var healthChecks []version2.HealthCheck
+	var matches []version2.Match
+	crUpstreams := make(map[string]conf_v1alpha1.Upstream)
+	proxySSLs := make(map[string]*version2.ProxySSL)
+	var sessionCookieMaps []version2.Map
+	setSessionCookies := make(map[string]string)
 
 	// generate upstreams for VirtualServer
 	for _, u := range virtualServerEx.VirtualServer.Spec.Upstreams {
 		upstreamName := virtualServerUpstreamNamer.GetNameForUpstream(u.Name)
-		endpointsKey := GenerateEndpointsKey(virtualServerEx.VirtualServer.Namespace, u.Service, u.Port)
-		ups := generateUpstream(upstreamName, virtualServerEx.Endpoints[endpointsKey], isPlus, baseCfgParams)
+		endpointsKey := GenerateEndpointsKey(virtualServerEx.VirtualServer.Namespace, u.Service, u.Subselector, u.Port)
+		ups := generateUpstream(upstreamName, u, virtualServerEx.Endpoints[endpointsKey], virtualServerEx.LocalZoneEndpoints, isPlus, baseCfgParams)
 		upstreams = append(upstreams, ups)
+		u.Type = generateUpstreamType(u, ssl)
+		crUpstreams[upstreamName] = u
+		proxySSLs[upstreamName] = generateProxySSL(u.TLS, virtualServerEx.VirtualServer.Namespace, virtualServerEx.UpstreamCASecrets, upstreamCAFileNames, upstreamPemFileNames)
+		if u.SessionCookie != nil && u.SessionCookie.Enable && !isPlus {
+			sessionCookieMaps = append(sessionCookieMaps, generateSessionCookieMaps(upstreamName, u.SessionCookie)...)
+			setSessionCookies[upstreamName] = getNameForSetSessionCookieVariable(upstreamName)
+		}
+
+		if hc := generateHealthCheck(u, upstreamName, baseCfgParams, virtualServerEx.HealthChecks[endpointsKey], isPlus); hc != nil {
+			hc.ProxySSL = proxySSLs[upstreamName]
+			healthChecks = append(healthChecks, *hc)
+			if hc.Match != "" {
+				matches = append(matches, generateUpstreamMatch(hc.Match, u.HealthCheck))
+			}
+		}
 	}
 	// generate upstreams for each VirtualServerRoute
 	for _, vsr := range virtualServerEx.VirtualServerRoutes {
 		upstreamNamer := newUpstreamNamerForVirtualServerRoute(virtualServerEx.VirtualServer, vsr)
 		for _, u := range vsr.Spec.Upstreams {
 			upstreamName := upstreamNamer.GetNameForUpstream(u.Name)
-			endpointsKey := GenerateEndpointsKey(vsr.Namespace, u.Service, u.Port)
-			ups := generateUpstream(upstreamName, virtualServerEx.Endpoints[endpointsKey], isPlus, baseCfgParams)
+			endpointsKey := GenerateEndpointsKey(vsr.Namespace, u.Service, u.Subselector, u.Port)
+			ups := generateUpstream(upstreamName, u, virtualServerEx.Endpoints[endpointsKey], virtualServerEx.LocalZoneEndpoints, isPlus, baseCfgParams)
 			upstreams = append(upstreams, ups)
+			u.Type = generateUpstreamType(u, ssl)
+			crUpstreams[upstreamName] = u
+			proxySSLs[upstreamName] = generateProxySSL(u.TLS, vsr.Namespace, virtualServerEx.UpstreamCASecrets, upstreamCAFileNames, upstreamPemFileNames)
+			if u.SessionCookie != nil && u.SessionCookie.Enable && !isPlus {
+				sessionCookieMaps = append(sessionCookieMaps, generateSessionCookieMaps(upstreamName, u.SessionCookie)...)
+				setSessionCookies[upstreamName] = getNameForSetSessionCookieVariable(upstreamName)
+			}
+
+			if hc := generateHealthCheck(u, upstreamName, baseCfgParams, virtualServerEx.HealthChecks[endpointsKey], isPlus); hc != nil {
+				hc.ProxySSL = proxySSLs[upstreamName]
+				healthChecks = append(healthChecks, *hc)
+				if hc.Match != "" {
+					matches = append(matches, generateUpstreamMatch(hc.Match, u.HealthCheck))
+				}
+			}
 		}
 	}
 
 	var locations []version2.Location
 	var internalRedirectLocations []version2.InternalRedirectLocation
 	var splitClients []version2.SplitClient
-	var maps []version2.Map
+	maps := sessionCookieMaps
 
 	rulesRoutes := 0
 
 	variableNamer := newVariableNamer(virtualServerEx.VirtualServer)
 
+	var serverWallarm *version2.Wallarm
+	if baseCfgParams.MainEnableWallarm {
+		serverWallarm = generateWallarm(newDefaultWallarm(), virtualServerEx.VirtualServer.Spec.Wallarm)
+	}
+
+	// Wallarm configuration of the routes that reference VirtualServerRoutes, applied to their subroutes
+	vsrWallarms := make(map[string]*version2.Wallarm)
+
+	specPolicies := generatePolicies(virtualServerEx.VirtualServer.Spec.Policies, virtualServerEx.Policies, virtualServerEx.VirtualServer.Namespace, virtualServerEx.VirtualServer, jwtKeyFileNames)
+	var limitReqZones []version2.LimitReqZone
+	var jwtRedirectLocations []version2.JWTRedirectLocation
+
+	// policies of the routes that reference VirtualServerRoutes, applied to their subroutes
+	vsrPolicies := make(map[string]policiesCfg)
+
 	// generates config for VirtualServer routes
 	for _, r := range virtualServerEx.VirtualServer.Spec.Routes {
 		// ignore routes that reference VirtualServerRoute
 		if r.Route != "" {
+			vsrKey := getVirtualServerRouteKey(r.Route, virtualServerEx.VirtualServer.Namespace)
+			if serverWallarm != nil && r.Wallarm != nil {
+				vsrWallarms[vsrKey] = generateWallarm(serverWallarm, r.Wallarm)
+			}
+			if len(r.Policies) > 0 {
+				vsrPolicies[vsrKey] = generatePolicies(r.Policies, virtualServerEx.Policies, virtualServerEx.VirtualServer.Namespace, virtualServerEx.VirtualServer, jwtKeyFileNames)
+			}
 			continue
 		}
 
+		routeLocationsStart := len(locations)
+
 		if len(r.Splits) > 0 {
-			splitCfg := generateSplitRouteConfig(r, virtualServerUpstreamNamer, variableNamer, len(splitClients), baseCfgParams)
+			splitCfg := generateSplitRouteConfig(r, virtualServerUpstreamNamer, crUpstreams, proxySSLs, variableNamer, len(splitClients), baseCfgParams)
 
 			splitClients = append(splitClients, splitCfg.SplitClient)
+			maps = append(maps, splitCfg.Maps...)
 			locations = append(locations, splitCfg.Locations...)
 			internalRedirectLocations = append(internalRedirectLocations, splitCfg.InternalRedirectLocation)
 		} else if r.Rules != nil {
-			rulesRouteCfg := generateRulesRouteConfig(r, virtualServerUpstreamNamer, variableNamer, rulesRoutes, baseCfgParams)
+			rulesRouteCfg := generateRulesRouteConfig(r, virtualServerUpstreamNamer, crUpstreams, proxySSLs, variableNamer, rulesRoutes, baseCfgParams)
 
 			maps = append(maps, rulesRouteCfg.Maps...)
 			locations = append(locations, rulesRouteCfg.Locations...)
@@ -137,24 +268,49 @@ func generateVirtualServerConfig(virtualServerEx *VirtualServerEx, tlsPemFileNam
 
 			rulesRoutes++
 		} else {
-			upstreamName := virtualServerUpstreamNamer.GetNameForUpstream(r.Upstream)
-			loc := generateLocation(r.Path, upstreamName, baseCfgParams)
+			rewrite := generateRewrite(r.Path, r.RewritePath)
+			loc := generateLocationForRoute(generateLocationPath(r.Path), r.Upstream, r.Action, rewrite, virtualServerUpstreamNamer, crUpstreams, proxySSLs, baseCfgParams)
 			locations = append(locations, loc)
 		}
+
+		setHeadersForLocations(locations[routeLocationsStart:], r.RequestHeaders, r.ResponseHeaders)
+
+		routePolicies := specPolicies
+		if len(r.Policies) > 0 {
+			routePolicies = generatePolicies(r.Policies, virtualServerEx.Policies, virtualServerEx.VirtualServer.Namespace, virtualServerEx.VirtualServer, jwtKeyFileNames)
+		}
+		setPoliciesForLocations(locations[routeLocationsStart:], routePolicies)
+		limitReqZones = addLimitReqZones(limitReqZones, routePolicies.LimitReqZones)
+		jwtRedirectLocations = addJWTRedirectLocations(jwtRedirectLocations, routePolicies.JWTRedirectLocations)
+
+		if serverWallarm != nil && r.Wallarm != nil {
+			setWallarmForLocations(locations[routeLocationsStart:], generateWallarm(serverWallarm, r.Wallarm))
+		}
 	}
 
 	// generate config for subroutes of each VirtualServerRoute
 	for _, vsr := range virtualServerEx.VirtualServerRoutes {
 		upstreamNamer := newUpstreamNamerForVirtualServerRoute(virtualServerEx.VirtualServer, vsr)
+		vsrKey := fmt.Sprintf("%s/%s", vsr.Namespace, vsr.Name)
+		vsrWallarm := vsrWallarms[vsrKey]
+
+		basePolicies := specPolicies
+		if p, exists := vsrPolicies[vsrKey]; exists {
+			basePolicies = p
+		}
+
 		for _, r := range vsr.Spec.Subroutes {
+			routeLocationsStart := len(locations)
+
 			if len(r.Splits) > 0 {
-				splitCfg := generateSplitRouteConfig(r, upstreamNamer, variableNamer, len(splitClients), baseCfgParams)
+				splitCfg := generateSplitRouteConfig(r, upstreamNamer, crUpstreams, proxySSLs, variableNamer, len(splitClients), baseCfgParams)
 
 				splitClients = append(splitClients, splitCfg.SplitClient)
+				maps = append(maps, splitCfg.Maps...)
 				locations = append(locations, splitCfg.Locations...)
 				internalRedirectLocations = append(internalRedirectLocations, splitCfg.InternalRedirectLocation)
 			} else if r.Rules != nil {
-				rulesRouteCfg := generateRulesRouteConfig(r, upstreamNamer, variableNamer, rulesRoutes, baseCfgParams)
+				rulesRouteCfg := generateRulesRouteConfig(r, upstreamNamer, crUpstreams, proxySSLs, variableNamer, rulesRoutes, baseCfgParams)
 
 				maps = append(maps, rulesRouteCfg.Maps...)
 				locations = append(locations, rulesRouteCfg.Locations...)
@@ -162,25 +318,45 @@ func generateVirtualServerConfig(virtualServerEx *VirtualServerEx, tlsPemFileNam
 
 				rulesRoutes++
 			} else {
-				upstreamName := upstreamNamer.GetNameForUpstream(r.Upstream)
-				loc := generateLocation(r.Path, upstreamName, baseCfgParams)
+				rewrite := generateRewrite(r.Path, r.RewritePath)
+				loc := generateLocationForRoute(generateLocationPath(r.Path), r.Upstream, r.Action, rewrite, upstreamNamer, crUpstreams, proxySSLs, baseCfgParams)
 				locations = append(locations, loc)
 			}
+
+			setHeadersForLocations(locations[routeLocationsStart:], r.RequestHeaders, r.ResponseHeaders)
+
+			routePolicies := basePolicies
+			if len(r.Policies) > 0 {
+				routePolicies = generatePolicies(r.Policies, virtualServerEx.Policies, vsr.Namespace, virtualServerEx.VirtualServer, jwtKeyFileNames)
+			}
+			setPoliciesForLocations(locations[routeLocationsStart:], routePolicies)
+			limitReqZones = addLimitReqZones(limitReqZones, routePolicies.LimitReqZones)
+			jwtRedirectLocations = addJWTRedirectLocations(jwtRedirectLocations, routePolicies.JWTRedirectLocations)
+
+			if serverWallarm != nil && r.Wallarm != nil {
+				base := serverWallarm
+				if vsrWallarm != nil {
+					base = vsrWallarm
+				}
+				setWallarmForLocations(locations[routeLocationsStart:], generateWallarm(base, r.Wallarm))
+			} else if vsrWallarm != nil {
+				setWallarmForLocations(locations[routeLocationsStart:], vsrWallarm)
+			}
 		}
 	}
 
-	keepalive := ""
-	if baseCfgParams.Keepalive > 0 {
-		keepalive = fmt.Sprint(baseCfgParams.Keepalive)
-	}
+	setSessionCookieForLocations(locations, setSessionCookies)
 
 	return version2.VirtualServerConfig{
-		Upstreams:    upstreams,
-		SplitClients: splitClients,
-		Maps:         maps,
+		Upstreams:     upstreams,
+		SplitClients:  splitClients,
+		Maps:          maps,
+		Matches:       matches,
+		LimitReqZones: limitReqZones,
 		Server: version2.Server{
 			ServerName:                            virtualServerEx.VirtualServer.Spec.Host,
 			ProxyProtocol:                         baseCfgParams.ProxyProtocol,
+			TLSPassthrough:                        staticParams.TLSPassthrough,
 			SSL:                                   ssl,
 			RedirectToHTTPSBasedOnXForwarderProto: baseCfgParams.RedirectToHTTPS,
 			ServerTokens:                          baseCfgParams.ServerTokens,
@@ -189,20 +365,40 @@ func generateVirtualServerConfig(virtualServerEx *VirtualServerEx, tlsPemFileNam
 			RealIPRecursive:                       baseCfgParams.RealIPRecursive,
 			Snippets:                              baseCfgParams.ServerSnippets,
 			InternalRedirectLocations:             internalRedirectLocations,
+			JWTRedirectLocations:                  jwtRedirectLocations,
 			Locations:                             locations,
+			HealthChecks:                          healthChecks,
+			Wallarm:                               serverWallarm,
 		},
-		Keepalive: keepalive,
 	}
 }
 
-func generateUpstream(upstreamName string, endpoints []string, isPlus bool, cfgParams *ConfigParams) version2.Upstream {
+func generateUpstream(upstreamName string, upstream conf_v1alpha1.Upstream, endpoints []string, localEndpoints map[string]bool,
+	isPlus bool, cfgParams *ConfigParams) version2.Upstream {
 	var upsServers []version2.UpstreamServer
 
+	maxFails := generateIntFromPointer(upstream.MaxFails, cfgParams.MaxFails)
+	maxConns := generateIntFromPointer(upstream.MaxConns, 0)
+	failTimeout := generateString(upstream.FailTimeout, cfgParams.FailTimeout)
+
+	lbMethod := generateLBMethod(upstream.LBMethod, cfgParams.LBMethod, isPlus)
+	if upstream.SessionCookie != nil && upstream.SessionCookie.Enable && !isPlus {
+		// NGINX doesn't support the sticky directive, so we fall back to a consistent hash on the session ID
+		lbMethod = generateLBMethodForSessionCookie(upstreamName)
+	}
+
+	var backupEndpoints map[string]bool
+	if upstream.ZoneAwareRouting {
+		backupEndpoints, lbMethod = generateZoneAwareRouting(upstreamName, endpoints, localEndpoints, lbMethod, cfgParams.ZoneAwareRoutingMinEndpoints)
+	}
+
 	for _, e := range endpoints {
 		s := version2.UpstreamServer{
 			Address:     e,
-			MaxFails:    cfgParams.MaxFails,
-			FailTimeout: cfgParams.FailTimeout,
+			MaxFails:    maxFails,
+			MaxConns:    maxConns,
+			FailTimeout: failTimeout,
+			Backup:      backupEndpoints[e],
 		}
 		upsServers = append(upsServers, s)
 	}
@@ -210,44 +406,777 @@ func generateUpstream(upstreamName string, endpoints []string, isPlus bool, cfgP
 	if !isPlus && len(upsServers) == 0 {
 		s := version2.UpstreamServer{
 			Address:     nginx502Server,
-			MaxFails:    cfgParams.MaxFails,
-			FailTimeout: cfgParams.FailTimeout,
+			MaxFails:    maxFails,
+			FailTimeout: failTimeout,
 		}
 		upsServers = append(upsServers, s)
 	}
 
 	ups := version2.Upstream{
-		Name:     upstreamName,
-		Servers:  upsServers,
-		LBMethod: cfgParams.LBMethod,
+		Name:      upstreamName,
+		Servers:   upsServers,
+		LBMethod:  lbMethod,
+		Keepalive: generateIntFromPointer(upstream.Keepalive, int(cfgParams.Keepalive)),
+	}
+
+	if upstream.SessionCookie != nil && upstream.SessionCookie.Enable && isPlus {
+		ups.SessionCookie = generateSessionCookie(upstream.SessionCookie)
 	}
 
 	return ups
 }
 
-func generateLocation(path string, upstreamName string, cfgParams *ConfigParams) version2.Location {
+func generateSessionCookie(sc *conf_v1alpha1.SessionCookie) *version2.SessionCookie {
+	return &version2.SessionCookie{
+		Name:     sc.Name,
+		Path:     sc.Path,
+		Expires:  sc.Expires,
+		Domain:   sc.Domain,
+		HTTPOnly: sc.HTTPOnly,
+		Secure:   sc.Secure,
+	}
+}
+
+func generateLBMethodForSessionCookie(upstreamName string) string {
+	return fmt.Sprintf("hash %s consistent", getNameForSessionIDVariable(upstreamName))
+}
+
+func getNameForSessionIDVariable(upstreamName string) string {
+	return fmt.Sprintf("$%s_session_id", strings.ReplaceAll(upstreamName, "-", "_"))
+}
+
+func getNameForSetSessionCookieVariable(upstreamName string) string {
+	return fmt.Sprintf("$%s_set_session_cookie", strings.ReplaceAll(upstreamName, "-", "_"))
+}
+
+// generateSessionCookieMaps generates the maps that emulate the sticky cookie method of NGINX Plus for an upstream in NGINX.
+// The first map takes the session ID from the cookie or, if the client doesn't send the cookie, generates a new one.
+// The second map sets the Set-Cookie header with the new session ID and is empty if the client already sends the cookie.
+func generateSessionCookieMaps(upstreamName string, sc *conf_v1alpha1.SessionCookie) []version2.Map {
+	source := fmt.Sprintf("$cookie_%s", sc.Name)
+
+	return []version2.Map{
+		{
+			Source:   source,
+			Variable: getNameForSessionIDVariable(upstreamName),
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: "$request_id"},
+				{Value: "default", Result: source},
+			},
+		},
+		{
+			Source:   source,
+			Variable: getNameForSetSessionCookieVariable(upstreamName),
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: fmt.Sprintf(`"%s"`, generateSetSessionCookie(sc))},
+				{Value: "default", Result: `""`},
+			},
+		},
+	}
+}
+
+// generateSetSessionCookie generates the value of the Set-Cookie header with the session ID, using the same attributes
+// as the sticky cookie method of NGINX Plus.
+func generateSetSessionCookie(sc *conf_v1alpha1.SessionCookie) string {
+	cookie := []string{fmt.Sprintf("%s=$request_id", sc.Name)}
+
+	if sc.Expires == "max" {
+		cookie = append(cookie, "Expires=Thu, 31 Dec 2037 23:55:55 GMT", "Max-Age=315360000")
+	} else if sc.Expires != "" {
+		cookie = append(cookie, fmt.Sprintf("Max-Age=%d", convertTimeToSeconds(sc.Expires)))
+	}
+	if sc.Domain != "" {
+		cookie = append(cookie, fmt.Sprintf("Domain=%s", sc.Domain))
+	}
+	if sc.HTTPOnly {
+		cookie = append(cookie, "HttpOnly")
+	}
+	if sc.Secure {
+		cookie = append(cookie, "Secure")
+	}
+	if sc.Path != "" {
+		cookie = append(cookie, fmt.Sprintf("Path=%s", sc.Path))
+	}
+
+	return strings.Join(cookie, "; ")
+}
+
+var nginxTimeUnits = map[string]int{
+	"":  1,
+	"s": 1,
+	"m": 60,
+	"h": 60 * 60,
+	"d": 24 * 60 * 60,
+	"w": 7 * 24 * 60 * 60,
+	"M": 30 * 24 * 60 * 60,
+	"y": 365 * 24 * 60 * 60,
+}
+
+var nginxTimePart = regexp.MustCompile(`(\d+)(ms|[smhdwMy]?)`)
+
+// convertTimeToSeconds converts a valid time in the NGINX format, for example, 1h 30m, to seconds.
+// Milliseconds are rounded down.
+func convertTimeToSeconds(time string) int {
+	seconds := 0
+	milliseconds := 0
+
+	for _, part := range nginxTimePart.FindAllStringSubmatch(time, -1) {
+		n, _ := strconv.Atoi(part[1])
+		if part[2] == "ms" {
+			milliseconds += n
+			continue
+		}
+		seconds += n * nginxTimeUnits[part[2]]
+	}
+
+	return seconds + milliseconds/1000
+}
+
+// setSessionCookieForLocations adds the Set-Cookie header of the session cookie to the locations that pass requests
+// to the upstreams with the session cookie in NGINX. setSessionCookies maps the upstream names to the variables
+// with the value of the header.
+func setSessionCookieForLocations(locations []version2.Location, setSessionCookies map[string]string) {
+	for i := range locations {
+		pass := locations[i].ProxyPass
+		if pass == "" {
+			pass = locations[i].GRPCPass
+		}
+
+		parts := strings.SplitN(pass, "://", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		if variable, exists := setSessionCookies[parts[1]]; exists {
+			locations[i].AddHeaders = append(locations[i].AddHeaders, version2.AddHeader{
+				Header: version2.Header{Name: "Set-Cookie", Value: variable},
+			})
+		}
+	}
+}
+
+// generateHealthCheck generates an active health check for an upstream. NGINX Plus only.
+// The parameters of the readiness probe of the pods of the upstream, if available, are used
+// as the defaults for the parameters that are not set in the health check of the upstream.
+func generateHealthCheck(upstream conf_v1alpha1.Upstream, upstreamName string, cfgParams *ConfigParams, probe *api_v1.Probe, isPlus bool) *version2.HealthCheck {
+	if !isPlus || upstream.HealthCheck == nil || !upstream.HealthCheck.Enable {
+		return nil
+	}
+
+	hc := newHealthCheckWithDefaults(upstream, upstreamName, cfgParams)
+
+	if probe != nil && probe.HTTPGet != nil {
+		if probe.HTTPGet.Path != "" {
+			hc.URI = probe.HTTPGet.Path
+		}
+		if probe.PeriodSeconds > 0 {
+			hc.Interval = fmt.Sprintf("%ds", probe.PeriodSeconds)
+		}
+		if probe.FailureThreshold > 0 {
+			hc.Fails = int(probe.FailureThreshold)
+		}
+		if probe.SuccessThreshold > 0 {
+			hc.Passes = int(probe.SuccessThreshold)
+		}
+		if probe.TimeoutSeconds > 0 {
+			timeout := fmt.Sprintf("%ds", probe.TimeoutSeconds)
+			hc.ProxyConnectTimeout = timeout
+			hc.ProxyReadTimeout = timeout
+			hc.ProxySendTimeout = timeout
+		}
+		if probe.HTTPGet.Scheme == api_v1.URISchemeHTTPS {
+			hc.ProxyPass = fmt.Sprintf("https://%v", upstreamName)
+		}
+		for _, h := range probe.HTTPGet.HTTPHeaders {
+			hc.Headers[h.Name] = h.Value
+		}
+	}
+
+	upstreamHC := upstream.HealthCheck
+
+	hc.URI = generateString(upstreamHC.Path, hc.URI)
+	hc.Interval = generateString(upstreamHC.Interval, hc.Interval)
+	hc.Jitter = generateString(upstreamHC.Jitter, hc.Jitter)
+	hc.Fails = generateIntFromPointer(upstreamHC.Fails, hc.Fails)
+	hc.Passes = generateIntFromPointer(upstreamHC.Passes, hc.Passes)
+	hc.Port = upstreamHC.Port
+	hc.ProxyConnectTimeout = generateString(upstreamHC.ConnectTimeout, hc.ProxyConnectTimeout)
+	hc.ProxyReadTimeout = generateString(upstreamHC.ReadTimeout, hc.ProxyReadTimeout)
+	hc.ProxySendTimeout = generateString(upstreamHC.SendTimeout, hc.ProxySendTimeout)
+
+	if upstreamHC.TLS != nil {
+		if upstreamHC.TLS.Enable {
+			hc.ProxyPass = fmt.Sprintf("https://%v", upstreamName)
+		} else {
+			hc.ProxyPass = fmt.Sprintf("http://%v", upstreamName)
+		}
+	}
+
+	for _, h := range upstreamHC.Headers {
+		hc.Headers[h.Name] = h.Value
+	}
+
+	if upstreamHC.StatusMatch != "" || upstreamHC.BodyMatch != "" {
+		hc.Match = generateUpstreamMatchName(upstreamName)
+	}
+
+	return hc
+}
+
+func newHealthCheckWithDefaults(upstream conf_v1alpha1.Upstream, upstreamName string, cfgParams *ConfigParams) *version2.HealthCheck {
+	return &version2.HealthCheck{
+		Name:                upstreamName,
+		URI:                 "/",
+		Interval:            "5s",
+		Jitter:              "0s",
+		Fails:               1,
+		Passes:              1,
+		ProxyPass:           fmt.Sprintf("%v://%v", generateProxyPassProtocol(upstream.TLS), upstreamName),
+		ProxyConnectTimeout: generateString(upstream.ProxyConnectTimeout, cfgParams.ProxyConnectTimeout),
+		ProxyReadTimeout:    generateString(upstream.ProxyReadTimeout, cfgParams.ProxyReadTimeout),
+		ProxySendTimeout:    generateString(upstream.ProxySendTimeout, defaultProxySendTimeout),
+		Headers:             make(map[string]string),
+	}
+}
+
+func generateUpstreamMatchName(upstreamName string) string {
+	return fmt.Sprintf("%s_match", upstreamName)
+}
+
+func generateUpstreamMatch(name string, healthCheck *conf_v1alpha1.HealthCheck) version2.Match {
+	return version2.Match{
+		Name:   name,
+		Status: healthCheck.StatusMatch,
+		Body:   healthCheck.BodyMatch,
+	}
+}
+
+// generateLBMethod returns the load balancing method of an upstream or the default one if the method is not set.
+// The method is expected to be validated.
+func generateLBMethod(method string, defaultMethod string, isPlus bool) string {
+	if method == "" {
+		return defaultMethod
+	}
+
+	if isPlus {
+		method, _ = ParseLBMethodForPlus(method)
+	} else {
+		method, _ = ParseLBMethod(method)
+	}
+
+	return method
+}
+
+func generateIntFromPointer(n *int, defaultN int) int {
+	if n == nil {
+		return defaultN
+	}
+	return *n
+}
+
+func generateString(s string, defaultS string) string {
+	if s == "" {
+		return defaultS
+	}
+	return s
+}
+
+func generateBool(b *bool, defaultB bool) bool {
+	if b == nil {
+		return defaultB
+	}
+	return *b
+}
+
+func generateBuffers(buffers *conf_v1alpha1.UpstreamBuffers, defaultBuffers string) string {
+	if buffers == nil {
+		return defaultBuffers
+	}
+	return fmt.Sprintf("%d %s", buffers.Number, buffers.Size)
+}
+
+func generateLocation(path string, upstreamName string, upstream conf_v1alpha1.Upstream, cfgParams *ConfigParams) version2.Location {
 	loc := version2.Location{
-		Path:                 path,
-		Snippets:             cfgParams.LocationSnippets,
-		ProxyConnectTimeout:  cfgParams.ProxyConnectTimeout,
-		ProxyReadTimeout:     cfgParams.ProxyReadTimeout,
-		ClientMaxBodySize:    cfgParams.ClientMaxBodySize,
-		ProxyMaxTempFileSize: cfgParams.ProxyMaxTempFileSize,
-		ProxyBuffering:       cfgParams.ProxyBuffering,
-		ProxyBuffers:         cfgParams.ProxyBuffers,
-		ProxyBufferSize:      cfgParams.ProxyBufferSize,
-		ProxyPass:            fmt.Sprintf("http://%v", upstreamName),
+		Path:                     path,
+		Snippets:                 cfgParams.LocationSnippets,
+		ProxyConnectTimeout:      generateString(upstream.ProxyConnectTimeout, cfgParams.ProxyConnectTimeout),
+		ProxyReadTimeout:         generateString(upstream.ProxyReadTimeout, cfgParams.ProxyReadTimeout),
+		ProxySendTimeout:         upstream.ProxySendTimeout,
+		ClientMaxBodySize:        generateString(upstream.ClientMaxBodySize, cfgParams.ClientMaxBodySize),
+		ProxyMaxTempFileSize:     cfgParams.ProxyMaxTempFileSize,
+		ProxyBuffering:           generateBool(upstream.ProxyBuffering, cfgParams.ProxyBuffering),
+		ProxyBuffers:             generateBuffers(upstream.ProxyBuffers, cfgParams.ProxyBuffers),
+		ProxyBufferSize:          generateString(upstream.ProxyBufferSize, cfgParams.ProxyBufferSize),
+		ProxyNextUpstream:        generateString(upstream.ProxyNextUpstream, cfgParams.ProxyNextUpstream),
+		ProxyNextUpstreamTimeout: generateString(upstream.ProxyNextUpstreamTimeout, cfgParams.ProxyNextUpstreamTimeout),
+		ProxyNextUpstreamTries:   generateIntFromPointer(upstream.ProxyNextUpstreamTries, cfgParams.ProxyNextUpstreamTries),
+		HasKeepalive:             generateIntFromPointer(upstream.Keepalive, int(cfgParams.Keepalive)) > 0,
+		ProxySetHeaders:          generateProxySetHeaders(nil),
+		ProxyPassRequestHeaders:  true,
+	}
+
+	if upstream.Type == "grpc" {
+		loc.GRPCPass = fmt.Sprintf("%v://%v", generateGRPCPassProtocol(upstream.TLS), upstreamName)
+	} else {
+		loc.ProxyPass = fmt.Sprintf("%v://%v", generateProxyPassProtocol(upstream.TLS), upstreamName)
+	}
+
+	return loc
+}
+
+// generateUpstreamType returns the type of an upstream. gRPC requires HTTP/2 on the TLS listener of the server,
+// so for a server without TLS or HTTP/2, a grpc upstream falls back to http. Such upstreams are reported
+// by getUpstreamsWithIgnoredGRPCType.
+func generateUpstreamType(upstream conf_v1alpha1.Upstream, ssl *version2.SSL) string {
+	if upstream.Type != "grpc" {
+		return upstream.Type
+	}
+
+	if ssl == nil || !ssl.HTTP2 {
+		return "http"
+	}
+
+	return upstream.Type
+}
+
+// getUpstreamsWithIgnoredGRPCType returns the upstreams of a VirtualServer and its VirtualServerRoutes of type grpc
+// that fall back to http, because the VirtualServer doesn't have TLS or HTTP/2 is disabled.
+// The upstreams of VirtualServerRoutes are returned in the <upstream> of VirtualServerRoute <namespace>/<name> format.
+func getUpstreamsWithIgnoredGRPCType(virtualServerEx *VirtualServerEx, http2 bool) []string {
+	tls := virtualServerEx.VirtualServer.Spec.TLS
+	if tls != nil && tls.Secret != "" && http2 {
+		return nil
+	}
+
+	var upstreams []string
+
+	for _, u := range virtualServerEx.VirtualServer.Spec.Upstreams {
+		if u.Type == "grpc" {
+			upstreams = append(upstreams, u.Name)
+		}
+	}
+
+	for _, vsr := range virtualServerEx.VirtualServerRoutes {
+		for _, u := range vsr.Spec.Upstreams {
+			if u.Type == "grpc" {
+				upstreams = append(upstreams, fmt.Sprintf("%s of VirtualServerRoute %s/%s", u.Name, vsr.Namespace, vsr.Name))
+			}
+		}
+	}
+
+	return upstreams
+}
+
+func generateGRPCPassProtocol(tls *conf_v1alpha1.UpstreamTLS) string {
+	if tls != nil && tls.Enable {
+		return "grpcs"
+	}
+	return "grpc"
+}
+
+func generateProxyPassProtocol(tls *conf_v1alpha1.UpstreamTLS) string {
+	if tls != nil && tls.Enable {
+		return "https"
+	}
+	return "http"
+}
+
+// generateProxySSL generates the TLS configuration for the connections to an upstream.
+// If a referenced Secret doesn't exist or is invalid, no cipher is allowed, so that NGINX fails to establish
+// the connections instead of passing requests to a backend that it can't verify or authenticate to.
+func generateProxySSL(tls *conf_v1alpha1.UpstreamTLS, namespace string, upstreamCASecrets map[string]*api_v1.Secret,
+	upstreamCAFileNames map[string]string, upstreamPemFileNames map[string]string) *version2.ProxySSL {
+	if tls == nil || !tls.Enable {
+		return nil
 	}
+
+	proxySSL := &version2.ProxySSL{
+		Name: tls.ServerName,
+	}
+
+	if tls.TrustedCertSecret != "" {
+		key := fmt.Sprintf("%s/%s", namespace, tls.TrustedCertSecret)
+		if fileName, exists := upstreamCAFileNames[key]; exists {
+			proxySSL.TrustedCertificate = fileName
+			// the certificate revocation list is appended to the CA bundle
+			if secret := upstreamCASecrets[key]; secret != nil && len(secret.Data[CRLKey]) > 0 {
+				proxySSL.CRLFile = fileName
+			}
+			proxySSL.VerifyDepth = generateIntFromPointer(tls.VerifyDepth, 1)
+		} else {
+			proxySSL.Ciphers = "NULL"
+		}
+	}
+
+	if tls.Secret != "" {
+		if fileName, exists := upstreamPemFileNames[fmt.Sprintf("%s/%s", namespace, tls.Secret)]; exists {
+			proxySSL.Certificate = fileName
+			proxySSL.CertificateKey = fileName
+		} else {
+			proxySSL.Ciphers = "NULL"
+		}
+	}
+
+	return proxySSL
+}
+
+// generateProxySetHeaders generates the headers passed to an upstream. Unless overridden, the Host header is set to $host.
+// The ignored headers are set to an empty value, so that NGINX doesn't pass them.
+func generateProxySetHeaders(requestHeaders *conf_v1alpha1.ProxyRequestHeaders) []version2.Header {
+	headers := []version2.Header{}
+	hasHost := false
+
+	if requestHeaders != nil {
+		for _, h := range requestHeaders.Set {
+			if strings.EqualFold(h.Name, "Host") {
+				hasHost = true
+			}
+			headers = append(headers, version2.Header{Name: h.Name, Value: h.Value})
+		}
+		for _, name := range requestHeaders.Ignore {
+			if strings.EqualFold(name, "Host") {
+				hasHost = true
+			}
+			headers = append(headers, version2.Header{Name: name, Value: ""})
+		}
+	}
+
+	if !hasHost {
+		headers = append([]version2.Header{{Name: "Host", Value: "$host"}}, headers...)
+	}
+
+	return headers
+}
+
+func generateAddHeaders(responseHeaders *conf_v1alpha1.ProxyResponseHeaders) []version2.AddHeader {
+	var headers []version2.AddHeader
+
+	for _, h := range responseHeaders.Add {
+		headers = append(headers, version2.AddHeader{
+			Header: version2.Header{Name: h.Name, Value: h.Value},
+			Always: h.Always,
+		})
+	}
+
+	return headers
+}
+
+// setHeadersForLocations applies the request and response headers manipulation of a route to its locations.
+func setHeadersForLocations(locations []version2.Location, requestHeaders *conf_v1alpha1.ProxyRequestHeaders,
+	responseHeaders *conf_v1alpha1.ProxyResponseHeaders) {
+	for i := range locations {
+		if requestHeaders != nil && locations[i].Return == nil {
+			locations[i].ProxySetHeaders = generateProxySetHeaders(requestHeaders)
+			locations[i].ProxyPassRequestHeaders = generateBool(requestHeaders.Pass, true)
+		}
+		if responseHeaders != nil {
+			locations[i].AddHeaders = append(locations[i].AddHeaders, generateAddHeaders(responseHeaders)...)
+			locations[i].ProxyHideHeaders = responseHeaders.Hide
+			locations[i].ProxyPassHeaders = responseHeaders.Pass
+			locations[i].ProxyIgnoreHeaders = strings.Join(responseHeaders.Ignore, " ")
+		}
+	}
+}
+
+// generateLocationForRoute generates a location that either passes requests to the upstream or performs the action.
+// Either the upstream or the action is expected to be set. The rewrite, if any, only applies to the requests passed to the upstream.
+func generateLocationForRoute(path string, upstream string, action *conf_v1alpha1.Action, rewrite *version2.Rewrite, upstreamNamer *upstreamNamer,
+	crUpstreams map[string]conf_v1alpha1.Upstream, proxySSLs map[string]*version2.ProxySSL, cfgParams *ConfigParams) version2.Location {
+	if action != nil {
+		if action.Pass == "" {
+			return generateLocationForReturn(path, action, cfgParams)
+		}
+		upstream = action.Pass
+	}
+
+	upstreamName := upstreamNamer.GetNameForUpstream(upstream)
+	loc := generateLocation(path, upstreamName, crUpstreams[upstreamName], cfgParams)
+	loc.Rewrite = rewrite
+	loc.ProxySSL = proxySSLs[upstreamName]
+
 	return loc
 }
 
+// generateLocationPath generates the parameters of the location directive for the path of a route.
+// A route path is either a prefix (/path), an exact path (=/path) or a regular expression (~ regex or ~* regex).
+// A regular expression is quoted, so that it can include the `{`, `}` and `;` characters.
+func generateLocationPath(path string) string {
+	if strings.HasPrefix(path, "~*") {
+		return fmt.Sprintf(`~* "%s"`, strings.TrimSpace(path[2:]))
+	}
+	if strings.HasPrefix(path, "~") {
+		return fmt.Sprintf(`~ "%s"`, strings.TrimSpace(path[1:]))
+	}
+
+	return path
+}
+
+// generateRewrite generates a rewrite that replaces the prefix of the URI matched by the path of a route with the rewrite path.
+// For an exact path, the whole URI is replaced. Rewriting is not supported for regex paths, which is ensured by the validation.
+// We use the rewrite directive rather than a URI in the proxy_pass directive, because the latter is not allowed
+// in the named locations of the splits and rules.
+func generateRewrite(path string, rewritePath string) *version2.Rewrite {
+	if rewritePath == "" {
+		return nil
+	}
+
+	prefix := strings.TrimPrefix(path, "=")
+
+	return &version2.Rewrite{
+		Regex:       fmt.Sprintf("^%s(.*)$", escapeForQuotedString(regexp.QuoteMeta(prefix))),
+		Replacement: rewritePath + "$1",
+	}
+}
+
+// escapeForQuotedString escapes backslashes and double quotes of a string used as a quoted parameter of an NGINX directive.
+func escapeForQuotedString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func generateLocationForReturn(path string, action *conf_v1alpha1.Action, cfgParams *ConfigParams) version2.Location {
+	return version2.Location{
+		Path:     path,
+		Snippets: cfgParams.LocationSnippets,
+		Return:   generateReturn(action),
+	}
+}
+
+// generateReturn generates a Return for the redirect or return of an action.
+func generateReturn(action *conf_v1alpha1.Action) *version2.Return {
+	if action.Redirect != nil {
+		return &version2.Return{
+			Code: generateIntWithDefault(action.Redirect.Code, defaultRedirectCode),
+			Text: action.Redirect.URL,
+		}
+	}
+
+	if action.Return != nil {
+		return &version2.Return{
+			Code: generateIntWithDefault(action.Return.Code, defaultReturnCode),
+			Type: generateString(action.Return.Type, defaultReturnType),
+			Text: action.Return.Body,
+		}
+	}
+
+	return nil
+}
+
+func generateIntWithDefault(n int, defaultN int) int {
+	if n == 0 {
+		return defaultN
+	}
+	return n
+}
+
+// newDefaultWallarm returns the default Wallarm configuration, which is the same as for Ingress resources.
+// The version1 and version2 Wallarm structs have the same fields, so the conversion keeps them in sync.
+func newDefaultWallarm() *version2.Wallarm {
+	wallarm := version2.Wallarm(*version1.NewWallarm())
+	return &wallarm
+}
+
+// generateWallarm returns a copy of the base Wallarm configuration with the values set in the Wallarm of a resource.
+func generateWallarm(base *version2.Wallarm, wallarm *conf_v1alpha1.Wallarm) *version2.Wallarm {
+	result := *base
+
+	if wallarm == nil {
+		return &result
+	}
+
+	if wallarm.Mode != "" {
+		result.Mode = wallarm.Mode
+	}
+	if wallarm.ModeAllowOverride != "" {
+		result.ModeAllowOverride = wallarm.ModeAllowOverride
+	}
+	if wallarm.Fallback != "" {
+		result.Fallback = wallarm.Fallback
+	}
+	if wallarm.Instance != "" {
+		result.Instance = wallarm.Instance
+	}
+	if wallarm.BlockPage != "" {
+		result.BlockPage = wallarm.BlockPage
+	}
+	if wallarm.ParseResponse != "" {
+		result.ParseResponse = wallarm.ParseResponse
+	}
+	if wallarm.ParseWebsocket != "" {
+		result.ParseWebsocket = wallarm.ParseWebsocket
+	}
+	if wallarm.UnpackResponse != "" {
+		result.UnpackResponse = wallarm.UnpackResponse
+	}
+	if wallarm.ParserDisable != nil {
+		result.ParserDisable = wallarm.ParserDisable
+	}
+
+	return &result
+}
+
+// getVirtualServerRouteKey returns the namespace/name key of a VirtualServerRoute referenced in the route field of a VirtualServer route.
+func getVirtualServerRouteKey(route string, virtualServerNamespace string) string {
+	if strings.Contains(route, "/") {
+		return route
+	}
+	return fmt.Sprintf("%s/%s", virtualServerNamespace, route)
+}
+
+func setWallarmForLocations(locations []version2.Location, wallarm *version2.Wallarm) {
+	for i := range locations {
+		locations[i].Wallarm = wallarm
+	}
+}
+
+type policiesCfg struct {
+	Allow                []string
+	Deny                 []string
+	LimitReq             *version2.LimitReq
+	LimitReqZones        []version2.LimitReqZone
+	JWTAuth              *version2.JWTAuth
+	JWTRedirectLocations []version2.JWTRedirectLocation
+	ErrorReturn          *version2.Return
+}
+
+// generatePolicies generates the configuration of the referenced policies. If a policy is missing or invalid (not
+// present in the policies map), the requests are rejected with the 500 status code. If several policies of the same
+// type are referenced, only the first one is applied.
+func generatePolicies(policyRefs []conf_v1alpha1.PolicyReference, policies map[string]*conf_v1alpha1.Policy, ownerNamespace string,
+	vs *conf_v1alpha1.VirtualServer, jwtKeyFileNames map[string]string) policiesCfg {
+	var cfg policiesCfg
+
+	for _, p := range policyRefs {
+		pol, exists := policies[GeneratePolicyKey(p, ownerNamespace)]
+		if !exists {
+			cfg.ErrorReturn = &version2.Return{
+				Code: 500,
+			}
+			continue
+		}
+
+		if pol.Spec.AccessControl != nil && cfg.Allow == nil && cfg.Deny == nil {
+			cfg.Allow = pol.Spec.AccessControl.Allow
+			cfg.Deny = pol.Spec.AccessControl.Deny
+		}
+
+		if pol.Spec.RateLimit != nil && cfg.LimitReq == nil {
+			zoneName := generateLimitReqZoneName(pol, vs)
+			cfg.LimitReq = generateLimitReq(zoneName, pol.Spec.RateLimit)
+			cfg.LimitReqZones = append(cfg.LimitReqZones, version2.LimitReqZone{
+				Name: zoneName,
+				Key:  pol.Spec.RateLimit.Key,
+				Size: generateString(pol.Spec.RateLimit.ZoneSize, defaultLimitReqZoneSize),
+				Rate: pol.Spec.RateLimit.Rate,
+			})
+		}
+
+		if pol.Spec.JWTAuth != nil && cfg.JWTAuth == nil {
+			jwtKeyFileName, exists := jwtKeyFileNames[fmt.Sprintf("%s/%s", pol.Namespace, pol.Spec.JWTAuth.Secret)]
+			if !exists {
+				cfg.ErrorReturn = &version2.Return{
+					Code: 500,
+				}
+				continue
+			}
+
+			cfg.JWTAuth = &version2.JWTAuth{
+				Secret: jwtKeyFileName,
+				Realm:  pol.Spec.JWTAuth.Realm,
+				Token:  pol.Spec.JWTAuth.Token,
+			}
+
+			if pol.Spec.JWTAuth.LoginURL != "" {
+				cfg.JWTAuth.RedirectLocationName = generateJWTRedirectLocationName(pol)
+				cfg.JWTRedirectLocations = append(cfg.JWTRedirectLocations, version2.JWTRedirectLocation{
+					Name:     cfg.JWTAuth.RedirectLocationName,
+					LoginURL: pol.Spec.JWTAuth.LoginURL,
+				})
+			}
+		}
+	}
+
+	return cfg
+}
+
+func generateJWTRedirectLocationName(policy *conf_v1alpha1.Policy) string {
+	return fmt.Sprintf("@login_url_%v_%v", policy.Namespace, policy.Name)
+}
+
+// generateLimitReqZoneName generates a name of the zone for a rate limit policy. The zone is unique for each pair
+// of a policy and a VirtualServer, so that the same policy referenced in different VirtualServers doesn't make
+// them share the rate limit.
+func generateLimitReqZoneName(policy *conf_v1alpha1.Policy, vs *conf_v1alpha1.VirtualServer) string {
+	return fmt.Sprintf("pol_rl_%v_%v_%v_%v", policy.Namespace, policy.Name, vs.Namespace, vs.Name)
+}
+
+func generateLimitReq(zoneName string, rateLimit *conf_v1alpha1.RateLimit) *version2.LimitReq {
+	return &version2.LimitReq{
+		Zone:       zoneName,
+		Burst:      generateIntFromPointer(rateLimit.Burst, 0),
+		NoDelay:    generateBool(rateLimit.NoDelay, false),
+		DryRun:     generateBool(rateLimit.DryRun, false),
+		LogLevel:   rateLimit.LogLevel,
+		RejectCode: generateIntFromPointer(rateLimit.RejectCode, 0),
+	}
+}
+
+// addLimitReqZones adds the zones that are not yet present in the list of zones. Several routes can reference
+// the same rate limit policy, which must result in only one zone.
+func addLimitReqZones(zones []version2.LimitReqZone, newZones []version2.LimitReqZone) []version2.LimitReqZone {
+	for _, newZone := range newZones {
+		exists := false
+		for _, z := range zones {
+			if z.Name == newZone.Name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			zones = append(zones, newZone)
+		}
+	}
+
+	return zones
+}
+
+// addJWTRedirectLocations adds the locations that are not yet present in the list of locations. Several routes can
+// reference the same JWT policy, which must result in only one location.
+func addJWTRedirectLocations(locations []version2.JWTRedirectLocation, newLocations []version2.JWTRedirectLocation) []version2.JWTRedirectLocation {
+	for _, newLoc := range newLocations {
+		exists := false
+		for _, l := range locations {
+			if l.Name == newLoc.Name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			locations = append(locations, newLoc)
+		}
+	}
+
+	return locations
+}
+
+func setPoliciesForLocations(locations []version2.Location, cfg policiesCfg) {
+	for i := range locations {
+		locations[i].Allow = cfg.Allow
+		locations[i].Deny = cfg.Deny
+		locations[i].LimitReq = cfg.LimitReq
+		locations[i].JWTAuth = cfg.JWTAuth
+		locations[i].PoliciesErrorReturn = cfg.ErrorReturn
+	}
+}
+
 type splitRouteCfg struct {
 	SplitClient              version2.SplitClient
+	Maps                     []version2.Map
 	Locations                []version2.Location
 	InternalRedirectLocation version2.InternalRedirectLocation
 }
 
-func generateSplitRouteConfig(route conf_v1alpha1.Route, upstreamNamer *upstreamNamer, variableNamer *variableNamer, index int, cfgParams *ConfigParams) splitRouteCfg {
+func generateSplitRouteConfig(route conf_v1alpha1.Route, upstreamNamer *upstreamNamer, crUpstreams map[string]conf_v1alpha1.Upstream,
+	proxySSLs map[string]*version2.ProxySSL, variableNamer *variableNamer, index int, cfgParams *ConfigParams) splitRouteCfg {
 	splitClientVarName := variableNamer.GetNameForSplitClientVariable(index)
 
 	// Generate a SplitClient
@@ -262,41 +1191,86 @@ func generateSplitRouteConfig(route conf_v1alpha1.Route, upstreamNamer *upstream
 	}
 
 	splitClient := version2.SplitClient{
-		Source:        "$request_id",
+		Source:        generateString(route.SplitKey, "$request_id"),
 		Variable:      splitClientVarName,
 		Distributions: distributions,
 	}
 
+	// Generate a map that sends the clients with the split cookie to the split they were pinned to
+	var maps []version2.Map
+	destination := splitClientVarName
+
+	if route.SplitCookie != nil {
+		cookieMapVarName := variableNamer.GetNameForSplitCookieMapVariable(index)
+
+		var params []version2.Parameter
+		for i := range route.Splits {
+			params = append(params, version2.Parameter{
+				Value:  fmt.Sprintf(`"%d"`, i),
+				Result: fmt.Sprintf("@splits_%d_split_%d", index, i),
+			})
+		}
+		params = append(params, version2.Parameter{
+			Value:  "default",
+			Result: splitClientVarName,
+		})
+
+		maps = append(maps, version2.Map{
+			Source:     fmt.Sprintf("$cookie_%s", route.SplitCookie.Name),
+			Variable:   cookieMapVarName,
+			Parameters: params,
+		})
+		destination = cookieMapVarName
+	}
+
 	// Generate locations
 	var locations []version2.Location
 
 	for i, s := range route.Splits {
 		path := fmt.Sprintf("@splits_%d_split_%d", index, i)
-		upstreamName := upstreamNamer.GetNameForUpstream(s.Upstream)
-		loc := generateLocation(path, upstreamName, cfgParams)
+		rewrite := generateRewrite(route.Path, generateString(s.RewritePath, route.RewritePath))
+		loc := generateLocationForRoute(path, s.Upstream, s.Action, rewrite, upstreamNamer, crUpstreams, proxySSLs, cfgParams)
+		if route.SplitCookie != nil {
+			loc.AddHeaders = append(loc.AddHeaders, generateSplitCookieHeader(route.SplitCookie, i))
+		}
 		locations = append(locations, loc)
 	}
 
 	// Generate an InternalRedirectLocation
 	irl := version2.InternalRedirectLocation{
-		Path:        route.Path,
-		Destination: splitClientVarName,
+		Path:        generateLocationPath(route.Path),
+		Destination: destination,
 	}
 
 	return splitRouteCfg{
 		SplitClient:              splitClient,
+		Maps:                     maps,
 		Locations:                locations,
 		InternalRedirectLocation: irl,
 	}
 }
 
+// generateSplitCookieHeader generates the Set-Cookie header that pins a client to the split with the index splitIndex.
+func generateSplitCookieHeader(splitCookie *conf_v1alpha1.SplitCookie, splitIndex int) version2.AddHeader {
+	value := fmt.Sprintf("%s=%d; Path=%s", splitCookie.Name, splitIndex, generateString(splitCookie.Path, "/"))
+	if splitCookie.MaxAge != nil {
+		value += fmt.Sprintf("; Max-Age=%d", *splitCookie.MaxAge)
+	}
+
+	return version2.AddHeader{
+		Header: version2.Header{Name: "Set-Cookie", Value: value},
+		Always: true,
+	}
+}
+
 type rulesRouteCfg struct {
 	Maps                     []version2.Map
 	Locations                []version2.Location
 	InternalRedirectLocation version2.InternalRedirectLocation
 }
 
-func generateRulesRouteConfig(route conf_v1alpha1.Route, upstreamNamer *upstreamNamer, variableNamer *variableNamer, index int, cfgParams *ConfigParams) rulesRouteCfg {
+func generateRulesRouteConfig(route conf_v1alpha1.Route, upstreamNamer *upstreamNamer, crUpstreams map[string]conf_v1alpha1.Upstream,
+	proxySSLs map[string]*version2.ProxySSL, variableNamer *variableNamer, index int, cfgParams *ConfigParams) rulesRouteCfg {
 	// Generate maps
 	var maps []version2.Map
 
@@ -353,20 +1327,20 @@ func generateRulesRouteConfig(route conf_v1alpha1.Route, upstreamNamer *upstream
 
 	for i, m := range route.Rules.Matches {
 		path := fmt.Sprintf("@rules_%d_match_%d", index, i)
-		upstreamName := upstreamNamer.GetNameForUpstream(m.Upstream)
-		loc := generateLocation(path, upstreamName, cfgParams)
+		rewrite := generateRewrite(route.Path, generateString(m.RewritePath, route.RewritePath))
+		loc := generateLocationForRoute(path, m.Upstream, m.Action, rewrite, upstreamNamer, crUpstreams, proxySSLs, cfgParams)
 		locations = append(locations, loc)
 	}
 
 	// Generate defaultUpsteam location
 	path := fmt.Sprintf("@rules_%d_default", index)
-	upstreamName := upstreamNamer.GetNameForUpstream(route.Rules.DefaultUpstream)
-	loc := generateLocation(path, upstreamName, cfgParams)
+	rewrite := generateRewrite(route.Path, route.RewritePath)
+	loc := generateLocationForRoute(path, route.Rules.DefaultUpstream, nil, rewrite, upstreamNamer, crUpstreams, proxySSLs, cfgParams)
 	locations = append(locations, loc)
 
 	// Generate an InternalRedirectLocation to the location defined by the main map variable
 	irl := version2.InternalRedirectLocation{
-		Path:        route.Path,
+		Path:        generateLocationPath(route.Path),
 		Destination: variable,
 	}
 
@@ -441,7 +1415,8 @@ func getNameForSourceForRulesRouteMapFromCondition(condition conf_v1alpha1.Condi
 	return condition.Variable
 }
 
-func generateSSLConfig(tls *conf_v1alpha1.TLS, tlsPemFileName string, cfgParams *ConfigParams) *version2.SSL {
+func generateSSLConfig(tls *conf_v1alpha1.TLS, tlsPemFileName string, clientCASecret *api_v1.Secret, clientCAFileName string,
+	cfgParams *ConfigParams) *version2.SSL {
 	if tls == nil {
 		return nil
 	}
@@ -468,40 +1443,108 @@ func generateSSLConfig(tls *conf_v1alpha1.TLS, tlsPemFileName string, cfgParams
 		RedirectToHTTPS: cfgParams.SSLRedirect,
 	}
 
+	if tls.ClientCertificate != nil {
+		if clientCAFileName == "" {
+			// the client certificates can't be verified without the CA Secret, so we reject all TLS connections
+			ssl.Ciphers = "NULL"
+		} else {
+			ssl.ClientCertificate = generateClientCertificate(tls.ClientCertificate, clientCASecret, clientCAFileName)
+		}
+	}
+
 	return &ssl
 }
 
-func createUpstreamServersForPlus(virtualServerEx *VirtualServerEx) map[string][]string {
-	upstreamEndpoints := make(map[string][]string)
+func generateClientCertificate(clientCert *conf_v1alpha1.ClientCertificate, clientCASecret *api_v1.Secret, clientCAFileName string) *version2.ClientCertificate {
+	cc := &version2.ClientCertificate{
+		CAFile:      clientCAFileName,
+		Verify:      "on",
+		VerifyDepth: 1,
+	}
+
+	if clientCASecret != nil && len(clientCASecret.Data[CRLKey]) > 0 {
+		cc.CRLFile = clientCAFileName
+	}
+
+	if clientCert.Verify != "" {
+		cc.Verify = clientCert.Verify
+	}
+
+	if clientCert.VerifyDepth != nil {
+		cc.VerifyDepth = *clientCert.VerifyDepth
+	}
+
+	if clientCert.ForwardToUpstream != nil {
+		cc.ForwardToUpstream = *clientCert.ForwardToUpstream
+	}
+
+	return cc
+}
+
+func createUpstreamsForPlus(virtualServerEx *VirtualServerEx, baseCfgParams *ConfigParams) []version2.Upstream {
+	var upstreams []version2.Upstream
 
+	isPlus := true
 	virtualServerUpstreamNamer := newUpstreamNamerForVirtualServer(virtualServerEx.VirtualServer)
 
 	for _, u := range virtualServerEx.VirtualServer.Spec.Upstreams {
-		endpointsKey := GenerateEndpointsKey(virtualServerEx.VirtualServer.Namespace, u.Service, u.Port)
-		name := virtualServerUpstreamNamer.GetNameForUpstream(u.Name)
+		endpointsKey := GenerateEndpointsKey(virtualServerEx.VirtualServer.Namespace, u.Service, u.Subselector, u.Port)
+		upstreamName := virtualServerUpstreamNamer.GetNameForUpstream(u.Name)
 
-		upstreamEndpoints[name] = virtualServerEx.Endpoints[endpointsKey]
+		ups := generateUpstream(upstreamName, u, virtualServerEx.Endpoints[endpointsKey], virtualServerEx.LocalZoneEndpoints, isPlus, baseCfgParams)
+		upstreams = append(upstreams, ups)
 	}
 
 	for _, vsr := range virtualServerEx.VirtualServerRoutes {
 		upstreamNamer := newUpstreamNamerForVirtualServerRoute(virtualServerEx.VirtualServer, vsr)
 		for _, u := range vsr.Spec.Upstreams {
-			endpointsKey := GenerateEndpointsKey(vsr.Namespace, u.Service, u.Port)
-			name := upstreamNamer.GetNameForUpstream(u.Name)
+			endpointsKey := GenerateEndpointsKey(vsr.Namespace, u.Service, u.Subselector, u.Port)
+			upstreamName := upstreamNamer.GetNameForUpstream(u.Name)
 
-			upstreamEndpoints[name] = virtualServerEx.Endpoints[endpointsKey]
+			ups := generateUpstream(upstreamName, u, virtualServerEx.Endpoints[endpointsKey], virtualServerEx.LocalZoneEndpoints, isPlus, baseCfgParams)
+			upstreams = append(upstreams, ups)
 		}
 	}
 
-	return upstreamEndpoints
+	return upstreams
 }
 
-func createUpstreamServersConfig(baseCfgParams *ConfigParams) nginx.ServerConfig {
-	cfg := nginx.ServerConfig{
-		MaxFails:    baseCfgParams.MaxFails,
-		FailTimeout: baseCfgParams.FailTimeout,
+// errMaxConnsNotSupported is returned when the servers of an upstream with the max_conns parameter must be updated via
+// the NGINX Plus API, which would drop the parameter. In that case, NGINX Plus is reloaded instead.
+var errMaxConnsNotSupported = errors.New("the API doesn't support the max_conns parameter of the servers")
+
+// hasMaxConnsServers checks if the servers of the upstream limit the number of connections.
+func hasMaxConnsServers(upstream version2.Upstream) bool {
+	for _, s := range upstream.Servers {
+		if s.MaxConns > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func createUpstreamServersConfigForPlus(upstream version2.Upstream, baseCfgParams *ConfigParams) nginx.ServerConfig {
+	if len(upstream.Servers) == 0 {
+		return nginx.ServerConfig{
+			MaxFails:    baseCfgParams.MaxFails,
+			FailTimeout: baseCfgParams.FailTimeout,
+			SlowStart:   baseCfgParams.SlowStart,
+		}
+	}
+
+	return nginx.ServerConfig{
+		MaxFails:    upstream.Servers[0].MaxFails,
+		FailTimeout: upstream.Servers[0].FailTimeout,
 		SlowStart:   baseCfgParams.SlowStart,
 	}
+}
 
-	return cfg
+func createEndpointsFromUpstream(upstream version2.Upstream) []string {
+	var endpoints []string
+
+	for _, server := range upstream.Servers {
+		endpoints = append(endpoints, server.Address)
+	}
+
+	return endpoints
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
 
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -53,10 +54,48 @@ func TestGenerateEndpointsKey(t *testing.T) {
 
 	expected := "default/test:80"
 
-	result := GenerateEndpointsKey(serviceNamespace, serviceName, port)
+	result := GenerateEndpointsKey(serviceNamespace, serviceName, nil, port)
 	if result != expected {
 		t.Errorf("GenerateEndpointsKey() returned %q but expected %q", result, expected)
 	}
+
+	expected = "default/test_track=canary,version=v2:80"
+
+	result = GenerateEndpointsKey(serviceNamespace, serviceName, map[string]string{"version": "v2", "track": "canary"}, port)
+	if result != expected {
+		t.Errorf("GenerateEndpointsKey() returned %q but expected %q", result, expected)
+	}
+}
+
+func TestGeneratePolicyKey(t *testing.T) {
+	tests := []struct {
+		policyRef      conf_v1alpha1.PolicyReference
+		ownerNamespace string
+		expected       string
+	}{
+		{
+			policyRef: conf_v1alpha1.PolicyReference{
+				Name: "allow-internal",
+			},
+			ownerNamespace: "default",
+			expected:       "default/allow-internal",
+		},
+		{
+			policyRef: conf_v1alpha1.PolicyReference{
+				Name:      "allow-internal",
+				Namespace: "policies",
+			},
+			ownerNamespace: "default",
+			expected:       "policies/allow-internal",
+		},
+	}
+
+	for _, test := range tests {
+		result := GeneratePolicyKey(test.policyRef, test.ownerNamespace)
+		if result != test.expected {
+			t.Errorf("GeneratePolicyKey(%+v, %q) returned %q but expected %q", test.policyRef, test.ownerNamespace, result, test.expected)
+		}
+	}
 }
 
 func TestUpstreamNamerForVirtualServer(t *testing.T) {
@@ -77,6 +116,33 @@ func TestUpstreamNamerForVirtualServer(t *testing.T) {
 	}
 }
 
+func TestUpstreamNamerForUpstreamWithSubselector(t *testing.T) {
+	virtualServer := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+		Spec: conf_v1alpha1.VirtualServerSpec{
+			Upstreams: []conf_v1alpha1.Upstream{
+				{
+					Name:        "test",
+					Service:     "test-svc",
+					Subselector: map[string]string{"version": "v2"},
+				},
+			},
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	upstream := "test"
+
+	expected := "vs_default_cafe_test_07e223be"
+
+	result := upstreamNamer.GetNameForUpstream(upstream)
+	if result != expected {
+		t.Errorf("GetNameForUpstream() returned %q but expected %q", result, expected)
+	}
+}
+
 func TestUpstreamNamerForVirtualServerRoute(t *testing.T) {
 	virtualServer := conf_v1alpha1.VirtualServer{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -242,6 +308,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 						Address: "10.0.0.20:80",
 					},
 				},
+				Keepalive: 16,
 			},
 			{
 				Name: "vs_default_cafe_vsr_default_coffee_coffee",
@@ -250,6 +317,7 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 						Address: "10.0.0.30:80",
 					},
 				},
+				Keepalive: 16,
 			},
 		},
 		Server: version2.Server{
@@ -263,21 +331,26 @@ func TestGenerateVirtualServerConfig(t *testing.T) {
 			Snippets:                              []string{"# server snippet"},
 			Locations: []version2.Location{
 				{
-					Path:      "/tea",
-					ProxyPass: "http://vs_default_cafe_tea",
+					Path:                    "/tea",
+					ProxyPass:               "http://vs_default_cafe_tea",
+					HasKeepalive:            true,
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 				{
-					Path:      "/coffee",
-					ProxyPass: "http://vs_default_cafe_vsr_default_coffee_coffee",
+					Path:                    "/coffee",
+					ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee",
+					HasKeepalive:            true,
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 			},
 		},
-		Keepalive: "16",
 	}
 
 	isPlus := false
 	tlsPemFileName := ""
-	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, &baseCfgParams, isPlus)
+	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, "", nil, nil, nil, &baseCfgParams, isPlus, &StaticConfigParams{})
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateVirtualServerConfig returned \n%v but expected \n%v", result, expected)
 	}
@@ -459,20 +532,28 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 			},
 			Locations: []version2.Location{
 				{
-					Path:      "@splits_0_split_0",
-					ProxyPass: "http://vs_default_cafe_tea-v1",
+					Path:                    "@splits_0_split_0",
+					ProxyPass:               "http://vs_default_cafe_tea-v1",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 				{
-					Path:      "@splits_0_split_1",
-					ProxyPass: "http://vs_default_cafe_tea-v2",
+					Path:                    "@splits_0_split_1",
+					ProxyPass:               "http://vs_default_cafe_tea-v2",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 				{
-					Path:      "@splits_1_split_0",
-					ProxyPass: "http://vs_default_cafe_vsr_default_coffee_coffee-v1",
+					Path:                    "@splits_1_split_0",
+					ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee-v1",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 				{
-					Path:      "@splits_1_split_1",
-					ProxyPass: "http://vs_default_cafe_vsr_default_coffee_coffee-v2",
+					Path:                    "@splits_1_split_1",
+					ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee-v2",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 			},
 		},
@@ -480,7 +561,7 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 
 	isPlus := false
 	tlsPemFileName := ""
-	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, &baseCfgParams, isPlus)
+	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, "", nil, nil, nil, &baseCfgParams, isPlus, &StaticConfigParams{})
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateVirtualServerConfig returned \n%v but expected \n%v", result, expected)
 	}
@@ -703,20 +784,28 @@ func TestGenerateVirtualServerConfigForVirtualServerWithRules(t *testing.T) {
 			},
 			Locations: []version2.Location{
 				{
-					Path:      "@rules_0_match_0",
-					ProxyPass: "http://vs_default_cafe_tea-v2",
+					Path:                    "@rules_0_match_0",
+					ProxyPass:               "http://vs_default_cafe_tea-v2",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 				{
-					Path:      "@rules_0_default",
-					ProxyPass: "http://vs_default_cafe_tea-v1",
+					Path:                    "@rules_0_default",
+					ProxyPass:               "http://vs_default_cafe_tea-v1",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 				{
-					Path:      "@rules_1_match_0",
-					ProxyPass: "http://vs_default_cafe_vsr_default_coffee_coffee-v2",
+					Path:                    "@rules_1_match_0",
+					ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee-v2",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 				{
-					Path:      "@rules_1_default",
-					ProxyPass: "http://vs_default_cafe_vsr_default_coffee_coffee-v1",
+					Path:                    "@rules_1_default",
+					ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee-v1",
+					ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+					ProxyPassRequestHeaders: true,
 				},
 			},
 		},
@@ -724,195 +813,13 @@ func TestGenerateVirtualServerConfigForVirtualServerWithRules(t *testing.T) {
 
 	isPlus := false
 	tlsPemFileName := ""
-	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, &baseCfgParams, isPlus)
+	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, "", nil, nil, nil, &baseCfgParams, isPlus, &StaticConfigParams{})
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateVirtualServerConfig returned \n%v but expected \n%v", result, expected)
 	}
 }
 
-func TestGenerateUpstream(t *testing.T) {
-	name := "test-upstream"
-	endpoints := []string{
-		"192.168.10.10:8080",
-	}
-	isPlus := false
-	cfgParams := ConfigParams{
-		LBMethod:    "random",
-		MaxFails:    1,
-		FailTimeout: "10s",
-	}
-
-	expected := version2.Upstream{
-		Name: "test-upstream",
-		Servers: []version2.UpstreamServer{
-			{
-				Address:     "192.168.10.10:8080",
-				MaxFails:    1,
-				FailTimeout: "10s",
-			},
-		},
-		LBMethod: "random",
-	}
-
-	result := generateUpstream(name, endpoints, isPlus, &cfgParams)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
-	}
-}
-
-func TestGenerateUpstreamForZeroEndpoints(t *testing.T) {
-	name := "test-upstream"
-	var endpoints []string // nil
-	cfgParams := ConfigParams{
-		MaxFails:    1,
-		FailTimeout: "10s",
-	}
-
-	isPlus := false
-	expectedForNGINX := version2.Upstream{
-		Name: "test-upstream",
-		Servers: []version2.UpstreamServer{
-			{
-				Address:     nginx502Server,
-				MaxFails:    1,
-				FailTimeout: "10s",
-			},
-		},
-	}
-
-	result := generateUpstream(name, endpoints, isPlus, &cfgParams)
-	if !reflect.DeepEqual(result, expectedForNGINX) {
-		t.Errorf("generateUpstream(isPlus=%v) returned %v but expected %v", isPlus, result, expectedForNGINX)
-	}
-
-	isPlus = true
-	expectedForNGINXPlus := version2.Upstream{
-		Name:    "test-upstream",
-		Servers: nil,
-	}
-
-	result = generateUpstream(name, endpoints, isPlus, &cfgParams)
-	if !reflect.DeepEqual(result, expectedForNGINXPlus) {
-		t.Errorf("generateUpstream(isPlus=%v) returned %v but expected %v", isPlus, result, expectedForNGINXPlus)
-	}
-}
-
-func TestGenerateLocation(t *testing.T) {
-	cfgParams := ConfigParams{
-		ProxyConnectTimeout:  "30s",
-		ProxyReadTimeout:     "31s",
-		ClientMaxBodySize:    "1m",
-		ProxyMaxTempFileSize: "1024m",
-		ProxyBuffering:       true,
-		ProxyBuffers:         "8 4k",
-		ProxyBufferSize:      "4k",
-		LocationSnippets:     []string{"# location snippet"},
-	}
-	path := "/"
-	upstreamName := "test-upstream"
-
-	expected := version2.Location{
-		Path:                 "/",
-		Snippets:             []string{"# location snippet"},
-		ProxyConnectTimeout:  "30s",
-		ProxyReadTimeout:     "31s",
-		ClientMaxBodySize:    "1m",
-		ProxyMaxTempFileSize: "1024m",
-		ProxyBuffering:       true,
-		ProxyBuffers:         "8 4k",
-		ProxyBufferSize:      "4k",
-		ProxyPass:            "http://test-upstream",
-	}
-
-	result := generateLocation(path, upstreamName, &cfgParams)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("generateLocation() returned %v but expected %v", result, expected)
-	}
-}
-
-func TestGenerateSSLConfig(t *testing.T) {
-	tests := []struct {
-		inputTLS            *conf_v1alpha1.TLS
-		inputTLSPemFileName string
-		inputCfgParams      *ConfigParams
-		expected            *version2.SSL
-		msg                 string
-	}{
-		{
-			inputTLS:            nil,
-			inputTLSPemFileName: "",
-			inputCfgParams:      &ConfigParams{},
-			expected:            nil,
-			msg:                 "no TLS field",
-		},
-		{
-			inputTLS: &conf_v1alpha1.TLS{
-				Secret: "",
-			},
-			inputTLSPemFileName: "",
-			inputCfgParams:      &ConfigParams{},
-			expected:            nil,
-			msg:                 "TLS field with empty secret",
-		},
-		{
-			inputTLS: &conf_v1alpha1.TLS{
-				Secret: "secret",
-			},
-			inputTLSPemFileName: "",
-			inputCfgParams:      &ConfigParams{},
-			expected: &version2.SSL{
-				HTTP2:           false,
-				Certificate:     pemFileNameForMissingTLSSecret,
-				CertificateKey:  pemFileNameForMissingTLSSecret,
-				Ciphers:         "NULL",
-				RedirectToHTTPS: false,
-			},
-			msg: "secret doesn't exist in the cluster with HTTP2 and SSLRedirect disabled",
-		},
-		{
-			inputTLS: &conf_v1alpha1.TLS{
-				Secret: "secret",
-			},
-			inputTLSPemFileName: "secret.pem",
-			inputCfgParams:      &ConfigParams{},
-			expected: &version2.SSL{
-				HTTP2:           false,
-				Certificate:     "secret.pem",
-				CertificateKey:  "secret.pem",
-				Ciphers:         "",
-				RedirectToHTTPS: false,
-			},
-			msg: "normal case with HTTP2 and SSLRedirect disabled",
-		},
-		{
-			inputTLS: &conf_v1alpha1.TLS{
-				Secret: "secret",
-			},
-			inputTLSPemFileName: "secret.pem",
-			inputCfgParams: &ConfigParams{
-				HTTP2:       true,
-				SSLRedirect: true,
-			},
-			expected: &version2.SSL{
-				HTTP2:           true,
-				Certificate:     "secret.pem",
-				CertificateKey:  "secret.pem",
-				Ciphers:         "",
-				RedirectToHTTPS: true,
-			},
-			msg: "normal case with HTTP2 and SSLRedirect enabled",
-		},
-	}
-
-	for _, test := range tests {
-		result := generateSSLConfig(test.inputTLS, test.inputTLSPemFileName, test.inputCfgParams)
-		if !reflect.DeepEqual(result, test.expected) {
-			t.Errorf("generateSSLConfig() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
-		}
-	}
-}
-
-func TestCreateUpstreamServersForPlus(t *testing.T) {
+func TestGenerateVirtualServerConfigForVirtualServerWithWallarm(t *testing.T) {
 	virtualServerEx := VirtualServerEx{
 		VirtualServer: &conf_v1alpha1.VirtualServer{
 			ObjectMeta: meta_v1.ObjectMeta{
@@ -927,29 +834,33 @@ func TestCreateUpstreamServersForPlus(t *testing.T) {
 						Service: "tea-svc",
 						Port:    80,
 					},
-					{
-						Name:    "test",
-						Service: "test-svc",
-						Port:    80,
-					},
 				},
 				Routes: []conf_v1alpha1.Route{
 					{
 						Path:     "/tea",
 						Upstream: "tea",
+						Wallarm: &conf_v1alpha1.Wallarm{
+							Mode:          "block",
+							ParserDisable: []string{"json"},
+						},
 					},
 					{
 						Path:  "/coffee",
-						Route: "default/coffee",
+						Route: "coffee",
+						Wallarm: &conf_v1alpha1.Wallarm{
+							Instance: "42",
+						},
 					},
 				},
+				Wallarm: &conf_v1alpha1.Wallarm{
+					Mode: "monitoring",
+				},
 			},
 		},
 		Endpoints: map[string][]string{
 			"default/tea-svc:80": []string{
 				"10.0.0.20:80",
 			},
-			"default/test-svc:80": []string{},
 			"default/coffee-svc:80": []string{
 				"10.0.0.30:80",
 			},
@@ -974,50 +885,1976 @@ func TestCreateUpstreamServersForPlus(t *testing.T) {
 							Path:     "/coffee",
 							Upstream: "coffee",
 						},
+						{
+							Path:     "/coffee/latte",
+							Upstream: "coffee",
+							Wallarm: &conf_v1alpha1.Wallarm{
+								Mode: "off",
+							},
+						},
 					},
 				},
 			},
 		},
 	}
 
-	expected := map[string][]string{
-		"vs_default_cafe_tea": []string{
-			"10.0.0.20:80",
-		},
-		"vs_default_cafe_test": []string{},
-		"vs_default_cafe_vsr_default_coffee_coffee": []string{
-			"10.0.0.30:80",
-		},
+	baseCfgParams := ConfigParams{
+		MainEnableWallarm: true,
 	}
 
-	result := createUpstreamServersForPlus(&virtualServerEx)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("createUpstreamServersForPlus returned %v but expected %v", result, expected)
+	expectedServerWallarm := &version2.Wallarm{
+		Mode:              "monitoring",
+		ModeAllowOverride: "on",
+		Fallback:          "on",
+		ParseResponse:     "on",
+		ParseWebsocket:    "off",
+		UnpackResponse:    "on",
+		ParserDisable:     []string{},
 	}
-}
 
-func TestCreateUpstreamServersConfig(t *testing.T) {
-	baseCfgParams := ConfigParams{
-		MaxFails:    5,
-		FailTimeout: "30s",
-		SlowStart:   "50s",
+	expectedLocations := []version2.Location{
+		{
+			Path:                    "/tea",
+			ProxyPass:               "http://vs_default_cafe_tea",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Wallarm: &version2.Wallarm{
+				Mode:              "block",
+				ModeAllowOverride: "on",
+				Fallback:          "on",
+				ParseResponse:     "on",
+				ParseWebsocket:    "off",
+				UnpackResponse:    "on",
+				ParserDisable:     []string{"json"},
+			},
+		},
+		{
+			Path:                    "/coffee",
+			ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Wallarm: &version2.Wallarm{
+				Mode:              "monitoring",
+				ModeAllowOverride: "on",
+				Fallback:          "on",
+				Instance:          "42",
+				ParseResponse:     "on",
+				ParseWebsocket:    "off",
+				UnpackResponse:    "on",
+				ParserDisable:     []string{},
+			},
+		},
+		{
+			Path:                    "/coffee/latte",
+			ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Wallarm: &version2.Wallarm{
+				Mode:              "off",
+				ModeAllowOverride: "on",
+				Fallback:          "on",
+				Instance:          "42",
+				ParseResponse:     "on",
+				ParseWebsocket:    "off",
+				UnpackResponse:    "on",
+				ParserDisable:     []string{},
+			},
+		},
 	}
 
-	expected := nginx.ServerConfig{
-		MaxFails:    5,
-		FailTimeout: "30s",
-		SlowStart:   "50s",
+	isPlus := false
+	tlsPemFileName := ""
+	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, "", nil, nil, nil, &baseCfgParams, isPlus, &StaticConfigParams{})
+	if !reflect.DeepEqual(result.Server.Wallarm, expectedServerWallarm) {
+		t.Errorf("generateVirtualServerConfig returned server Wallarm %+v but expected %+v", result.Server.Wallarm, expectedServerWallarm)
+	}
+	if !reflect.DeepEqual(result.Server.Locations, expectedLocations) {
+		t.Errorf("generateVirtualServerConfig returned locations \n%+v but expected \n%+v", result.Server.Locations, expectedLocations)
 	}
 
-	result := createUpstreamServersConfig(&baseCfgParams)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("createUpstreamServersConfig returned %v but expected %v", result, expected)
+	baseCfgParams.MainEnableWallarm = false
+	result = generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, "", nil, nil, nil, &baseCfgParams, isPlus, &StaticConfigParams{})
+	if result.Server.Wallarm != nil {
+		t.Errorf("generateVirtualServerConfig returned server Wallarm %+v for disabled Wallarm but expected nil", result.Server.Wallarm)
+	}
+	for _, l := range result.Server.Locations {
+		if l.Wallarm != nil {
+			t.Errorf("generateVirtualServerConfig returned Wallarm %+v for location %v for disabled Wallarm but expected nil", l.Wallarm, l.Path)
+		}
 	}
 }
 
-func TestGenerateSplitRouteConfig(t *testing.T) {
-	route := conf_v1alpha1.Route{
-		Path: "/",
+func TestGenerateVirtualServerConfigForVirtualServerWithPolicies(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1alpha1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1alpha1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+				},
+				Policies: []conf_v1alpha1.PolicyReference{
+					{
+						Name: "deny-blacklisted",
+					},
+				},
+				Routes: []conf_v1alpha1.Route{
+					{
+						Path:     "/tea",
+						Upstream: "tea",
+					},
+					{
+						Path:     "/tea/admin",
+						Upstream: "tea",
+						Policies: []conf_v1alpha1.PolicyReference{
+							{
+								Name: "allow-internal",
+							},
+						},
+					},
+					{
+						Path:     "/tea/missing",
+						Upstream: "tea",
+						Policies: []conf_v1alpha1.PolicyReference{
+							{
+								Name: "missing",
+							},
+						},
+					},
+					{
+						Path:  "/coffee",
+						Route: "coffee",
+						Policies: []conf_v1alpha1.PolicyReference{
+							{
+								Name: "allow-internal",
+							},
+						},
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": []string{
+				"10.0.0.20:80",
+			},
+			"coffee/coffee-svc:80": []string{
+				"10.0.0.30:80",
+			},
+		},
+		VirtualServerRoutes: []*conf_v1alpha1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "coffee",
+					Namespace: "default",
+				},
+				Spec: conf_v1alpha1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1alpha1.Upstream{
+						{
+							Name:    "coffee",
+							Service: "coffee-svc",
+							Port:    80,
+						},
+					},
+					Subroutes: []conf_v1alpha1.Route{
+						{
+							Path:     "/coffee",
+							Upstream: "coffee",
+						},
+						{
+							Path:     "/coffee/latte",
+							Upstream: "coffee",
+							Policies: []conf_v1alpha1.PolicyReference{
+								{
+									Name:      "deny-blacklisted",
+									Namespace: "default",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Policies: map[string]*conf_v1alpha1.Policy{
+			"default/allow-internal": {
+				Spec: conf_v1alpha1.PolicySpec{
+					AccessControl: &conf_v1alpha1.AccessControl{
+						Allow: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+			"default/deny-blacklisted": {
+				Spec: conf_v1alpha1.PolicySpec{
+					AccessControl: &conf_v1alpha1.AccessControl{
+						Deny: []string{"192.168.1.1"},
+					},
+				},
+			},
+		},
+	}
+
+	expectedLocations := []version2.Location{
+		{
+			Path:                    "/tea",
+			ProxyPass:               "http://vs_default_cafe_tea",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Deny:                    []string{"192.168.1.1"},
+		},
+		{
+			Path:                    "/tea/admin",
+			ProxyPass:               "http://vs_default_cafe_tea",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Allow:                   []string{"10.0.0.0/8"},
+		},
+		{
+			Path:                    "/tea/missing",
+			ProxyPass:               "http://vs_default_cafe_tea",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			PoliciesErrorReturn: &version2.Return{
+				Code: 500,
+			},
+		},
+		{
+			Path:                    "/coffee",
+			ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Allow:                   []string{"10.0.0.0/8"},
+		},
+		{
+			Path:                    "/coffee/latte",
+			ProxyPass:               "http://vs_default_cafe_vsr_default_coffee_coffee",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Deny:                    []string{"192.168.1.1"},
+		},
+	}
+
+	isPlus := false
+	tlsPemFileName := ""
+	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, "", nil, nil, nil, &ConfigParams{}, isPlus, &StaticConfigParams{})
+	if !reflect.DeepEqual(result.Server.Locations, expectedLocations) {
+		t.Errorf("generateVirtualServerConfig returned locations \n%+v but expected \n%+v", result.Server.Locations, expectedLocations)
+	}
+}
+
+func TestGenerateVirtualServerConfigForVirtualServerWithRateLimitPolicies(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1alpha1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1alpha1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+				},
+				Policies: []conf_v1alpha1.PolicyReference{
+					{
+						Name: "rate-limit",
+					},
+				},
+				Routes: []conf_v1alpha1.Route{
+					{
+						Path:     "/tea",
+						Upstream: "tea",
+					},
+					{
+						Path:     "/tea/green",
+						Upstream: "tea",
+						Policies: []conf_v1alpha1.PolicyReference{
+							{
+								Name: "rate-limit",
+							},
+						},
+					},
+					{
+						Path:     "/tea/black",
+						Upstream: "tea",
+						Policies: []conf_v1alpha1.PolicyReference{
+							{
+								Name: "rate-limit-strict",
+							},
+						},
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": []string{
+				"10.0.0.20:80",
+			},
+		},
+		Policies: map[string]*conf_v1alpha1.Policy{
+			"default/rate-limit": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "rate-limit",
+					Namespace: "default",
+				},
+				Spec: conf_v1alpha1.PolicySpec{
+					RateLimit: &conf_v1alpha1.RateLimit{
+						Rate: "10r/s",
+						Key:  "${binary_remote_addr}",
+					},
+				},
+			},
+			"default/rate-limit-strict": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "rate-limit-strict",
+					Namespace: "default",
+				},
+				Spec: conf_v1alpha1.PolicySpec{
+					RateLimit: &conf_v1alpha1.RateLimit{
+						Rate: "1r/s",
+						Key:  "${binary_remote_addr}",
+					},
+				},
+			},
+		},
+	}
+
+	expectedZones := []version2.LimitReqZone{
+		{
+			Name: "pol_rl_default_rate-limit_default_cafe",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+		{
+			Name: "pol_rl_default_rate-limit-strict_default_cafe",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "1r/s",
+		},
+	}
+
+	expectedLocations := []version2.Location{
+		{
+			Path:                    "/tea",
+			ProxyPass:               "http://vs_default_cafe_tea",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			LimitReq: &version2.LimitReq{
+				Zone: "pol_rl_default_rate-limit_default_cafe",
+			},
+		},
+		{
+			Path:                    "/tea/green",
+			ProxyPass:               "http://vs_default_cafe_tea",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			LimitReq: &version2.LimitReq{
+				Zone: "pol_rl_default_rate-limit_default_cafe",
+			},
+		},
+		{
+			Path:                    "/tea/black",
+			ProxyPass:               "http://vs_default_cafe_tea",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			LimitReq: &version2.LimitReq{
+				Zone: "pol_rl_default_rate-limit-strict_default_cafe",
+			},
+		},
+	}
+
+	isPlus := false
+	tlsPemFileName := ""
+	result := generateVirtualServerConfig(&virtualServerEx, tlsPemFileName, "", nil, nil, nil, &ConfigParams{}, isPlus, &StaticConfigParams{})
+	if !reflect.DeepEqual(result.LimitReqZones, expectedZones) {
+		t.Errorf("generateVirtualServerConfig returned zones \n%+v but expected \n%+v", result.LimitReqZones, expectedZones)
+	}
+	if !reflect.DeepEqual(result.Server.Locations, expectedLocations) {
+		t.Errorf("generateVirtualServerConfig returned locations \n%+v but expected \n%+v", result.Server.Locations, expectedLocations)
+	}
+}
+
+func TestGenerateUpstream(t *testing.T) {
+	name := "test-upstream"
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	isPlus := false
+	cfgParams := ConfigParams{
+		LBMethod:    "random",
+		MaxFails:    1,
+		FailTimeout: "10s",
+	}
+
+	expected := version2.Upstream{
+		Name: "test-upstream",
+		Servers: []version2.UpstreamServer{
+			{
+				Address:     "192.168.10.10:8080",
+				MaxFails:    1,
+				FailTimeout: "10s",
+			},
+		},
+		LBMethod: "random",
+	}
+
+	result := generateUpstream(name, conf_v1alpha1.Upstream{}, endpoints, nil, isPlus, &cfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateUpstreamWithUpstreamSettings(t *testing.T) {
+	name := "test-upstream"
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	maxFails := 0
+	maxConns := 16
+	keepalive := 32
+	upstream := conf_v1alpha1.Upstream{
+		LBMethod:    "least_conn",
+		MaxFails:    &maxFails,
+		MaxConns:    &maxConns,
+		FailTimeout: "30s",
+		Keepalive:   &keepalive,
+	}
+	isPlus := false
+	cfgParams := ConfigParams{
+		LBMethod:    "random",
+		MaxFails:    1,
+		FailTimeout: "10s",
+		Keepalive:   64,
+	}
+
+	expected := version2.Upstream{
+		Name: "test-upstream",
+		Servers: []version2.UpstreamServer{
+			{
+				Address:     "192.168.10.10:8080",
+				MaxFails:    0,
+				MaxConns:    16,
+				FailTimeout: "30s",
+			},
+		},
+		LBMethod:  "least_conn",
+		Keepalive: 32,
+	}
+
+	result := generateUpstream(name, upstream, endpoints, nil, isPlus, &cfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateUpstreamWithSessionCookie(t *testing.T) {
+	name := "test-upstream"
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	upstream := conf_v1alpha1.Upstream{
+		LBMethod: "least_conn",
+		SessionCookie: &conf_v1alpha1.SessionCookie{
+			Enable:   true,
+			Name:     "srv_id",
+			Path:     "/",
+			Expires:  "1h",
+			Domain:   ".example.com",
+			HTTPOnly: true,
+			Secure:   true,
+		},
+	}
+	cfgParams := ConfigParams{
+		LBMethod:    "random two least_conn",
+		MaxFails:    1,
+		FailTimeout: "10s",
+	}
+
+	tests := []struct {
+		isPlus   bool
+		expected version2.Upstream
+		msg      string
+	}{
+		{
+			isPlus: true,
+			expected: version2.Upstream{
+				Name: "test-upstream",
+				Servers: []version2.UpstreamServer{
+					{
+						Address:     "192.168.10.10:8080",
+						MaxFails:    1,
+						FailTimeout: "10s",
+					},
+				},
+				LBMethod: "least_conn",
+				SessionCookie: &version2.SessionCookie{
+					Name:     "srv_id",
+					Path:     "/",
+					Expires:  "1h",
+					Domain:   ".example.com",
+					HTTPOnly: true,
+					Secure:   true,
+				},
+			},
+			msg: "NGINX Plus",
+		},
+		{
+			isPlus: false,
+			expected: version2.Upstream{
+				Name: "test-upstream",
+				Servers: []version2.UpstreamServer{
+					{
+						Address:     "192.168.10.10:8080",
+						MaxFails:    1,
+						FailTimeout: "10s",
+					},
+				},
+				LBMethod: "hash $test_upstream_session_id consistent",
+			},
+			msg: "NGINX",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateUpstream(name, upstream, endpoints, nil, test.isPlus, &cfgParams)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateUpstream() returned %+v but expected %+v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateSessionCookieMaps(t *testing.T) {
+	sc := &conf_v1alpha1.SessionCookie{
+		Enable:   true,
+		Name:     "srv_id",
+		Path:     "/",
+		Expires:  "1h 30m",
+		Domain:   ".example.com",
+		HTTPOnly: true,
+		Secure:   true,
+	}
+
+	expected := []version2.Map{
+		{
+			Source:   "$cookie_srv_id",
+			Variable: "$vs_default_cafe_tea_session_id",
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: "$request_id"},
+				{Value: "default", Result: "$cookie_srv_id"},
+			},
+		},
+		{
+			Source:   "$cookie_srv_id",
+			Variable: "$vs_default_cafe_tea_set_session_cookie",
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: `"srv_id=$request_id; Max-Age=5400; Domain=.example.com; HttpOnly; Secure; Path=/"`},
+				{Value: "default", Result: `""`},
+			},
+		},
+	}
+
+	result := generateSessionCookieMaps("vs_default_cafe-tea", sc)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateSessionCookieMaps() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateSetSessionCookie(t *testing.T) {
+	tests := []struct {
+		sc       *conf_v1alpha1.SessionCookie
+		expected string
+		msg      string
+	}{
+		{
+			sc:       &conf_v1alpha1.SessionCookie{Enable: true, Name: "srv_id"},
+			expected: "srv_id=$request_id",
+			msg:      "session cookie",
+		},
+		{
+			sc:       &conf_v1alpha1.SessionCookie{Enable: true, Name: "srv_id", Expires: "max", Path: "/tea"},
+			expected: "srv_id=$request_id; Expires=Thu, 31 Dec 2037 23:55:55 GMT; Max-Age=315360000; Path=/tea",
+			msg:      "max expiration time",
+		},
+		{
+			sc:       &conf_v1alpha1.SessionCookie{Enable: true, Name: "srv_id", Expires: "30s", Secure: true},
+			expected: "srv_id=$request_id; Max-Age=30; Secure",
+			msg:      "expiration time",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateSetSessionCookie(test.sc)
+		if result != test.expected {
+			t.Errorf("generateSetSessionCookie() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestConvertTimeToSeconds(t *testing.T) {
+	tests := []struct {
+		time     string
+		expected int
+	}{
+		{time: "10", expected: 10},
+		{time: "10s", expected: 10},
+		{time: "2m", expected: 120},
+		{time: "1h 30m", expected: 5400},
+		{time: "1d", expected: 86400},
+		{time: "1w", expected: 604800},
+		{time: "1M", expected: 2592000},
+		{time: "1y", expected: 31536000},
+		{time: "1500ms", expected: 1},
+		{time: "1m1s", expected: 61},
+	}
+
+	for _, test := range tests {
+		result := convertTimeToSeconds(test.time)
+		if result != test.expected {
+			t.Errorf("convertTimeToSeconds(%q) returned %d but expected %d", test.time, result, test.expected)
+		}
+	}
+}
+
+func TestSetSessionCookieForLocations(t *testing.T) {
+	locations := []version2.Location{
+		{Path: "/tea", ProxyPass: "http://vs_default_cafe_tea"},
+		{Path: "/coffee", ProxyPass: "https://vs_default_cafe_coffee"},
+		{Path: "/grpc", GRPCPass: "grpc://vs_default_cafe_tea"},
+		{Path: "/return", Return: &version2.Return{Code: 200, Text: "ok"}},
+	}
+	setSessionCookies := map[string]string{
+		"vs_default_cafe_tea": "$vs_default_cafe_tea_set_session_cookie",
+	}
+	setCookieHeader := []version2.AddHeader{
+		{Header: version2.Header{Name: "Set-Cookie", Value: "$vs_default_cafe_tea_set_session_cookie"}},
+	}
+
+	expected := []version2.Location{
+		{Path: "/tea", ProxyPass: "http://vs_default_cafe_tea", AddHeaders: setCookieHeader},
+		{Path: "/coffee", ProxyPass: "https://vs_default_cafe_coffee"},
+		{Path: "/grpc", GRPCPass: "grpc://vs_default_cafe_tea", AddHeaders: setCookieHeader},
+		{Path: "/return", Return: &version2.Return{Code: 200, Text: "ok"}},
+	}
+
+	setSessionCookieForLocations(locations, setSessionCookies)
+	if !reflect.DeepEqual(locations, expected) {
+		t.Errorf("setSessionCookieForLocations() returned %v but expected %v", locations, expected)
+	}
+}
+
+func TestGenerateLocationForRoute(t *testing.T) {
+	virtualServer := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	crUpstreams := map[string]conf_v1alpha1.Upstream{
+		"vs_default_cafe_tea": {},
+	}
+	cfgParams := ConfigParams{
+		ProxyConnectTimeout: "30s",
+		ProxyReadTimeout:    "31s",
+		ClientMaxBodySize:   "1m",
+	}
+
+	expectedProxyLocation := version2.Location{
+		Path:                    "/",
+		ProxyConnectTimeout:     "30s",
+		ProxyReadTimeout:        "31s",
+		ClientMaxBodySize:       "1m",
+		ProxyPass:               "http://vs_default_cafe_tea",
+		ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+		ProxyPassRequestHeaders: true,
+	}
+
+	tests := []struct {
+		upstream string
+		action   *conf_v1alpha1.Action
+		expected version2.Location
+		msg      string
+	}{
+		{
+			upstream: "tea",
+			action:   nil,
+			expected: expectedProxyLocation,
+			msg:      "upstream",
+		},
+		{
+			upstream: "",
+			action: &conf_v1alpha1.Action{
+				Pass: "tea",
+			},
+			expected: expectedProxyLocation,
+			msg:      "pass action",
+		},
+		{
+			upstream: "",
+			action: &conf_v1alpha1.Action{
+				Redirect: &conf_v1alpha1.ActionRedirect{
+					URL: "https://example.com",
+				},
+			},
+			expected: version2.Location{
+				Path: "/",
+				Return: &version2.Return{
+					Code: 301,
+					Text: "https://example.com",
+				},
+			},
+			msg: "redirect action with the default code",
+		},
+		{
+			upstream: "",
+			action: &conf_v1alpha1.Action{
+				Redirect: &conf_v1alpha1.ActionRedirect{
+					URL:  "https://example.com",
+					Code: 307,
+				},
+			},
+			expected: version2.Location{
+				Path: "/",
+				Return: &version2.Return{
+					Code: 307,
+					Text: "https://example.com",
+				},
+			},
+			msg: "redirect action",
+		},
+		{
+			upstream: "",
+			action: &conf_v1alpha1.Action{
+				Return: &conf_v1alpha1.ActionReturn{
+					Body: "hello",
+				},
+			},
+			expected: version2.Location{
+				Path: "/",
+				Return: &version2.Return{
+					Code: 200,
+					Type: "text/plain",
+					Text: "hello",
+				},
+			},
+			msg: "return action with the default code and type",
+		},
+		{
+			upstream: "",
+			action: &conf_v1alpha1.Action{
+				Return: &conf_v1alpha1.ActionReturn{
+					Code: 503,
+					Type: "application/json",
+					Body: `{\"status\": \"maintenance\"}`,
+				},
+			},
+			expected: version2.Location{
+				Path: "/",
+				Return: &version2.Return{
+					Code: 503,
+					Type: "application/json",
+					Text: `{\"status\": \"maintenance\"}`,
+				},
+			},
+			msg: "return action",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateLocationForRoute("/", test.upstream, test.action, nil, upstreamNamer, crUpstreams, nil, &cfgParams)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateLocationForRoute() returned \n%+v but expected \n%+v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateProxySetHeaders(t *testing.T) {
+	tests := []struct {
+		requestHeaders *conf_v1alpha1.ProxyRequestHeaders
+		expected       []version2.Header
+		msg            string
+	}{
+		{
+			requestHeaders: nil,
+			expected: []version2.Header{
+				{Name: "Host", Value: "$host"},
+			},
+			msg: "no request headers",
+		},
+		{
+			requestHeaders: &conf_v1alpha1.ProxyRequestHeaders{
+				Set: []conf_v1alpha1.Header{
+					{Name: "X-Request-Info", Value: "$request_method"},
+				},
+				Ignore: []string{"Cookie"},
+			},
+			expected: []version2.Header{
+				{Name: "Host", Value: "$host"},
+				{Name: "X-Request-Info", Value: "$request_method"},
+				{Name: "Cookie", Value: ""},
+			},
+			msg: "set and ignored headers",
+		},
+		{
+			requestHeaders: &conf_v1alpha1.ProxyRequestHeaders{
+				Set: []conf_v1alpha1.Header{
+					{Name: "host", Value: "example.com"},
+				},
+			},
+			expected: []version2.Header{
+				{Name: "host", Value: "example.com"},
+			},
+			msg: "overridden host",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateProxySetHeaders(test.requestHeaders)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateProxySetHeaders() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestSetHeadersForLocations(t *testing.T) {
+	pass := false
+	requestHeaders := &conf_v1alpha1.ProxyRequestHeaders{
+		Pass: &pass,
+		Set: []conf_v1alpha1.Header{
+			{Name: "X-Request-Info", Value: "$request_method"},
+		},
+	}
+	responseHeaders := &conf_v1alpha1.ProxyResponseHeaders{
+		Add: []conf_v1alpha1.AddHeader{
+			{
+				Header: conf_v1alpha1.Header{Name: "X-Upstream", Value: "$upstream_addr"},
+				Always: true,
+			},
+		},
+		Hide:   []string{"X-Powered-By"},
+		Pass:   []string{"Server"},
+		Ignore: []string{"Expires", "Vary"},
+	}
+
+	locations := []version2.Location{
+		{
+			Path:                    "@splits_0_split_0",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+		},
+		{
+			Path: "@splits_0_split_1",
+			Return: &version2.Return{
+				Code: 200,
+				Text: "ok",
+			},
+		},
+	}
+	expectedAddHeaders := []version2.AddHeader{
+		{
+			Header: version2.Header{Name: "X-Upstream", Value: "$upstream_addr"},
+			Always: true,
+		},
+	}
+	expected := []version2.Location{
+		{
+			Path: "@splits_0_split_0",
+			ProxySetHeaders: []version2.Header{
+				{Name: "Host", Value: "$host"},
+				{Name: "X-Request-Info", Value: "$request_method"},
+			},
+			ProxyPassRequestHeaders: false,
+			ProxyHideHeaders:        []string{"X-Powered-By"},
+			ProxyPassHeaders:        []string{"Server"},
+			ProxyIgnoreHeaders:      "Expires Vary",
+			AddHeaders:              expectedAddHeaders,
+		},
+		{
+			Path: "@splits_0_split_1",
+			Return: &version2.Return{
+				Code: 200,
+				Text: "ok",
+			},
+			ProxyHideHeaders:   []string{"X-Powered-By"},
+			ProxyPassHeaders:   []string{"Server"},
+			ProxyIgnoreHeaders: "Expires Vary",
+			AddHeaders:         expectedAddHeaders,
+		},
+	}
+
+	setHeadersForLocations(locations, requestHeaders, responseHeaders)
+	if !reflect.DeepEqual(locations, expected) {
+		t.Errorf("setHeadersForLocations() returned \n%+v but expected \n%+v", locations, expected)
+	}
+}
+
+func TestGenerateHealthCheck(t *testing.T) {
+	upstreamName := "test-upstream"
+	cfgParams := ConfigParams{
+		ProxyConnectTimeout: "30s",
+		ProxyReadTimeout:    "30s",
+	}
+
+	fails := 5
+	probe := &api_v1.Probe{
+		Handler: api_v1.Handler{
+			HTTPGet: &api_v1.HTTPGetAction{
+				Path:   "/ready",
+				Scheme: api_v1.URISchemeHTTPS,
+				HTTPHeaders: []api_v1.HTTPHeader{
+					{
+						Name:  "Host",
+						Value: "probe.example.com",
+					},
+				},
+			},
+		},
+		TimeoutSeconds:   2,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}
+
+	tests := []struct {
+		upstream conf_v1alpha1.Upstream
+		probe    *api_v1.Probe
+		expected *version2.HealthCheck
+		msg      string
+	}{
+		{
+			upstream: conf_v1alpha1.Upstream{
+				HealthCheck: &conf_v1alpha1.HealthCheck{
+					Enable: true,
+				},
+			},
+			probe: nil,
+			expected: &version2.HealthCheck{
+				Name:                upstreamName,
+				URI:                 "/",
+				Interval:            "5s",
+				Jitter:              "0s",
+				Fails:               1,
+				Passes:              1,
+				ProxyPass:           "http://test-upstream",
+				ProxyConnectTimeout: "30s",
+				ProxyReadTimeout:    "30s",
+				ProxySendTimeout:    "60s",
+				Headers:             map[string]string{},
+			},
+			msg: "empty health check without a readiness probe",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{
+				HealthCheck: &conf_v1alpha1.HealthCheck{
+					Enable: true,
+				},
+			},
+			probe: probe,
+			expected: &version2.HealthCheck{
+				Name:                upstreamName,
+				URI:                 "/ready",
+				Interval:            "10s",
+				Jitter:              "0s",
+				Fails:               3,
+				Passes:              1,
+				ProxyPass:           "https://test-upstream",
+				ProxyConnectTimeout: "2s",
+				ProxyReadTimeout:    "2s",
+				ProxySendTimeout:    "2s",
+				Headers: map[string]string{
+					"Host": "probe.example.com",
+				},
+			},
+			msg: "empty health check with a readiness probe",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{
+				HealthCheck: &conf_v1alpha1.HealthCheck{
+					Enable:         true,
+					Path:           "/healthz",
+					Interval:       "1s",
+					Jitter:         "1s",
+					Fails:          &fails,
+					Port:           8080,
+					TLS:            &conf_v1alpha1.UpstreamTLS{Enable: false},
+					ConnectTimeout: "1s",
+					Headers: []conf_v1alpha1.Header{
+						{
+							Name:  "Host",
+							Value: "my.service",
+						},
+					},
+					StatusMatch: "200",
+				},
+			},
+			probe: probe,
+			expected: &version2.HealthCheck{
+				Name:                upstreamName,
+				URI:                 "/healthz",
+				Interval:            "1s",
+				Jitter:              "1s",
+				Fails:               5,
+				Passes:              1,
+				Port:                8080,
+				ProxyPass:           "http://test-upstream",
+				ProxyConnectTimeout: "1s",
+				ProxyReadTimeout:    "2s",
+				ProxySendTimeout:    "2s",
+				Headers: map[string]string{
+					"Host": "my.service",
+				},
+				Match: "test-upstream_match",
+			},
+			msg: "health check overrides the readiness probe",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateHealthCheck(test.upstream, upstreamName, &cfgParams, test.probe, true)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateHealthCheck() returned \n%+v but expected \n%+v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateHealthCheckReturnsNil(t *testing.T) {
+	tests := []struct {
+		upstream conf_v1alpha1.Upstream
+		isPlus   bool
+		msg      string
+	}{
+		{
+			upstream: conf_v1alpha1.Upstream{},
+			isPlus:   true,
+			msg:      "no health check",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{
+				HealthCheck: &conf_v1alpha1.HealthCheck{
+					Enable: false,
+				},
+			},
+			isPlus: true,
+			msg:    "disabled health check",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{
+				HealthCheck: &conf_v1alpha1.HealthCheck{
+					Enable: true,
+				},
+			},
+			isPlus: false,
+			msg:    "health check for NGINX",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateHealthCheck(test.upstream, "test-upstream", &ConfigParams{}, nil, test.isPlus)
+		if result != nil {
+			t.Errorf("generateHealthCheck() returned %+v but expected nil for the case of %s", result, test.msg)
+		}
+	}
+}
+
+func TestGenerateUpstreamMatch(t *testing.T) {
+	hc := &conf_v1alpha1.HealthCheck{
+		Enable:      true,
+		StatusMatch: "! 500",
+		BodyMatch:   "ok",
+	}
+	expected := version2.Match{
+		Name:   "test-upstream_match",
+		Status: "! 500",
+		Body:   "ok",
+	}
+
+	result := generateUpstreamMatch("test-upstream_match", hc)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateUpstreamMatch() returned %+v but expected %+v", result, expected)
+	}
+}
+
+func TestGenerateLBMethod(t *testing.T) {
+	defaultMethod := "random two least_conn"
+
+	tests := []struct {
+		input    string
+		isPlus   bool
+		expected string
+	}{
+		{
+			input:    "",
+			isPlus:   false,
+			expected: defaultMethod,
+		},
+		{
+			input:    "round_robin",
+			isPlus:   false,
+			expected: "",
+		},
+		{
+			input:    "random",
+			isPlus:   false,
+			expected: "random",
+		},
+		{
+			input:    "least_time header",
+			isPlus:   true,
+			expected: "least_time header",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateLBMethod(test.input, defaultMethod, test.isPlus)
+		if result != test.expected {
+			t.Errorf("generateLBMethod(%q, %q, %v) returned %q but expected %q", test.input, defaultMethod, test.isPlus, result, test.expected)
+		}
+	}
+}
+
+func TestGenerateUpstreamForZeroEndpoints(t *testing.T) {
+	name := "test-upstream"
+	var endpoints []string // nil
+	cfgParams := ConfigParams{
+		MaxFails:    1,
+		FailTimeout: "10s",
+	}
+
+	isPlus := false
+	expectedForNGINX := version2.Upstream{
+		Name: "test-upstream",
+		Servers: []version2.UpstreamServer{
+			{
+				Address:     nginx502Server,
+				MaxFails:    1,
+				FailTimeout: "10s",
+			},
+		},
+	}
+
+	result := generateUpstream(name, conf_v1alpha1.Upstream{}, endpoints, nil, isPlus, &cfgParams)
+	if !reflect.DeepEqual(result, expectedForNGINX) {
+		t.Errorf("generateUpstream(isPlus=%v) returned %v but expected %v", isPlus, result, expectedForNGINX)
+	}
+
+	isPlus = true
+	expectedForNGINXPlus := version2.Upstream{
+		Name:    "test-upstream",
+		Servers: nil,
+	}
+
+	result = generateUpstream(name, conf_v1alpha1.Upstream{}, endpoints, nil, isPlus, &cfgParams)
+	if !reflect.DeepEqual(result, expectedForNGINXPlus) {
+		t.Errorf("generateUpstream(isPlus=%v) returned %v but expected %v", isPlus, result, expectedForNGINXPlus)
+	}
+}
+
+func TestGenerateLocation(t *testing.T) {
+	cfgParams := ConfigParams{
+		ProxyConnectTimeout:  "30s",
+		ProxyReadTimeout:     "31s",
+		ClientMaxBodySize:    "1m",
+		ProxyMaxTempFileSize: "1024m",
+		ProxyBuffering:       true,
+		ProxyBuffers:         "8 4k",
+		ProxyBufferSize:      "4k",
+		LocationSnippets:     []string{"# location snippet"},
+	}
+	path := "/"
+	upstreamName := "test-upstream"
+
+	expected := version2.Location{
+		Path:                    "/",
+		Snippets:                []string{"# location snippet"},
+		ProxyConnectTimeout:     "30s",
+		ProxyReadTimeout:        "31s",
+		ClientMaxBodySize:       "1m",
+		ProxyMaxTempFileSize:    "1024m",
+		ProxyBuffering:          true,
+		ProxyBuffers:            "8 4k",
+		ProxyBufferSize:         "4k",
+		ProxyPass:               "http://test-upstream",
+		ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+		ProxyPassRequestHeaders: true,
+	}
+
+	result := generateLocation(path, upstreamName, conf_v1alpha1.Upstream{}, &cfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateLocation() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateLocationWithUpstreamSettings(t *testing.T) {
+	cfgParams := ConfigParams{
+		ProxyConnectTimeout:  "30s",
+		ProxyReadTimeout:     "31s",
+		ClientMaxBodySize:    "1m",
+		ProxyMaxTempFileSize: "1024m",
+		ProxyBuffering:       true,
+		ProxyBuffers:         "8 4k",
+		ProxyBufferSize:      "4k",
+	}
+	path := "/"
+	upstreamName := "test-upstream"
+	keepalive := 32
+	buffering := false
+	nextUpstreamTries := 5
+	upstream := conf_v1alpha1.Upstream{
+		Keepalive:           &keepalive,
+		ProxyConnectTimeout: "10s",
+		ProxyReadTimeout:    "11s",
+		ProxySendTimeout:    "12s",
+		ProxyBuffering:      &buffering,
+		ProxyBuffers: &conf_v1alpha1.UpstreamBuffers{
+			Number: 16,
+			Size:   "8k",
+		},
+		ProxyBufferSize:          "8k",
+		ClientMaxBodySize:        "2m",
+		ProxyNextUpstream:        "error timeout http_502",
+		ProxyNextUpstreamTimeout: "20s",
+		ProxyNextUpstreamTries:   &nextUpstreamTries,
+		TLS:                      &conf_v1alpha1.UpstreamTLS{Enable: true},
+	}
+
+	expected := version2.Location{
+		Path:                     "/",
+		ProxyConnectTimeout:      "10s",
+		ProxyReadTimeout:         "11s",
+		ProxySendTimeout:         "12s",
+		ClientMaxBodySize:        "2m",
+		ProxyMaxTempFileSize:     "1024m",
+		ProxyBuffering:           false,
+		ProxyBuffers:             "16 8k",
+		ProxyBufferSize:          "8k",
+		ProxyNextUpstream:        "error timeout http_502",
+		ProxyNextUpstreamTimeout: "20s",
+		ProxyNextUpstreamTries:   5,
+		ProxyPass:                "https://test-upstream",
+		HasKeepalive:             true,
+		ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+		ProxyPassRequestHeaders:  true,
+	}
+
+	result := generateLocation(path, upstreamName, upstream, &cfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateLocation() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateLocationForGRPC(t *testing.T) {
+	cfgParams := ConfigParams{
+		ProxyConnectTimeout: "30s",
+		ProxyReadTimeout:    "31s",
+		ClientMaxBodySize:   "1m",
+	}
+	path := "/"
+	upstreamName := "test-upstream"
+
+	tests := []struct {
+		upstream conf_v1alpha1.Upstream
+		expected string
+		msg      string
+	}{
+		{
+			upstream: conf_v1alpha1.Upstream{Type: "grpc"},
+			expected: "grpc://test-upstream",
+			msg:      "grpc upstream",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{
+				Type: "grpc",
+				TLS:  &conf_v1alpha1.UpstreamTLS{Enable: true},
+			},
+			expected: "grpcs://test-upstream",
+			msg:      "grpc upstream with TLS",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateLocation(path, upstreamName, test.upstream, &cfgParams)
+		if result.GRPCPass != test.expected {
+			t.Errorf("generateLocation() returned GRPCPass %q but expected %q for the case of %s", result.GRPCPass, test.expected, test.msg)
+		}
+		if result.ProxyPass != "" {
+			t.Errorf("generateLocation() returned ProxyPass %q but expected an empty string for the case of %s", result.ProxyPass, test.msg)
+		}
+	}
+}
+
+func TestGenerateUpstreamType(t *testing.T) {
+	tests := []struct {
+		upstream conf_v1alpha1.Upstream
+		ssl      *version2.SSL
+		expected string
+		msg      string
+	}{
+		{
+			upstream: conf_v1alpha1.Upstream{},
+			ssl:      nil,
+			expected: "",
+			msg:      "no type",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{Type: "http"},
+			ssl:      nil,
+			expected: "http",
+			msg:      "http type",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{Type: "grpc"},
+			ssl:      &version2.SSL{HTTP2: true},
+			expected: "grpc",
+			msg:      "grpc type with TLS and HTTP2",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{Type: "grpc"},
+			ssl:      nil,
+			expected: "http",
+			msg:      "grpc type without TLS",
+		},
+		{
+			upstream: conf_v1alpha1.Upstream{Type: "grpc"},
+			ssl:      &version2.SSL{HTTP2: false},
+			expected: "http",
+			msg:      "grpc type without HTTP2",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateUpstreamType(test.upstream, test.ssl)
+		if result != test.expected {
+			t.Errorf("generateUpstreamType() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGetUpstreamsWithIgnoredGRPCType(t *testing.T) {
+	virtualServerEx := &VirtualServerEx{
+		VirtualServer: &conf_v1alpha1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1alpha1.Upstream{
+					{Name: "tea", Service: "tea-svc", Port: 80},
+					{Name: "grpc-tea", Service: "grpc-tea-svc", Port: 50051, Type: "grpc"},
+				},
+			},
+		},
+		VirtualServerRoutes: []*conf_v1alpha1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "coffee",
+					Namespace: "default",
+				},
+				Spec: conf_v1alpha1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1alpha1.Upstream{
+						{Name: "grpc-coffee", Service: "grpc-coffee-svc", Port: 50051, Type: "grpc"},
+					},
+				},
+			},
+		},
+	}
+	expected := []string{"grpc-tea", "grpc-coffee of VirtualServerRoute default/coffee"}
+
+	result := getUpstreamsWithIgnoredGRPCType(virtualServerEx, true)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("getUpstreamsWithIgnoredGRPCType() returned %v but expected %v for the case of no TLS", result, expected)
+	}
+
+	virtualServerEx.VirtualServer.Spec.TLS = &conf_v1alpha1.TLS{Secret: "cafe-secret"}
+
+	result = getUpstreamsWithIgnoredGRPCType(virtualServerEx, false)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("getUpstreamsWithIgnoredGRPCType() returned %v but expected %v for the case of disabled HTTP/2", result, expected)
+	}
+
+	result = getUpstreamsWithIgnoredGRPCType(virtualServerEx, true)
+	if result != nil {
+		t.Errorf("getUpstreamsWithIgnoredGRPCType() returned %v but expected nil for the case of TLS and HTTP/2", result)
+	}
+}
+
+func TestGenerateProxySSL(t *testing.T) {
+	verifyDepth := 3
+	upstreamCASecrets := map[string]*api_v1.Secret{
+		"default/backend-ca": {
+			Data: map[string][]byte{CAKey: []byte("ca")},
+		},
+		"default/backend-ca-with-crl": {
+			Data: map[string][]byte{CAKey: []byte("ca"), CRLKey: []byte("crl")},
+		},
+	}
+	upstreamCAFileNames := map[string]string{
+		"default/backend-ca":          "/etc/nginx/secrets/default-backend-ca_ca",
+		"default/backend-ca-with-crl": "/etc/nginx/secrets/default-backend-ca-with-crl_ca",
+	}
+	upstreamPemFileNames := map[string]string{
+		"default/backend-client-secret": "/etc/nginx/secrets/default-backend-client-secret",
+	}
+
+	tests := []struct {
+		tls      *conf_v1alpha1.UpstreamTLS
+		expected *version2.ProxySSL
+		msg      string
+	}{
+		{
+			tls:      nil,
+			expected: nil,
+			msg:      "no tls",
+		},
+		{
+			tls:      &conf_v1alpha1.UpstreamTLS{Enable: false, TrustedCertSecret: "backend-ca"},
+			expected: nil,
+			msg:      "disabled tls",
+		},
+		{
+			tls:      &conf_v1alpha1.UpstreamTLS{Enable: true},
+			expected: &version2.ProxySSL{},
+			msg:      "tls without verification",
+		},
+		{
+			tls: &conf_v1alpha1.UpstreamTLS{
+				Enable:            true,
+				TrustedCertSecret: "backend-ca",
+				ServerName:        "backend.example.com",
+			},
+			expected: &version2.ProxySSL{
+				TrustedCertificate: "/etc/nginx/secrets/default-backend-ca_ca",
+				VerifyDepth:        1,
+				Name:               "backend.example.com",
+			},
+			msg: "verification with the default verify depth",
+		},
+		{
+			tls: &conf_v1alpha1.UpstreamTLS{
+				Enable:            true,
+				TrustedCertSecret: "backend-ca-with-crl",
+				ServerName:        "backend.example.com",
+			},
+			expected: &version2.ProxySSL{
+				TrustedCertificate: "/etc/nginx/secrets/default-backend-ca-with-crl_ca",
+				CRLFile:            "/etc/nginx/secrets/default-backend-ca-with-crl_ca",
+				VerifyDepth:        1,
+				Name:               "backend.example.com",
+			},
+			msg: "verification with a certificate revocation list",
+		},
+		{
+			tls: &conf_v1alpha1.UpstreamTLS{
+				Enable:            true,
+				TrustedCertSecret: "backend-ca",
+				VerifyDepth:       &verifyDepth,
+				Secret:            "backend-client-secret",
+			},
+			expected: &version2.ProxySSL{
+				TrustedCertificate: "/etc/nginx/secrets/default-backend-ca_ca",
+				VerifyDepth:        3,
+				Certificate:        "/etc/nginx/secrets/default-backend-client-secret",
+				CertificateKey:     "/etc/nginx/secrets/default-backend-client-secret",
+			},
+			msg: "verification and client certificate",
+		},
+		{
+			tls: &conf_v1alpha1.UpstreamTLS{
+				Enable:            true,
+				TrustedCertSecret: "missing-ca",
+			},
+			expected: &version2.ProxySSL{
+				Ciphers: "NULL",
+			},
+			msg: "missing trusted cert secret",
+		},
+		{
+			tls: &conf_v1alpha1.UpstreamTLS{
+				Enable: true,
+				Secret: "missing-secret",
+			},
+			expected: &version2.ProxySSL{
+				Ciphers: "NULL",
+			},
+			msg: "missing client certificate secret",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateProxySSL(test.tls, "default", upstreamCASecrets, upstreamCAFileNames, upstreamPemFileNames)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateProxySSL() returned %+v but expected %+v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateSSLConfig(t *testing.T) {
+	verifyDepth := 3
+	forwardToUpstream := true
+
+	tests := []struct {
+		inputTLS              *conf_v1alpha1.TLS
+		inputTLSPemFileName   string
+		inputClientCASecret   *api_v1.Secret
+		inputClientCAFileName string
+		inputCfgParams        *ConfigParams
+		expected              *version2.SSL
+		msg                   string
+	}{
+		{
+			inputTLS:            nil,
+			inputTLSPemFileName: "",
+			inputCfgParams:      &ConfigParams{},
+			expected:            nil,
+			msg:                 "no TLS field",
+		},
+		{
+			inputTLS: &conf_v1alpha1.TLS{
+				Secret: "",
+			},
+			inputTLSPemFileName: "",
+			inputCfgParams:      &ConfigParams{},
+			expected:            nil,
+			msg:                 "TLS field with empty secret",
+		},
+		{
+			inputTLS: &conf_v1alpha1.TLS{
+				Secret: "secret",
+			},
+			inputTLSPemFileName: "",
+			inputCfgParams:      &ConfigParams{},
+			expected: &version2.SSL{
+				HTTP2:           false,
+				Certificate:     pemFileNameForMissingTLSSecret,
+				CertificateKey:  pemFileNameForMissingTLSSecret,
+				Ciphers:         "NULL",
+				RedirectToHTTPS: false,
+			},
+			msg: "secret doesn't exist in the cluster with HTTP2 and SSLRedirect disabled",
+		},
+		{
+			inputTLS: &conf_v1alpha1.TLS{
+				Secret: "secret",
+			},
+			inputTLSPemFileName: "secret.pem",
+			inputCfgParams:      &ConfigParams{},
+			expected: &version2.SSL{
+				HTTP2:           false,
+				Certificate:     "secret.pem",
+				CertificateKey:  "secret.pem",
+				Ciphers:         "",
+				RedirectToHTTPS: false,
+			},
+			msg: "normal case with HTTP2 and SSLRedirect disabled",
+		},
+		{
+			inputTLS: &conf_v1alpha1.TLS{
+				Secret: "secret",
+			},
+			inputTLSPemFileName: "secret.pem",
+			inputCfgParams: &ConfigParams{
+				HTTP2:       true,
+				SSLRedirect: true,
+			},
+			expected: &version2.SSL{
+				HTTP2:           true,
+				Certificate:     "secret.pem",
+				CertificateKey:  "secret.pem",
+				Ciphers:         "",
+				RedirectToHTTPS: true,
+			},
+			msg: "normal case with HTTP2 and SSLRedirect enabled",
+		},
+		{
+			inputTLS: &conf_v1alpha1.TLS{
+				Secret: "secret",
+				ClientCertificate: &conf_v1alpha1.ClientCertificate{
+					Secret: "ca-secret",
+				},
+			},
+			inputTLSPemFileName: "secret.pem",
+			inputCfgParams:      &ConfigParams{},
+			expected: &version2.SSL{
+				Certificate:    "secret.pem",
+				CertificateKey: "secret.pem",
+				Ciphers:        "NULL",
+			},
+			msg: "CA secret doesn't exist in the cluster",
+		},
+		{
+			inputTLS: &conf_v1alpha1.TLS{
+				Secret: "secret",
+				ClientCertificate: &conf_v1alpha1.ClientCertificate{
+					Secret: "ca-secret",
+				},
+			},
+			inputTLSPemFileName: "secret.pem",
+			inputClientCASecret: &api_v1.Secret{
+				Data: map[string][]byte{
+					CAKey: []byte("ca"),
+				},
+			},
+			inputClientCAFileName: "ca-secret.pem",
+			inputCfgParams:        &ConfigParams{},
+			expected: &version2.SSL{
+				Certificate:    "secret.pem",
+				CertificateKey: "secret.pem",
+				ClientCertificate: &version2.ClientCertificate{
+					CAFile:      "ca-secret.pem",
+					Verify:      "on",
+					VerifyDepth: 1,
+				},
+			},
+			msg: "client certificate with default parameters",
+		},
+		{
+			inputTLS: &conf_v1alpha1.TLS{
+				Secret: "secret",
+				ClientCertificate: &conf_v1alpha1.ClientCertificate{
+					Secret:            "ca-secret",
+					Verify:            "optional",
+					VerifyDepth:       &verifyDepth,
+					ForwardToUpstream: &forwardToUpstream,
+				},
+			},
+			inputTLSPemFileName: "secret.pem",
+			inputClientCASecret: &api_v1.Secret{
+				Data: map[string][]byte{
+					CAKey:  []byte("ca"),
+					CRLKey: []byte("crl"),
+				},
+			},
+			inputClientCAFileName: "ca-secret.pem",
+			inputCfgParams:        &ConfigParams{},
+			expected: &version2.SSL{
+				Certificate:    "secret.pem",
+				CertificateKey: "secret.pem",
+				ClientCertificate: &version2.ClientCertificate{
+					CAFile:            "ca-secret.pem",
+					CRLFile:           "ca-secret.pem",
+					Verify:            "optional",
+					VerifyDepth:       3,
+					ForwardToUpstream: true,
+				},
+			},
+			msg: "client certificate with CRL and custom parameters",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateSSLConfig(test.inputTLS, test.inputTLSPemFileName, test.inputClientCASecret, test.inputClientCAFileName, test.inputCfgParams)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateSSLConfig() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestCreateUpstreamServersForPlus(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1alpha1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1alpha1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+					{
+						Name:    "test",
+						Service: "test-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1alpha1.Route{
+					{
+						Path:     "/tea",
+						Upstream: "tea",
+					},
+					{
+						Path:  "/coffee",
+						Route: "default/coffee",
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": []string{
+				"10.0.0.20:80",
+			},
+			"default/test-svc:80": []string{},
+			"default/coffee-svc:80": []string{
+				"10.0.0.30:80",
+			},
+		},
+		VirtualServerRoutes: []*conf_v1alpha1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "coffee",
+					Namespace: "default",
+				},
+				Spec: conf_v1alpha1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1alpha1.Upstream{
+						{
+							Name:    "coffee",
+							Service: "coffee-svc",
+							Port:    80,
+						},
+					},
+					Subroutes: []conf_v1alpha1.Route{
+						{
+							Path:     "/coffee",
+							Upstream: "coffee",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []version2.Upstream{
+		{
+			Name: "vs_default_cafe_tea",
+			Servers: []version2.UpstreamServer{
+				{
+					Address: "10.0.0.20:80",
+				},
+			},
+		},
+		{
+			Name:    "vs_default_cafe_test",
+			Servers: nil,
+		},
+		{
+			Name: "vs_default_cafe_vsr_default_coffee_coffee",
+			Servers: []version2.UpstreamServer{
+				{
+					Address: "10.0.0.30:80",
+				},
+			},
+		},
+	}
+
+	result := createUpstreamsForPlus(&virtualServerEx, &ConfigParams{})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("createUpstreamsForPlus returned \n%v but expected \n%v", result, expected)
+	}
+}
+
+func TestCreateUpstreamServersConfigForPlus(t *testing.T) {
+	baseCfgParams := ConfigParams{
+		MaxFails:    5,
+		FailTimeout: "30s",
+		SlowStart:   "50s",
+	}
+
+	upstream := version2.Upstream{
+		Servers: []version2.UpstreamServer{
+			{
+				Address:     "10.0.0.20:80",
+				MaxFails:    10,
+				FailTimeout: "60s",
+			},
+		},
+	}
+
+	expected := nginx.ServerConfig{
+		MaxFails:    10,
+		FailTimeout: "60s",
+		SlowStart:   "50s",
+	}
+
+	result := createUpstreamServersConfigForPlus(upstream, &baseCfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("createUpstreamServersConfigForPlus returned %v but expected %v", result, expected)
+	}
+
+	expected = nginx.ServerConfig{
+		MaxFails:    5,
+		FailTimeout: "30s",
+		SlowStart:   "50s",
+	}
+
+	result = createUpstreamServersConfigForPlus(version2.Upstream{}, &baseCfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("createUpstreamServersConfigForPlus returned %v but expected %v", result, expected)
+	}
+}
+
+func TestCreateEndpointsFromUpstream(t *testing.T) {
+	upstream := version2.Upstream{
+		Servers: []version2.UpstreamServer{
+			{
+				Address: "10.0.0.20:80",
+			},
+			{
+				Address: "10.0.0.30:80",
+			},
+		},
+	}
+
+	expected := []string{
+		"10.0.0.20:80",
+		"10.0.0.30:80",
+	}
+
+	result := createEndpointsFromUpstream(upstream)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("createEndpointsFromUpstream returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateSplitRouteConfig(t *testing.T) {
+	route := conf_v1alpha1.Route{
+		Path: "/",
+		Splits: []conf_v1alpha1.Split{
+			{
+				Weight:   90,
+				Upstream: "coffee-v1",
+			},
+			{
+				Weight:   10,
+				Upstream: "coffee-v2",
+			},
+		},
+	}
+	virtualServer := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	variableNamer := newVariableNamer(&virtualServer)
+	index := 1
+
+	expected := splitRouteCfg{
+		SplitClient: version2.SplitClient{
+			Source:   "$request_id",
+			Variable: "$vs_default_cafe_splits_1",
+			Distributions: []version2.Distribution{
+				{
+					Weight: "90%",
+					Value:  "@splits_1_split_0",
+				},
+				{
+					Weight: "10%",
+					Value:  "@splits_1_split_1",
+				},
+			},
+		},
+		Locations: []version2.Location{
+			{
+				Path:                    "@splits_1_split_0",
+				ProxyPass:               "http://vs_default_cafe_coffee-v1",
+				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+				ProxyPassRequestHeaders: true,
+			},
+			{
+				Path:                    "@splits_1_split_1",
+				ProxyPass:               "http://vs_default_cafe_coffee-v2",
+				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+				ProxyPassRequestHeaders: true,
+			},
+		},
+		InternalRedirectLocation: version2.InternalRedirectLocation{
+			Path:        "/",
+			Destination: "$vs_default_cafe_splits_1",
+		},
+	}
+
+	cfgParams := ConfigParams{}
+
+	result := generateSplitRouteConfig(route, upstreamNamer, map[string]conf_v1alpha1.Upstream{}, nil, variableNamer, index, &cfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateSplitRouteConfig() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateSplitRouteConfigWithRewritePath(t *testing.T) {
+	route := conf_v1alpha1.Route{
+		Path:        "/coffee",
+		RewritePath: "/beans",
+		Splits: []conf_v1alpha1.Split{
+			{
+				Weight:   90,
+				Upstream: "coffee-v1",
+			},
+			{
+				Weight:      10,
+				Upstream:    "coffee-v2",
+				RewritePath: "/v2/beans",
+			},
+		},
+	}
+	virtualServer := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	variableNamer := newVariableNamer(&virtualServer)
+	index := 0
+
+	expectedLocations := []version2.Location{
+		{
+			Path:                    "@splits_0_split_0",
+			ProxyPass:               "http://vs_default_cafe_coffee-v1",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Rewrite: &version2.Rewrite{
+				Regex:       "^/coffee(.*)$",
+				Replacement: "/beans$1",
+			},
+		},
+		{
+			Path:                    "@splits_0_split_1",
+			ProxyPass:               "http://vs_default_cafe_coffee-v2",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Rewrite: &version2.Rewrite{
+				Regex:       "^/coffee(.*)$",
+				Replacement: "/v2/beans$1",
+			},
+		},
+	}
+	// the internal redirect location keeps the original URI, so that the named locations can rewrite it
+	expectedInternalRedirectLocation := version2.InternalRedirectLocation{
+		Path:        "/coffee",
+		Destination: "$vs_default_cafe_splits_0",
+	}
+
+	result := generateSplitRouteConfig(route, upstreamNamer, map[string]conf_v1alpha1.Upstream{}, nil, variableNamer, index, &ConfigParams{})
+	if !reflect.DeepEqual(result.Locations, expectedLocations) {
+		t.Errorf("generateSplitRouteConfig() returned locations \n%+v but expected \n%+v", result.Locations, expectedLocations)
+	}
+	if !reflect.DeepEqual(result.InternalRedirectLocation, expectedInternalRedirectLocation) {
+		t.Errorf("generateSplitRouteConfig() returned internal redirect location %+v but expected %+v",
+			result.InternalRedirectLocation, expectedInternalRedirectLocation)
+	}
+}
+
+func TestGenerateSplitRouteConfigWithSplitKeyAndSplitCookie(t *testing.T) {
+	maxAge := 3600
+	route := conf_v1alpha1.Route{
+		Path: "/",
 		Splits: []conf_v1alpha1.Split{
 			{
 				Weight:   90,
@@ -1028,53 +2865,259 @@ func TestGenerateSplitRouteConfig(t *testing.T) {
 				Upstream: "coffee-v2",
 			},
 		},
-	}
-	virtualServer := conf_v1alpha1.VirtualServer{
-		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      "cafe",
-			Namespace: "default",
+		SplitKey: "${remote_addr}${http_user_agent}",
+		SplitCookie: &conf_v1alpha1.SplitCookie{
+			Name:   "canary",
+			MaxAge: &maxAge,
+		},
+	}
+	virtualServer := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	variableNamer := newVariableNamer(&virtualServer)
+	index := 1
+
+	expected := splitRouteCfg{
+		SplitClient: version2.SplitClient{
+			Source:   "${remote_addr}${http_user_agent}",
+			Variable: "$vs_default_cafe_splits_1",
+			Distributions: []version2.Distribution{
+				{
+					Weight: "90%",
+					Value:  "@splits_1_split_0",
+				},
+				{
+					Weight: "10%",
+					Value:  "@splits_1_split_1",
+				},
+			},
+		},
+		Maps: []version2.Map{
+			{
+				Source:   "$cookie_canary",
+				Variable: "$vs_default_cafe_splits_1_cookie",
+				Parameters: []version2.Parameter{
+					{
+						Value:  `"0"`,
+						Result: "@splits_1_split_0",
+					},
+					{
+						Value:  `"1"`,
+						Result: "@splits_1_split_1",
+					},
+					{
+						Value:  "default",
+						Result: "$vs_default_cafe_splits_1",
+					},
+				},
+			},
+		},
+		Locations: []version2.Location{
+			{
+				Path:                    "@splits_1_split_0",
+				ProxyPass:               "http://vs_default_cafe_coffee-v1",
+				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+				ProxyPassRequestHeaders: true,
+				AddHeaders: []version2.AddHeader{
+					{
+						Header: version2.Header{Name: "Set-Cookie", Value: "canary=0; Path=/; Max-Age=3600"},
+						Always: true,
+					},
+				},
+			},
+			{
+				Path:                    "@splits_1_split_1",
+				ProxyPass:               "http://vs_default_cafe_coffee-v2",
+				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+				ProxyPassRequestHeaders: true,
+				AddHeaders: []version2.AddHeader{
+					{
+						Header: version2.Header{Name: "Set-Cookie", Value: "canary=1; Path=/; Max-Age=3600"},
+						Always: true,
+					},
+				},
+			},
+		},
+		InternalRedirectLocation: version2.InternalRedirectLocation{
+			Path:        "/",
+			Destination: "$vs_default_cafe_splits_1_cookie",
+		},
+	}
+
+	result := generateSplitRouteConfig(route, upstreamNamer, map[string]conf_v1alpha1.Upstream{}, nil, variableNamer, index, &ConfigParams{})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateSplitRouteConfig() returned \n%+v but expected \n%+v", result, expected)
+	}
+}
+
+func TestGenerateRulesRouteConfigWithRewritePath(t *testing.T) {
+	route := conf_v1alpha1.Route{
+		Path:        "/tea/",
+		RewritePath: "/",
+		Rules: &conf_v1alpha1.Rules{
+			Conditions: []conf_v1alpha1.Condition{
+				{
+					Header: "x-version",
+				},
+			},
+			Matches: []conf_v1alpha1.Match{
+				{
+					Values:      []string{"v2"},
+					Upstream:    "tea-v2",
+					RewritePath: "/v2/",
+				},
+				{
+					Values: []string{"v3"},
+					Action: &conf_v1alpha1.Action{
+						Return: &conf_v1alpha1.ActionReturn{
+							Body: "v3 is not released yet",
+						},
+					},
+				},
+			},
+			DefaultUpstream: "tea-v1",
+		},
+	}
+	virtualServer := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	variableNamer := newVariableNamer(&virtualServer)
+	index := 0
+
+	expectedLocations := []version2.Location{
+		{
+			Path:                    "@rules_0_match_0",
+			ProxyPass:               "http://vs_default_cafe_tea-v2",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Rewrite: &version2.Rewrite{
+				Regex:       "^/tea/(.*)$",
+				Replacement: "/v2/$1",
+			},
+		},
+		{
+			Path: "@rules_0_match_1",
+			Return: &version2.Return{
+				Code: 200,
+				Type: "text/plain",
+				Text: "v3 is not released yet",
+			},
+		},
+		{
+			Path:                    "@rules_0_default",
+			ProxyPass:               "http://vs_default_cafe_tea-v1",
+			ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+			ProxyPassRequestHeaders: true,
+			Rewrite: &version2.Rewrite{
+				Regex:       "^/tea/(.*)$",
+				Replacement: "/$1",
+			},
+		},
+	}
+	expectedInternalRedirectLocation := version2.InternalRedirectLocation{
+		Path:        "/tea/",
+		Destination: "$vs_default_cafe_rules_0",
+	}
+
+	result := generateRulesRouteConfig(route, upstreamNamer, map[string]conf_v1alpha1.Upstream{}, nil, variableNamer, index, &ConfigParams{})
+	if !reflect.DeepEqual(result.Locations, expectedLocations) {
+		t.Errorf("generateRulesRouteConfig() returned locations \n%+v but expected \n%+v", result.Locations, expectedLocations)
+	}
+	if !reflect.DeepEqual(result.InternalRedirectLocation, expectedInternalRedirectLocation) {
+		t.Errorf("generateRulesRouteConfig() returned internal redirect location %+v but expected %+v",
+			result.InternalRedirectLocation, expectedInternalRedirectLocation)
+	}
+}
+
+func TestGenerateLocationPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "/coffee",
+			expected: "/coffee",
+		},
+		{
+			path:     "=/coffee",
+			expected: "=/coffee",
+		},
+		{
+			path:     `~ ^/coffee/\d{3}$`,
+			expected: `~ "^/coffee/\d{3}$"`,
+		},
+		{
+			path:     `~* \.(jpg|png)$`,
+			expected: `~* "\.(jpg|png)$"`,
 		},
 	}
-	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
-	variableNamer := newVariableNamer(&virtualServer)
-	index := 1
 
-	expected := splitRouteCfg{
-		SplitClient: version2.SplitClient{
-			Source:   "$request_id",
-			Variable: "$vs_default_cafe_splits_1",
-			Distributions: []version2.Distribution{
-				{
-					Weight: "90%",
-					Value:  "@splits_1_split_0",
-				},
-				{
-					Weight: "10%",
-					Value:  "@splits_1_split_1",
-				},
+	for _, test := range tests {
+		result := generateLocationPath(test.path)
+		if result != test.expected {
+			t.Errorf("generateLocationPath(%q) returned %q but expected %q", test.path, result, test.expected)
+		}
+	}
+}
+
+func TestGenerateRewrite(t *testing.T) {
+	tests := []struct {
+		prefix      string
+		rewritePath string
+		expected    *version2.Rewrite
+	}{
+		{
+			prefix:      "/coffee",
+			rewritePath: "",
+			expected:    nil,
+		},
+		{
+			prefix:      "/coffee",
+			rewritePath: "/beans",
+			expected: &version2.Rewrite{
+				Regex:       "^/coffee(.*)$",
+				Replacement: "/beans$1",
 			},
 		},
-		Locations: []version2.Location{
-			{
-				Path:      "@splits_1_split_0",
-				ProxyPass: "http://vs_default_cafe_coffee-v1",
+		{
+			prefix:      "/coffee.v1/",
+			rewritePath: "/",
+			expected: &version2.Rewrite{
+				Regex:       `^/coffee\\.v1/(.*)$`,
+				Replacement: "/$1",
 			},
-			{
-				Path:      "@splits_1_split_1",
-				ProxyPass: "http://vs_default_cafe_coffee-v2",
+		},
+		{
+			prefix:      "=/coffee",
+			rewritePath: "/beans",
+			expected: &version2.Rewrite{
+				Regex:       "^/coffee(.*)$",
+				Replacement: "/beans$1",
 			},
 		},
-		InternalRedirectLocation: version2.InternalRedirectLocation{
-			Path:        "/",
-			Destination: "$vs_default_cafe_splits_1",
+		{
+			prefix:      `/tea"`,
+			rewritePath: "/",
+			expected: &version2.Rewrite{
+				Regex:       `^/tea\"(.*)$`,
+				Replacement: "/$1",
+			},
 		},
 	}
 
-	cfgParams := ConfigParams{}
-
-	result := generateSplitRouteConfig(route, upstreamNamer, variableNamer, index, &cfgParams)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("generateSplitRouteConfig() returned %v but expected %v", result, expected)
+	for _, test := range tests {
+		result := generateRewrite(test.prefix, test.rewritePath)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateRewrite(%q, %q) returned %+v but expected %+v", test.prefix, test.rewritePath, result, test.expected)
+		}
 	}
 }
 
@@ -1264,16 +3307,22 @@ func TestGenerateRulesRouteConfig(t *testing.T) {
 		},
 		Locations: []version2.Location{
 			{
-				Path:      "@rules_1_match_0",
-				ProxyPass: "http://vs_default_cafe_coffee-v1",
+				Path:                    "@rules_1_match_0",
+				ProxyPass:               "http://vs_default_cafe_coffee-v1",
+				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+				ProxyPassRequestHeaders: true,
 			},
 			{
-				Path:      "@rules_1_match_1",
-				ProxyPass: "http://vs_default_cafe_coffee-v2",
+				Path:                    "@rules_1_match_1",
+				ProxyPass:               "http://vs_default_cafe_coffee-v2",
+				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+				ProxyPassRequestHeaders: true,
 			},
 			{
-				Path:      "@rules_1_default",
-				ProxyPass: "http://vs_default_cafe_tea",
+				Path:                    "@rules_1_default",
+				ProxyPass:               "http://vs_default_cafe_tea",
+				ProxySetHeaders:         []version2.Header{{Name: "Host", Value: "$host"}},
+				ProxyPassRequestHeaders: true,
 			},
 		},
 		InternalRedirectLocation: version2.InternalRedirectLocation{
@@ -1284,7 +3333,7 @@ func TestGenerateRulesRouteConfig(t *testing.T) {
 
 	cfgParams := ConfigParams{}
 
-	result := generateRulesRouteConfig(route, upstreamNamer, variableNamer, index, &cfgParams)
+	result := generateRulesRouteConfig(route, upstreamNamer, map[string]conf_v1alpha1.Upstream{}, nil, variableNamer, index, &cfgParams)
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateRulesRouteConfig() returned \n%v but expected \n%v", result, expected)
 	}
@@ -1436,3 +3485,430 @@ func TestGetNameForSourceForRulesRouteMapFromCondition(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateWallarm(t *testing.T) {
+	base := &version2.Wallarm{
+		Mode:              "off",
+		ModeAllowOverride: "on",
+		Fallback:          "on",
+		ParseResponse:     "on",
+		ParseWebsocket:    "off",
+		UnpackResponse:    "on",
+		ParserDisable:     []string{},
+	}
+
+	tests := []struct {
+		wallarm  *conf_v1alpha1.Wallarm
+		expected *version2.Wallarm
+		msg      string
+	}{
+		{
+			wallarm:  nil,
+			expected: base,
+			msg:      "no wallarm",
+		},
+		{
+			wallarm: &conf_v1alpha1.Wallarm{
+				Mode:              "safe_blocking",
+				ModeAllowOverride: "strict",
+				Fallback:          "off",
+				Instance:          "5",
+				BlockPage:         "/usr/share/nginx/html/wallarm_blocked.html",
+				ParseResponse:     "off",
+				ParseWebsocket:    "on",
+				UnpackResponse:    "off",
+				ParserDisable:     []string{"base64", "xml"},
+			},
+			expected: &version2.Wallarm{
+				Mode:              "safe_blocking",
+				ModeAllowOverride: "strict",
+				Fallback:          "off",
+				Instance:          "5",
+				BlockPage:         "/usr/share/nginx/html/wallarm_blocked.html",
+				ParseResponse:     "off",
+				ParseWebsocket:    "on",
+				UnpackResponse:    "off",
+				ParserDisable:     []string{"base64", "xml"},
+			},
+			msg: "all fields",
+		},
+		{
+			wallarm: &conf_v1alpha1.Wallarm{
+				Mode: "block",
+			},
+			expected: &version2.Wallarm{
+				Mode:              "block",
+				ModeAllowOverride: "on",
+				Fallback:          "on",
+				ParseResponse:     "on",
+				ParseWebsocket:    "off",
+				UnpackResponse:    "on",
+				ParserDisable:     []string{},
+			},
+			msg: "mode only",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateWallarm(base, test.wallarm)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateWallarm() returned %+v but expected %+v for the case of %s", result, test.expected, test.msg)
+		}
+		if result == base {
+			t.Errorf("generateWallarm() returned the base Wallarm instead of a copy for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestGeneratePolicies(t *testing.T) {
+	burst := 10
+	noDelay := true
+	rejectCode := 429
+
+	policies := map[string]*conf_v1alpha1.Policy{
+		"default/allow-policy": {
+			Spec: conf_v1alpha1.PolicySpec{
+				AccessControl: &conf_v1alpha1.AccessControl{
+					Allow: []string{"127.0.0.1"},
+				},
+			},
+		},
+		"default/deny-policy": {
+			Spec: conf_v1alpha1.PolicySpec{
+				AccessControl: &conf_v1alpha1.AccessControl{
+					Deny: []string{"127.0.0.2"},
+				},
+			},
+		},
+		"default/rate-limit-policy": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "rate-limit-policy",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.PolicySpec{
+				RateLimit: &conf_v1alpha1.RateLimit{
+					Rate:       "10r/s",
+					Key:        "${binary_remote_addr}",
+					Burst:      &burst,
+					NoDelay:    &noDelay,
+					LogLevel:   "warn",
+					RejectCode: &rejectCode,
+				},
+			},
+		},
+		"default/jwt-policy": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "jwt-policy",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.PolicySpec{
+				JWTAuth: &conf_v1alpha1.JWTAuth{
+					Realm:    "My API",
+					Secret:   "jwk-secret",
+					Token:    "$http_token",
+					LoginURL: "https://login.example.com",
+				},
+			},
+		},
+		"default/jwt-policy-missing-secret": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "jwt-policy-missing-secret",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.PolicySpec{
+				JWTAuth: &conf_v1alpha1.JWTAuth{
+					Realm:  "My API",
+					Secret: "missing-secret",
+				},
+			},
+		},
+		"default/another-rate-limit-policy": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "another-rate-limit-policy",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.PolicySpec{
+				RateLimit: &conf_v1alpha1.RateLimit{
+					Rate:     "100r/m",
+					Key:      "${request_uri}",
+					ZoneSize: "20m",
+				},
+			},
+		},
+	}
+
+	vs := &conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+
+	jwtKeyFileNames := map[string]string{
+		"default/jwk-secret": "/etc/nginx/secrets/default-jwk-secret",
+	}
+
+	tests := []struct {
+		policyRefs []conf_v1alpha1.PolicyReference
+		expected   policiesCfg
+		msg        string
+	}{
+		{
+			policyRefs: nil,
+			expected:   policiesCfg{},
+			msg:        "no policies",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name:      "allow-policy",
+					Namespace: "default",
+				},
+			},
+			expected: policiesCfg{
+				Allow: []string{"127.0.0.1"},
+			},
+			msg: "explicit reference",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "deny-policy",
+				},
+			},
+			expected: policiesCfg{
+				Deny: []string{"127.0.0.2"},
+			},
+			msg: "implicit reference",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "allow-policy",
+				},
+				{
+					Name: "deny-policy",
+				},
+			},
+			expected: policiesCfg{
+				Allow: []string{"127.0.0.1"},
+			},
+			msg: "multiple access control policies",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "allow-policy",
+				},
+				{
+					Name: "missing-policy",
+				},
+			},
+			expected: policiesCfg{
+				Allow: []string{"127.0.0.1"},
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			msg: "missing policy",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "rate-limit-policy",
+				},
+				{
+					Name: "another-rate-limit-policy",
+				},
+			},
+			expected: policiesCfg{
+				LimitReq: &version2.LimitReq{
+					Zone:       "pol_rl_default_rate-limit-policy_default_cafe",
+					Burst:      10,
+					NoDelay:    true,
+					LogLevel:   "warn",
+					RejectCode: 429,
+				},
+				LimitReqZones: []version2.LimitReqZone{
+					{
+						Name: "pol_rl_default_rate-limit-policy_default_cafe",
+						Key:  "${binary_remote_addr}",
+						Size: "10m",
+						Rate: "10r/s",
+					},
+				},
+			},
+			msg: "multiple rate limit policies",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "allow-policy",
+				},
+				{
+					Name: "another-rate-limit-policy",
+				},
+			},
+			expected: policiesCfg{
+				Allow: []string{"127.0.0.1"},
+				LimitReq: &version2.LimitReq{
+					Zone: "pol_rl_default_another-rate-limit-policy_default_cafe",
+				},
+				LimitReqZones: []version2.LimitReqZone{
+					{
+						Name: "pol_rl_default_another-rate-limit-policy_default_cafe",
+						Key:  "${request_uri}",
+						Size: "20m",
+						Rate: "100r/m",
+					},
+				},
+			},
+			msg: "access control and rate limit policies",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "jwt-policy",
+				},
+			},
+			expected: policiesCfg{
+				JWTAuth: &version2.JWTAuth{
+					Secret:               "/etc/nginx/secrets/default-jwk-secret",
+					Realm:                "My API",
+					Token:                "$http_token",
+					RedirectLocationName: "@login_url_default_jwt-policy",
+				},
+				JWTRedirectLocations: []version2.JWTRedirectLocation{
+					{
+						Name:     "@login_url_default_jwt-policy",
+						LoginURL: "https://login.example.com",
+					},
+				},
+			},
+			msg: "jwt policy",
+		},
+		{
+			policyRefs: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "jwt-policy-missing-secret",
+				},
+			},
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			msg: "jwt policy with missing secret",
+		},
+	}
+
+	for _, test := range tests {
+		result := generatePolicies(test.policyRefs, policies, "default", vs, jwtKeyFileNames)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generatePolicies() returned %+v but expected %+v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestAddLimitReqZones(t *testing.T) {
+	zones := []version2.LimitReqZone{
+		{
+			Name: "pol_rl_default_rate-limit-policy_default_cafe",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+	}
+	newZones := []version2.LimitReqZone{
+		{
+			Name: "pol_rl_default_rate-limit-policy_default_cafe",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+		{
+			Name: "pol_rl_default_another-rate-limit-policy_default_cafe",
+			Key:  "${request_uri}",
+			Size: "10m",
+			Rate: "100r/m",
+		},
+	}
+
+	expected := []version2.LimitReqZone{
+		{
+			Name: "pol_rl_default_rate-limit-policy_default_cafe",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+		{
+			Name: "pol_rl_default_another-rate-limit-policy_default_cafe",
+			Key:  "${request_uri}",
+			Size: "10m",
+			Rate: "100r/m",
+		},
+	}
+
+	result := addLimitReqZones(zones, newZones)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("addLimitReqZones() returned %+v but expected %+v", result, expected)
+	}
+}
+
+func TestAddJWTRedirectLocations(t *testing.T) {
+	locations := []version2.JWTRedirectLocation{
+		{
+			Name:     "@login_url_default_jwt-policy",
+			LoginURL: "https://login.example.com",
+		},
+	}
+	newLocations := []version2.JWTRedirectLocation{
+		{
+			Name:     "@login_url_default_jwt-policy",
+			LoginURL: "https://login.example.com",
+		},
+		{
+			Name:     "@login_url_default_another-jwt-policy",
+			LoginURL: "https://login.example.com/another",
+		},
+	}
+
+	expected := []version2.JWTRedirectLocation{
+		{
+			Name:     "@login_url_default_jwt-policy",
+			LoginURL: "https://login.example.com",
+		},
+		{
+			Name:     "@login_url_default_another-jwt-policy",
+			LoginURL: "https://login.example.com/another",
+		},
+	}
+
+	result := addJWTRedirectLocations(locations, newLocations)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("addJWTRedirectLocations() returned %+v but expected %+v", result, expected)
+	}
+}
+
+func TestGetVirtualServerRouteKey(t *testing.T) {
+	tests := []struct {
+		route    string
+		expected string
+	}{
+		{
+			route:    "coffee",
+			expected: "default/coffee",
+		},
+		{
+			route:    "coffee-ns/coffee",
+			expected: "coffee-ns/coffee",
+		},
+	}
+
+	for _, test := range tests {
+		result := getVirtualServerRouteKey(test.route, "default")
+		if result != test.expected {
+			t.Errorf("getVirtualServerRouteKey(%q) returned %q but expected %q", test.route, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package configs
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// generateZoneAwareRouting returns the endpoints of an upstream that must be marked as backup servers, so that NGINX
+// prefers the endpoints from the zone of the Ingress Controller, and the load balancing method of the upstream.
+// Because NGINX doesn't support backup servers for the hash, ip_hash and random methods, the random methods are
+// replaced with their closest alternative, while zone-aware routing is ignored for the hash methods.
+// If localEndpoints is nil, the zone of the Ingress Controller is unknown and zone-aware routing is disabled.
+func generateZoneAwareRouting(upstreamName string, endpoints []string, localEndpoints map[string]bool, lbMethod string, minLocalEndpoints int) (map[string]bool, string) {
+	if localEndpoints == nil {
+		return nil, lbMethod
+	}
+
+	method, ok := generateLBMethodForBackupServers(lbMethod)
+	if !ok {
+		glog.Warningf("Zone-aware routing is ignored for the upstream %v: the load balancing method %q doesn't support backup servers", upstreamName, lbMethod)
+		return nil, lbMethod
+	}
+
+	return generateBackupEndpoints(endpoints, localEndpoints, minLocalEndpoints), method
+}
+
+// generateBackupEndpoints returns the endpoints that are not in the zone of the Ingress Controller. If the zone has fewer
+// than minLocalEndpoints endpoints, no endpoints are returned, so that the traffic is balanced across all zones.
+func generateBackupEndpoints(endpoints []string, localEndpoints map[string]bool, minLocalEndpoints int) map[string]bool {
+	backupEndpoints := make(map[string]bool)
+	for _, endp := range endpoints {
+		if !localEndpoints[endp] {
+			backupEndpoints[endp] = true
+		}
+	}
+
+	localCount := len(endpoints) - len(backupEndpoints)
+	if len(backupEndpoints) == 0 || localCount == 0 || localCount < minLocalEndpoints {
+		return nil
+	}
+
+	return backupEndpoints
+}
+
+// generateLBMethodForBackupServers returns a load balancing method that can be used with backup servers.
+// It returns false if no such method exists.
+func generateLBMethodForBackupServers(method string) (string, bool) {
+	if method == "ip_hash" || strings.HasPrefix(method, "hash") {
+		return "", false
+	}
+
+	if !strings.HasPrefix(method, "random") {
+		return method, true
+	}
+
+	// the random methods with two servers are converted to the method that picks the best of the two servers
+	switch {
+	case strings.HasSuffix(method, "least_conn"):
+		return "least_conn", true
+	case strings.Contains(method, "least_time="):
+		return "least_time " + strings.SplitN(method, "least_time=", 2)[1], true
+	}
+
+	// round-robin
+	return "", true
+}
+
+// errBackupServersNotSupported is returned when the endpoints of upstreams with zone-aware routing must be updated
+// via the NGINX Plus API, which can't configure backup servers. In that case, NGINX Plus is reloaded instead.
+var errBackupServersNotSupported = errors.New("the API doesn't support backup servers of zone-aware routing")
+
+// hasZoneAwareRoutingUpstreams checks if any upstream of a VirtualServer or its VirtualServerRoutes enables zone-aware routing.
+func hasZoneAwareRoutingUpstreams(virtualServerEx *VirtualServerEx) bool {
+	for _, u := range virtualServerEx.VirtualServer.Spec.Upstreams {
+		if u.ZoneAwareRouting {
+			return true
+		}
+	}
+
+	for _, vsr := range virtualServerEx.VirtualServerRoutes {
+		for _, u := range vsr.Spec.Upstreams {
+			if u.ZoneAwareRouting {
+				return true
+			}
+		}
+	}
+
+	return false
+}
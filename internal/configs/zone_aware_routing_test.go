@@ -0,0 +1,238 @@
+package configs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestGenerateBackupEndpoints(t *testing.T) {
+	endpoints := []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.0.3:80"}
+
+	tests := []struct {
+		localEndpoints    map[string]bool
+		minLocalEndpoints int
+		expected          map[string]bool
+		msg               string
+	}{
+		{
+			localEndpoints:    map[string]bool{"10.0.0.1:80": true},
+			minLocalEndpoints: 1,
+			expected:          map[string]bool{"10.0.0.2:80": true, "10.0.0.3:80": true},
+			msg:               "one local endpoint",
+		},
+		{
+			localEndpoints:    map[string]bool{"10.0.0.1:80": true},
+			minLocalEndpoints: 2,
+			expected:          nil,
+			msg:               "fewer local endpoints than required",
+		},
+		{
+			localEndpoints:    map[string]bool{},
+			minLocalEndpoints: 1,
+			expected:          nil,
+			msg:               "no local endpoints",
+		},
+		{
+			localEndpoints:    map[string]bool{"10.0.0.1:80": true, "10.0.0.2:80": true, "10.0.0.3:80": true},
+			minLocalEndpoints: 1,
+			expected:          nil,
+			msg:               "all endpoints are local",
+		},
+		{
+			localEndpoints:    map[string]bool{"10.0.0.4:80": true},
+			minLocalEndpoints: 1,
+			expected:          nil,
+			msg:               "local endpoint of another upstream",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateBackupEndpoints(endpoints, test.localEndpoints, test.minLocalEndpoints)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateBackupEndpoints() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateLBMethodForBackupServers(t *testing.T) {
+	tests := []struct {
+		method     string
+		expected   string
+		expectedOk bool
+	}{
+		{
+			method:     "random two least_conn",
+			expected:   "least_conn",
+			expectedOk: true,
+		},
+		{
+			method:     "random two least_time=last_byte",
+			expected:   "least_time last_byte",
+			expectedOk: true,
+		},
+		{
+			method:     "random two",
+			expected:   "",
+			expectedOk: true,
+		},
+		{
+			method:     "random",
+			expected:   "",
+			expectedOk: true,
+		},
+		{
+			method:     "",
+			expected:   "",
+			expectedOk: true,
+		},
+		{
+			method:     "least_time header inflight",
+			expected:   "least_time header inflight",
+			expectedOk: true,
+		},
+		{
+			method:     "ip_hash",
+			expected:   "",
+			expectedOk: false,
+		},
+		{
+			method:     "hash $request_uri consistent",
+			expected:   "",
+			expectedOk: false,
+		},
+	}
+
+	for _, test := range tests {
+		result, ok := generateLBMethodForBackupServers(test.method)
+		if result != test.expected || ok != test.expectedOk {
+			t.Errorf("generateLBMethodForBackupServers(%q) returned %q, %v but expected %q, %v", test.method, result, ok, test.expected, test.expectedOk)
+		}
+	}
+}
+
+func TestGenerateZoneAwareRouting(t *testing.T) {
+	endpoints := []string{"10.0.0.1:80", "10.0.0.2:80"}
+	localEndpoints := map[string]bool{"10.0.0.1:80": true}
+
+	tests := []struct {
+		localEndpoints  map[string]bool
+		lbMethod        string
+		expectedBackups map[string]bool
+		expectedMethod  string
+		msg             string
+	}{
+		{
+			localEndpoints:  localEndpoints,
+			lbMethod:        "random two least_conn",
+			expectedBackups: map[string]bool{"10.0.0.2:80": true},
+			expectedMethod:  "least_conn",
+			msg:             "compatible load balancing method",
+		},
+		{
+			localEndpoints:  localEndpoints,
+			lbMethod:        "hash $cookie_srv_id consistent",
+			expectedBackups: nil,
+			expectedMethod:  "hash $cookie_srv_id consistent",
+			msg:             "incompatible load balancing method",
+		},
+		{
+			localEndpoints:  nil,
+			lbMethod:        "random two least_conn",
+			expectedBackups: nil,
+			expectedMethod:  "random two least_conn",
+			msg:             "unknown zone",
+		},
+	}
+
+	for _, test := range tests {
+		backups, method := generateZoneAwareRouting("test-upstream", endpoints, test.localEndpoints, test.lbMethod, 1)
+		if !reflect.DeepEqual(backups, test.expectedBackups) || method != test.expectedMethod {
+			t.Errorf("generateZoneAwareRouting() returned %v, %q but expected %v, %q for the case of %s",
+				backups, method, test.expectedBackups, test.expectedMethod, test.msg)
+		}
+	}
+}
+
+func TestGenerateUpstreamWithZoneAwareRouting(t *testing.T) {
+	name := "test-upstream"
+	upstream := conf_v1alpha1.Upstream{ZoneAwareRouting: true}
+	endpoints := []string{"10.0.0.1:80", "10.0.0.2:80"}
+	localEndpoints := map[string]bool{"10.0.0.1:80": true}
+	cfgParams := NewDefaultConfigParams()
+
+	expected := version2.Upstream{
+		Name: "test-upstream",
+		Servers: []version2.UpstreamServer{
+			{
+				Address:     "10.0.0.1:80",
+				MaxFails:    1,
+				FailTimeout: "10s",
+			},
+			{
+				Address:     "10.0.0.2:80",
+				MaxFails:    1,
+				FailTimeout: "10s",
+				Backup:      true,
+			},
+		},
+		LBMethod: "least_conn",
+	}
+
+	result := generateUpstream(name, upstream, endpoints, localEndpoints, false, cfgParams)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateUpstream() returned %+v but expected %+v", result, expected)
+	}
+}
+
+func TestCreateUpstreamWithZoneAwareRouting(t *testing.T) {
+	backend := &networking.IngressBackend{
+		ServiceName: "coffee-svc",
+		ServicePort: intstr.FromInt(80),
+	}
+	ingEx := &IngressEx{
+		Ingress: &networking.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe-ingress",
+				Namespace: "default",
+			},
+		},
+		Endpoints: map[string][]string{
+			"coffee-svc80": {"10.0.0.1:80", "10.0.0.2:80"},
+		},
+		LocalZoneEndpoints: map[string]bool{"10.0.0.2:80": true},
+	}
+	cfgParams := NewDefaultConfigParams()
+	cfgParams.ZoneAwareRouting = true
+
+	expected := version1.Upstream{
+		Name: "default-cafe-ingress-coffee-svc-80",
+		UpstreamServers: []version1.UpstreamServer{
+			{
+				Address:     "10.0.0.1",
+				Port:        "80",
+				MaxFails:    1,
+				FailTimeout: "10s",
+				Backup:      true,
+			},
+			{
+				Address:     "10.0.0.2",
+				Port:        "80",
+				MaxFails:    1,
+				FailTimeout: "10s",
+			},
+		},
+		LBMethod: "least_conn",
+	}
+
+	result := createUpstream(ingEx, expected.Name, backend, "", cfgParams, false, false)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("createUpstream() returned %+v but expected %+v", result, expected)
+	}
+}
@@ -27,12 +27,12 @@ import (
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
-	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
 	core_v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
@@ -42,14 +42,19 @@ import (
 
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	discovery_v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/discovery/v1beta1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
+	conf_scheme "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
 	api_v1 "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	ingressClassKey = "kubernetes.io/ingress.class"
+	// ingressControllerName is the value of the controller field of the IngressClass resources
+	// handled by the Ingress Controller.
+	ingressControllerName = "nginx.org/ingress-controller"
 )
 
 // LoadBalancerController watches Kubernetes API and
@@ -58,19 +63,31 @@ type LoadBalancerController struct {
 	client                       kubernetes.Interface
 	confClient                   k8s_nginx.Interface
 	ingressController            cache.Controller
+	ingressClassController       cache.Controller
 	svcController                cache.Controller
 	endpointController           cache.Controller
+	endpointSliceController      cache.Controller
 	configMapController          cache.Controller
 	secretController             cache.Controller
 	virtualServerController      cache.Controller
 	virtualServerRouteController cache.Controller
+	transportServerController    cache.Controller
+	policyController             cache.Controller
+	podController                cache.Controller
+	nodeController               cache.Controller
 	ingressLister                storeToIngressLister
+	ingressClassLister           cache.Store
 	svcLister                    cache.Store
 	endpointLister               storeToEndpointLister
+	endpointSliceLister          storeToEndpointSliceLister
 	configMapLister              storeToConfigMapLister
 	secretLister                 storeToSecretLister
 	virtualServerLister          cache.Store
 	virtualServerRouteLister     cache.Store
+	transportServerLister        cache.Store
+	policyLister                 cache.Store
+	podLister                    cache.Store
+	nodeLister                   cache.Store
 	syncQueue                    *taskQueue
 	ctx                          context.Context
 	cancel                       context.CancelFunc
@@ -81,6 +98,12 @@ type LoadBalancerController struct {
 	defaultServerSecret          string
 	ingressClass                 string
 	useIngressClassOnly          bool
+	useExtensionsIngressAPI      bool
+	isIngressClassAPIAvailable   bool
+	useEndpointSlices            bool
+	controllerZone               string
+	nginxConfigMapNamespace      string
+	nginxConfigMapName           string
 	statusUpdater                *statusUpdater
 	leaderElector                *leaderelection.LeaderElector
 	reportIngressStatus          bool
@@ -91,6 +114,8 @@ type LoadBalancerController struct {
 	controllerNamespace          string
 	wildcardTLSSecret            string
 	areCustomResourcesEnabled    bool
+	isTLSPassthroughEnabled      bool
+	areAnnotationsStrict         bool
 	wallarmTarantoolServiceName  string
 	metricsCollector             collectors.ControllerCollector
 }
@@ -108,6 +133,10 @@ type NewLoadBalancerControllerInput struct {
 	IsNginxPlus               bool
 	IngressClass              string
 	UseIngressClassOnly       bool
+	UseExtensionsIngressAPI   bool
+	IsIngressClassAPIAvailable bool
+	UseEndpointSlices         bool
+	ControllerZone            string
 	ExternalServiceName       string
 	ControllerNamespace       string
 	ReportIngressStatus       bool
@@ -116,6 +145,8 @@ type NewLoadBalancerControllerInput struct {
 	WildcardTLSSecret         string
 	ConfigMaps                string
 	AreCustomResourcesEnabled bool
+	IsTLSPassthroughEnabled   bool
+	AreAnnotationsStrict      bool
 	WallarmTarantoolServiceName string
 	MetricsCollector          collectors.ControllerCollector
 }
@@ -130,6 +161,10 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		isNginxPlus:               input.IsNginxPlus,
 		ingressClass:              input.IngressClass,
 		useIngressClassOnly:       input.UseIngressClassOnly,
+		useExtensionsIngressAPI:   input.UseExtensionsIngressAPI,
+		isIngressClassAPIAvailable: input.IsIngressClassAPIAvailable,
+		useEndpointSlices:         input.UseEndpointSlices,
+		controllerZone:            input.ControllerZone,
 		reportIngressStatus:       input.ReportIngressStatus,
 		isLeaderElectionEnabled:   input.IsLeaderElectionEnabled,
 		leaderElectionLockName:    input.LeaderElectionLockName,
@@ -138,6 +173,8 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		controllerNamespace:       input.ControllerNamespace,
 		wildcardTLSSecret:         input.WildcardTLSSecret,
 		areCustomResourcesEnabled: input.AreCustomResourcesEnabled,
+		isTLSPassthroughEnabled:   input.IsTLSPassthroughEnabled,
+		areAnnotationsStrict:      input.AreAnnotationsStrict,
 		wallarmTarantoolServiceName: input.WallarmTarantoolServiceName,
 		metricsCollector:          input.MetricsCollector,
 	}
@@ -147,7 +184,15 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	eventBroadcaster.StartRecordingToSink(&core_v1.EventSinkImpl{
 		Interface: core_v1.New(input.KubeClient.CoreV1().RESTClient()).Events(""),
 	})
-	lbc.recorder = eventBroadcaster.NewRecorder(scheme.Scheme,
+	// The types of the Ingress resources conflict with the networking.k8s.io types of the client-go scheme,
+	// so the events are recorded with a separate scheme.
+	eventScheme := runtime.NewScheme()
+	schemeBuilder := runtime.NewSchemeBuilder(api_v1.AddToScheme, conf_scheme.AddToScheme)
+	err := schemeBuilder.AddToScheme(eventScheme)
+	if err != nil {
+		glog.Fatalf("Failed to create the scheme for events: %v", err)
+	}
+	lbc.recorder = eventBroadcaster.NewRecorder(eventScheme,
 		api_v1.EventSource{Component: "nginx-ingress-controller"})
 
 	lbc.syncQueue = newTaskQueue(lbc.sync)
@@ -155,22 +200,40 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	glog.V(3).Infof("Nginx Ingress Controller has class: %v", input.IngressClass)
 
 	lbc.statusUpdater = &statusUpdater{
-		client:              input.KubeClient,
-		namespace:           input.ControllerNamespace,
-		externalServiceName: input.ExternalServiceName,
-		ingLister:           &lbc.ingressLister,
-		keyFunc:             keyFunc,
+		client:                  input.KubeClient,
+		confClient:              input.ConfClient,
+		namespace:               input.ControllerNamespace,
+		externalServiceName:     input.ExternalServiceName,
+		ingLister:               &lbc.ingressLister,
+		keyFunc:                 keyFunc,
+		useExtensionsIngressAPI: input.UseExtensionsIngressAPI,
 	}
 
 	// create handlers for resources we care about
 	lbc.addSecretHandler(createSecretHandlers(lbc))
 	lbc.addIngressHandler(createIngressHandlers(lbc))
+	if lbc.isIngressClassAPIAvailable {
+		lbc.addIngressClassHandler(createIngressClassHandlers(lbc))
+	}
 	lbc.addServiceHandler(createServiceHandlers(lbc))
-	lbc.addEndpointHandler(createEndpointHandlers(lbc))
+	if lbc.useEndpointSlices {
+		lbc.addEndpointSliceHandler(createEndpointSliceHandlers(lbc))
+	} else {
+		lbc.addEndpointHandler(createEndpointHandlers(lbc))
+	}
+	if lbc.controllerZone != "" {
+		lbc.addNodeHandler(cache.ResourceEventHandlerFuncs{})
+	}
 
 	if lbc.areCustomResourcesEnabled {
 		lbc.addVirtualServerHandler(createVirtualServerHandlers(lbc))
 		lbc.addVirtualServerRouteHandler(createVirtualServerRouteHandlers(lbc))
+		lbc.addTransportServerHandler(createTransportServerHandlers(lbc))
+		lbc.addPolicyHandler(createPolicyHandlers(lbc))
+		lbc.addPodHandler(createPodHandlers(lbc))
+
+		lbc.statusUpdater.virtualServerLister = lbc.virtualServerLister
+		lbc.statusUpdater.virtualServerRouteLister = lbc.virtualServerRouteLister
 	}
 
 	if input.ConfigMaps != "" {
@@ -179,11 +242,13 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 			glog.Warning(err)
 		} else {
 			lbc.watchNginxConfigMaps = true
+			lbc.nginxConfigMapNamespace = nginxConfigMapsNS
+			lbc.nginxConfigMapName = nginxConfigMapsName
 			lbc.addConfigMapHandler(createConfigMapHandlers(lbc, nginxConfigMapsName), nginxConfigMapsNS)
 		}
 	}
 
-	if input.ReportIngressStatus && input.IsLeaderElectionEnabled {
+	if input.IsLeaderElectionEnabled && (input.ReportIngressStatus || input.AreCustomResourcesEnabled) {
 		lbc.addLeaderHandler(createLeaderHandler(lbc))
 	}
 
@@ -191,7 +256,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 }
 
 // UpdateManagedAndMergeableIngresses invokes the UpdateManagedAndMergeableIngresses method on the Status Updater
-func (lbc *LoadBalancerController) UpdateManagedAndMergeableIngresses(ingresses []v1beta1.Ingress, mergeableIngresses map[string]*configs.MergeableIngresses) error {
+func (lbc *LoadBalancerController) UpdateManagedAndMergeableIngresses(ingresses []networking.Ingress, mergeableIngresses map[string]*configs.MergeableIngresses) error {
 	return lbc.statusUpdater.UpdateManagedAndMergeableIngresses(ingresses, mergeableIngresses)
 }
 
@@ -239,13 +304,34 @@ func (lbc *LoadBalancerController) addServiceHandler(handlers cache.ResourceEven
 
 // addIngressHandler adds the handler for ingresses to the controller
 func (lbc *LoadBalancerController) addIngressHandler(handlers cache.ResourceEventHandlerFuncs) {
-	lbc.ingressLister.Store, lbc.ingressController = cache.NewInformer(
-		cache.NewListWatchFromClient(
-			lbc.client.ExtensionsV1beta1().RESTClient(),
+	var listWatch cache.ListerWatcher
+	if lbc.useExtensionsIngressAPI {
+		listWatch = newExtensionsIngressListWatch(lbc.client, lbc.namespace)
+	} else {
+		listWatch = cache.NewListWatchFromClient(
+			lbc.confClient.NetworkingV1beta1().RESTClient(),
 			"ingresses",
 			lbc.namespace,
+			fields.Everything())
+	}
+
+	lbc.ingressLister.Store, lbc.ingressController = cache.NewInformer(
+		listWatch,
+		&networking.Ingress{},
+		lbc.resync,
+		handlers,
+	)
+}
+
+// addIngressClassHandler adds the handler for ingress classes to the controller
+func (lbc *LoadBalancerController) addIngressClassHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.ingressClassLister, lbc.ingressClassController = cache.NewInformer(
+		cache.NewListWatchFromClient(
+			lbc.confClient.NetworkingV1beta1().RESTClient(),
+			"ingressclasses",
+			"",
 			fields.Everything()),
-		&extensions.Ingress{},
+		&networking.IngressClass{},
 		lbc.resync,
 		handlers,
 	)
@@ -265,6 +351,22 @@ func (lbc *LoadBalancerController) addEndpointHandler(handlers cache.ResourceEve
 	)
 }
 
+// addEndpointSliceHandler adds the handler for endpoint slices to the controller. The endpoint slices are indexed
+// by their service, so that the endpoints of a service can be aggregated from its slices.
+func (lbc *LoadBalancerController) addEndpointSliceHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.endpointSliceLister.Indexer, lbc.endpointSliceController = cache.NewIndexerInformer(
+		cache.NewListWatchFromClient(
+			lbc.confClient.DiscoveryV1beta1().RESTClient(),
+			"endpointslices",
+			lbc.namespace,
+			fields.Everything()),
+		&discovery_v1beta1.EndpointSlice{},
+		lbc.resync,
+		handlers,
+		cache.Indexers{endpointSliceServiceIndex: endpointSliceServiceIndexFunc},
+	)
+}
+
 // addConfigMapHandler adds the handler for config maps to the controller
 func (lbc *LoadBalancerController) addConfigMapHandler(handlers cache.ResourceEventHandlerFuncs, namespace string) {
 	lbc.configMapLister.Store, lbc.configMapController = cache.NewInformer(
@@ -305,6 +407,47 @@ func (lbc *LoadBalancerController) addVirtualServerRouteHandler(handlers cache.R
 	)
 }
 
+func (lbc *LoadBalancerController) addTransportServerHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.transportServerLister, lbc.transportServerController = cache.NewInformer(
+		cache.NewListWatchFromClient(
+			lbc.confClient.K8sV1alpha1().RESTClient(),
+			"transportservers",
+			lbc.namespace,
+			fields.Everything()),
+		&conf_v1alpha1.TransportServer{},
+		lbc.resync,
+		handlers,
+	)
+}
+
+func (lbc *LoadBalancerController) addPolicyHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.policyLister, lbc.policyController = cache.NewInformer(
+		cache.NewListWatchFromClient(
+			lbc.confClient.K8sV1alpha1().RESTClient(),
+			"policies",
+			lbc.namespace,
+			fields.Everything()),
+		&conf_v1alpha1.Policy{},
+		lbc.resync,
+		handlers,
+	)
+}
+
+// addPodHandler adds the handler for pods to the controller. The pods are used to resolve the subselectors
+// of VirtualServer and VirtualServerRoute upstreams. Note that the informer caches all pods of the watched namespaces.
+func (lbc *LoadBalancerController) addPodHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.podLister, lbc.podController = cache.NewInformer(
+		cache.NewListWatchFromClient(
+			lbc.client.CoreV1().RESTClient(),
+			"pods",
+			lbc.namespace,
+			fields.Everything()),
+		&api_v1.Pod{},
+		lbc.resync,
+		handlers,
+	)
+}
+
 // Run starts the loadbalancer controller
 func (lbc *LoadBalancerController) Run() {
 	lbc.ctx, lbc.cancel = context.WithCancel(context.Background())
@@ -313,15 +456,28 @@ func (lbc *LoadBalancerController) Run() {
 		go lbc.leaderElector.Run(lbc.ctx)
 	}
 	go lbc.svcController.Run(lbc.ctx.Done())
-	go lbc.endpointController.Run(lbc.ctx.Done())
+	if lbc.useEndpointSlices {
+		go lbc.endpointSliceController.Run(lbc.ctx.Done())
+	} else {
+		go lbc.endpointController.Run(lbc.ctx.Done())
+	}
+	if lbc.controllerZone != "" {
+		go lbc.nodeController.Run(lbc.ctx.Done())
+	}
 	go lbc.secretController.Run(lbc.ctx.Done())
 	if lbc.watchNginxConfigMaps {
 		go lbc.configMapController.Run(lbc.ctx.Done())
 	}
+	if lbc.isIngressClassAPIAvailable {
+		go lbc.ingressClassController.Run(lbc.ctx.Done())
+	}
 	go lbc.ingressController.Run(lbc.ctx.Done())
 	if lbc.areCustomResourcesEnabled {
 		go lbc.virtualServerController.Run(lbc.ctx.Done())
 		go lbc.virtualServerRouteController.Run(lbc.ctx.Done())
+		go lbc.transportServerController.Run(lbc.ctx.Done())
+		go lbc.policyController.Run(lbc.ctx.Done())
+		go lbc.podController.Run(lbc.ctx.Done())
 	}
 	go lbc.syncQueue.Run(time.Second, lbc.ctx.Done())
 	<-lbc.ctx.Done()
@@ -338,7 +494,7 @@ func (lbc *LoadBalancerController) syncEndpoint(task task) {
 	key := task.Key
 	glog.V(3).Infof("Syncing endpoints %v", key)
 
-	obj, endpExists, err := lbc.endpointLister.GetByKey(key)
+	obj, endpExists, err := lbc.getEndpointsByKey(key)
 	if err != nil {
 		glog.V(3).Infof("Error syncing endpoints %v: %v", key, err)
 		lbc.syncQueue.Requeue(task, err)
@@ -418,6 +574,17 @@ func (lbc *LoadBalancerController) syncEndpoint(task task) {
 					glog.Errorf("Error updating endpoints for %v: %v", virtualServersExes, err)
 				}
 			}
+
+			transportServers := lbc.getTransportServersForEndpoints(obj.(*api_v1.Endpoints))
+			transportServerExes := lbc.transportServersToTransportServerExes(transportServers)
+
+			if len(transportServerExes) > 0 {
+				glog.V(3).Infof("Updating endpoints for %v", transportServerExes)
+				err = lbc.configurator.UpdateEndpointsForTransportServers(transportServerExes)
+				if err != nil {
+					glog.Errorf("Error updating endpoints for %v: %v", transportServerExes, err)
+				}
+			}
 		}
 	}
 }
@@ -435,7 +602,11 @@ func (lbc *LoadBalancerController) syncConfig(task task) {
 
 	if configExists {
 		cfgm := obj.(*api_v1.ConfigMap)
-		cfgParams = configs.ParseConfigMap(cfgm, lbc.isNginxPlus)
+		var cfgErrs field.ErrorList
+		cfgParams, cfgErrs = configs.ParseConfigMap(cfgm, lbc.isNginxPlus)
+		for _, err := range cfgErrs {
+			lbc.recorder.Eventf(cfgm, api_v1.EventTypeWarning, "InvalidKey", "%v has an invalid key, the default is used instead: %v", key, err)
+		}
 
 		lbc.statusUpdater.SaveStatusFromExternalStatus(cfgm.Data["external-status-address"])
 	}
@@ -449,6 +620,7 @@ func (lbc *LoadBalancerController) syncConfig(task task) {
 			glog.V(3).Infof("error updating status on ConfigMap change: %v", err)
 		}
 	}
+	lbc.updateCustomResourcesExternalEndpoints()
 
 	var virtualServerExes []*configs.VirtualServerEx
 	if lbc.areCustomResourcesEnabled {
@@ -494,9 +666,9 @@ func (lbc *LoadBalancerController) syncConfig(task task) {
 }
 
 // GetManagedIngresses gets Ingress resources that the IC is currently responsible for
-func (lbc *LoadBalancerController) GetManagedIngresses() ([]extensions.Ingress, map[string]*configs.MergeableIngresses) {
+func (lbc *LoadBalancerController) GetManagedIngresses() ([]networking.Ingress, map[string]*configs.MergeableIngresses) {
 	mergeableIngresses := make(map[string]*configs.MergeableIngresses)
-	var managedIngresses []extensions.Ingress
+	var managedIngresses []networking.Ingress
 	ings, _ := lbc.ingressLister.List()
 	for i := range ings.Items {
 		ing := ings.Items[i]
@@ -530,7 +702,7 @@ func (lbc *LoadBalancerController) GetManagedIngresses() ([]extensions.Ingress,
 	return managedIngresses, mergeableIngresses
 }
 
-func (lbc *LoadBalancerController) ingressesToIngressExes(ings []extensions.Ingress) []*configs.IngressEx {
+func (lbc *LoadBalancerController) ingressesToIngressExes(ings []networking.Ingress) []*configs.IngressEx {
 	var ingExes []*configs.IngressEx
 	for i := range ings {
 		ingEx, err := lbc.createIngress(&ings[i])
@@ -546,13 +718,23 @@ func (lbc *LoadBalancerController) virtualServersToVirtualServerExes(virtualServ
 	var virtualServersExes []*configs.VirtualServerEx
 
 	for _, vs := range virtualServers {
-		vsEx, _ := lbc.createVirtualServer(vs) // ignoring VirtualServerRouteErrors
+		vsEx, _, _ := lbc.createVirtualServer(vs) // ignoring VirtualServerRouteErrors and policyErrors
 		virtualServersExes = append(virtualServersExes, vsEx)
 	}
 
 	return virtualServersExes
 }
 
+func (lbc *LoadBalancerController) transportServersToTransportServerExes(transportServers []*conf_v1alpha1.TransportServer) []*configs.TransportServerEx {
+	var transportServerExes []*configs.TransportServerEx
+
+	for _, ts := range transportServers {
+		transportServerExes = append(transportServerExes, lbc.createTransportServer(ts))
+	}
+
+	return transportServerExes
+}
+
 func (lbc *LoadBalancerController) sync(task task) {
 	glog.V(3).Infof("Syncing %v", task.Key)
 
@@ -579,6 +761,10 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.syncVirtualServer(task)
 	case virtualServerRoute:
 		lbc.syncVirtualServerRoute(task)
+	case transportserver:
+		lbc.syncTransportServer(task)
+	case policy:
+		lbc.syncPolicy(task)
 	}
 }
 
@@ -605,41 +791,108 @@ func (lbc *LoadBalancerController) syncVirtualServer(task task) {
 
 	vs := obj.(*conf_v1alpha1.VirtualServer)
 
-	validationErr := validation.ValidateVirtualServer(vs)
+	validationErr := validation.ValidateVirtualServer(vs, lbc.isNginxPlus)
 	if validationErr != nil {
 		err := lbc.configurator.DeleteVirtualServer(key)
 		if err != nil {
 			glog.Errorf("Error when deleting configuration for %v: %v", key, err)
 		}
-		lbc.recorder.Eventf(vs, api_v1.EventTypeWarning, "Rejected", "VirtualServer %v is invalid and was rejected: %v", key, validationErr)
+		msg := fmt.Sprintf("VirtualServer %v is invalid and was rejected: %v", key, validationErr)
+		lbc.recorder.Event(vs, api_v1.EventTypeWarning, "Rejected", msg)
+		lbc.updateVirtualServerStatus(vs, conf_v1alpha1.StateInvalid, "Rejected", msg)
 		// TO-DO: emit events for referenced VirtualServerRoutes
 		return
 	}
 
-	vsEx, vsrErrors := lbc.createVirtualServer(vs)
+	vsEx, vsrErrors, policyErrors := lbc.createVirtualServer(vs)
 
 	for _, vsrError := range vsrErrors {
 		lbc.recorder.Eventf(vs, api_v1.EventTypeWarning, "IgnoredVirtualServerRoute", "Ignored VirtualServerRoute %v: %v", vsrError.VirtualServerRouteNsName, vsrError.Error)
 		if vsrError.VirtualServerRoute != nil {
-			lbc.recorder.Eventf(vsrError.VirtualServerRoute, api_v1.EventTypeWarning, "Ignored", "Ignored by VirtualServer %v/%v: %v", vs.Namespace, vs.Name, vsrError.Error)
+			msg := fmt.Sprintf("Ignored by VirtualServer %v/%v: %v", vs.Namespace, vs.Name, vsrError.Error)
+			lbc.recorder.Event(vsrError.VirtualServerRoute, api_v1.EventTypeWarning, "Ignored", msg)
+			lbc.updateVirtualServerRouteStatus(vsrError.VirtualServerRoute, conf_v1alpha1.StateInvalid, "Ignored", msg)
 		}
 	}
 
+	for _, polError := range policyErrors {
+		lbc.recorder.Eventf(vs, api_v1.EventTypeWarning, "MissingOrInvalidPolicy", "Policy %v is missing or invalid, the requests that are subject to it are rejected with 500: %v",
+			polError.PolicyNsName, polError.Error)
+	}
+
+	grpcUpstreams := lbc.configurator.GetUpstreamsWithIgnoredGRPCType(vsEx)
+	for _, u := range grpcUpstreams {
+		lbc.recorder.Eventf(vs, api_v1.EventTypeWarning, "IgnoredUpstreamType", "Upstream %v: type grpc requires TLS and HTTP/2, the upstream is configured as http", u)
+	}
+
 	addErr := lbc.configurator.AddOrUpdateVirtualServer(vsEx)
 
 	eventTitle := "AddedOrUpdated"
 	eventType := api_v1.EventTypeNormal
 	eventWarningMessage := ""
+	state := conf_v1alpha1.StateValid
 
 	if addErr != nil {
 		eventTitle = "AddedOrUpdatedWithError"
 		eventType = api_v1.EventTypeWarning
 		eventWarningMessage = fmt.Sprintf("but was not applied: %v", addErr)
+		state = conf_v1alpha1.StateInvalid
+	}
+
+	msg := fmt.Sprintf("Configuration for %v was added or updated", key)
+	if eventWarningMessage != "" {
+		msg += " " + eventWarningMessage
+	}
+	lbc.recorder.Event(vs, eventType, eventTitle, msg)
+
+	if addErr == nil && (len(vsrErrors) > 0 || len(policyErrors) > 0 || len(grpcUpstreams) > 0) {
+		var warnings []string
+		if len(vsrErrors) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d ignored VirtualServerRoute(s)", len(vsrErrors)))
+		}
+		if len(policyErrors) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d missing or invalid Policy(ies)", len(policyErrors)))
+		}
+		if len(grpcUpstreams) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d grpc upstream(s) configured as http", len(grpcUpstreams)))
+		}
+		lbc.updateVirtualServerStatus(vs, conf_v1alpha1.StateWarning, "AddedOrUpdatedWithWarning",
+			fmt.Sprintf("Configuration for %v was added or updated with %s", key, strings.Join(warnings, " and ")))
+	} else {
+		lbc.updateVirtualServerStatus(vs, state, eventTitle, msg)
 	}
 
-	lbc.recorder.Eventf(vs, eventType, eventTitle, "Configuration for %v was added or updated %s", key, eventWarningMessage)
 	for _, vsr := range vsEx.VirtualServerRoutes {
-		lbc.recorder.Eventf(vsr, eventType, eventTitle, "Configuration for %v/%v was added or updated %s", vsr.Namespace, vsr.Name, eventWarningMessage)
+		vsrMsg := fmt.Sprintf("Configuration for %v/%v was added or updated", vsr.Namespace, vsr.Name)
+		if eventWarningMessage != "" {
+			vsrMsg += " " + eventWarningMessage
+		}
+		lbc.recorder.Event(vsr, eventType, eventTitle, vsrMsg)
+		lbc.updateVirtualServerRouteStatus(vsr, state, eventTitle, vsrMsg)
+	}
+}
+
+// updateVirtualServerStatus updates the status of a VirtualServer if reporting the status is enabled.
+func (lbc *LoadBalancerController) updateVirtualServerStatus(vs *conf_v1alpha1.VirtualServer, state string, reason string, message string) {
+	if !lbc.reportCustomResourceStatusEnabled() {
+		return
+	}
+
+	err := lbc.statusUpdater.UpdateVirtualServerStatus(vs, state, reason, message)
+	if err != nil {
+		glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", vs.Namespace, vs.Name, err)
+	}
+}
+
+// updateVirtualServerRouteStatus updates the status of a VirtualServerRoute if reporting the status is enabled.
+func (lbc *LoadBalancerController) updateVirtualServerRouteStatus(vsr *conf_v1alpha1.VirtualServerRoute, state string, reason string, message string) {
+	if !lbc.reportCustomResourceStatusEnabled() {
+		return
+	}
+
+	err := lbc.statusUpdater.UpdateVirtualServerRouteStatus(vsr, state, reason, message)
+	if err != nil {
+		glog.Errorf("Error when updating the status for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 	}
 }
 
@@ -663,17 +916,109 @@ func (lbc *LoadBalancerController) syncVirtualServerRoute(task task) {
 
 	vsr := obj.(*conf_v1alpha1.VirtualServerRoute)
 
-	validationErr := validation.ValidateVirtualServerRoute(vsr)
+	validationErr := validation.ValidateVirtualServerRoute(vsr, lbc.isNginxPlus)
 	if validationErr != nil {
-		lbc.recorder.Eventf(vsr, api_v1.EventTypeWarning, "Rejected", "VirtualServerRoute %s is invalid and was rejected: %v", key, validationErr)
+		msg := fmt.Sprintf("VirtualServerRoute %s is invalid and was rejected: %v", key, validationErr)
+		lbc.recorder.Event(vsr, api_v1.EventTypeWarning, "Rejected", msg)
+		lbc.updateVirtualServerRouteStatus(vsr, conf_v1alpha1.StateInvalid, "Rejected", msg)
 	}
 
 	vsCount := lbc.enqueueVirtualServersForVirtualServerRouteKey(key)
 
 	if vsCount == 0 {
-		lbc.recorder.Eventf(vsr, api_v1.EventTypeWarning, "NoVirtualServersFound", "No VirtualServer references VirtualServerRoute %s", key)
+		msg := fmt.Sprintf("No VirtualServer references VirtualServerRoute %s", key)
+		lbc.recorder.Event(vsr, api_v1.EventTypeWarning, "NoVirtualServersFound", msg)
+		if validationErr == nil {
+			lbc.updateVirtualServerRouteStatus(vsr, conf_v1alpha1.StateWarning, "NoVirtualServersFound", msg)
+		}
+	}
+
+}
+
+func (lbc *LoadBalancerController) syncPolicy(task task) {
+	key := task.Key
+
+	obj, polExists, err := lbc.policyLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	glog.V(2).Infof("Adding, Updating or Deleting Policy: %v\n", key)
+
+	if polExists {
+		pol := obj.(*conf_v1alpha1.Policy)
+		err := validation.ValidatePolicy(pol, lbc.isNginxPlus)
+		if err != nil {
+			lbc.recorder.Eventf(pol, api_v1.EventTypeWarning, "Rejected", "Policy %v is invalid and was rejected: %v", key, err)
+		} else {
+			lbc.recorder.Eventf(pol, api_v1.EventTypeNormal, "AddedOrUpdated", "Policy %v was added or updated", key)
+		}
+	}
+
+	// the VirtualServers that reference the Policy need to be updated even if the Policy was deleted or is invalid,
+	// so that the requests that are subject to the Policy are rejected
+	lbc.enqueueVirtualServersForPolicyKey(key)
+}
+
+func (lbc *LoadBalancerController) syncTransportServer(task task) {
+	key := task.Key
+	obj, tsExists, err := lbc.transportServerLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	if !tsExists {
+		glog.V(2).Infof("Deleting TransportServer: %v\n", key)
+
+		err := lbc.configurator.DeleteTransportServer(key)
+		if err != nil {
+			glog.Errorf("Error when deleting configuration for %v: %v", key, err)
+		}
+		return
+	}
+
+	glog.V(2).Infof("Adding or Updating TransportServer: %v\n", key)
+
+	ts := obj.(*conf_v1alpha1.TransportServer)
+
+	validationErr := validation.ValidateTransportServer(ts, lbc.isNginxPlus, lbc.isTLSPassthroughEnabled)
+	if validationErr != nil {
+		err := lbc.configurator.DeleteTransportServer(key)
+		if err != nil {
+			glog.Errorf("Error when deleting configuration for %v: %v", key, err)
+		}
+		lbc.recorder.Eventf(ts, api_v1.EventTypeWarning, "Rejected", "TransportServer %v is invalid and was rejected: %v", key, validationErr)
+		return
+	}
+
+	listenerKey := getListenerKeyForTransportServer(ts)
+	holder := findTransportServerHoldingListener(lbc.getTransportServers(), listenerKey)
+	if holder != nil && !isSameTransportServer(holder, ts) {
+		err := lbc.configurator.DeleteTransportServer(key)
+		if err != nil {
+			glog.Errorf("Error when deleting configuration for %v: %v", key, err)
+		}
+		lbc.recorder.Eventf(ts, api_v1.EventTypeWarning, "Rejected", "TransportServer %v was rejected: %v", key, getListenerConflictMessage(ts, holder))
+		return
+	}
+
+	tsEx := lbc.createTransportServer(ts)
+
+	addErr := lbc.configurator.AddOrUpdateTransportServer(tsEx)
+
+	eventTitle := "AddedOrUpdated"
+	eventType := api_v1.EventTypeNormal
+	eventWarningMessage := ""
+
+	if addErr != nil {
+		eventTitle = "AddedOrUpdatedWithError"
+		eventType = api_v1.EventTypeWarning
+		eventWarningMessage = fmt.Sprintf("but was not applied: %v", addErr)
 	}
 
+	lbc.recorder.Eventf(ts, eventType, eventTitle, "Configuration for %v was added or updated %s", key, eventWarningMessage)
 }
 
 func (lbc *LoadBalancerController) syncIngMinion(task task) {
@@ -690,7 +1035,9 @@ func (lbc *LoadBalancerController) syncIngMinion(task task) {
 	}
 	glog.V(2).Infof("Adding or Updating Minion: %v\n", key)
 
-	minion := obj.(*extensions.Ingress)
+	minion := obj.(*networking.Ingress)
+
+	lbc.recordAnnotationErrors(minion, key)
 
 	master, err := lbc.FindMasterForMinion(minion)
 	if err != nil {
@@ -700,7 +1047,11 @@ func (lbc *LoadBalancerController) syncIngMinion(task task) {
 
 	_, err = lbc.createIngress(minion)
 	if err != nil {
-		lbc.syncQueue.RequeueAfter(task, err, 5*time.Second)
+		if isInvalidAnnotationsError(err) {
+			lbc.recorder.Eventf(minion, api_v1.EventTypeWarning, "Rejected", "%v was rejected: %v", key, err)
+		} else {
+			lbc.syncQueue.RequeueAfter(task, err, 5*time.Second)
+		}
 		if !lbc.configurator.HasMinion(master, minion) {
 			return
 		}
@@ -724,16 +1075,30 @@ func (lbc *LoadBalancerController) syncIng(task task) {
 		if err != nil {
 			glog.Errorf("Error when deleting configuration for %v: %v", key, err)
 		}
+	} else if !lbc.IsNginxIngress(ing) {
+		// the Ingress is no longer handled by the ingress controller, for example, because its IngressClass was changed
+		if lbc.configurator.HasIngress(ing) {
+			glog.V(2).Infof("Deleting Ingress: %v\n", key)
+
+			err := lbc.configurator.DeleteIngress(key)
+			if err != nil {
+				glog.Errorf("Error when deleting configuration for %v: %v", key, err)
+			}
+		}
 	} else {
 		glog.V(2).Infof("Adding or Updating Ingress: %v\n", key)
 
+		lbc.recordAnnotationErrors(ing, key)
+
 		if isMaster(ing) {
 			mergeableIngExs, err := lbc.createMergableIngresses(ing)
 			if err != nil {
 				// we need to requeue because an error can occur even if the master is valid
 				// otherwise, we will not be able to generate the config until there is change
-				// in the master or minions.
-				lbc.syncQueue.RequeueAfter(task, err, 5*time.Second)
+				// in the master or minions. Invalid annotations of the master are the exception.
+				if !isInvalidAnnotationsError(err) {
+					lbc.syncQueue.RequeueAfter(task, err, 5*time.Second)
+				}
 				lbc.recorder.Eventf(ing, api_v1.EventTypeWarning, "Rejected", "%v was rejected: %v", key, err)
 				if lbc.reportStatusEnabled() {
 					err = lbc.statusUpdater.ClearIngressStatus(*ing)
@@ -795,6 +1160,28 @@ func (lbc *LoadBalancerController) syncIng(task task) {
 	}
 }
 
+// invalidAnnotationsError is returned when an Ingress with invalid annotations is rejected in the strict annotations mode.
+// Unlike other errors, it is permanent: the Ingress is not requeued, because it is synced again once it is updated.
+type invalidAnnotationsError struct {
+	errs field.ErrorList
+}
+
+func (e *invalidAnnotationsError) Error() string {
+	return fmt.Sprintf("Ingress has invalid annotations: %v", e.errs.ToAggregate())
+}
+
+func isInvalidAnnotationsError(err error) bool {
+	_, ok := err.(*invalidAnnotationsError)
+	return ok
+}
+
+// recordAnnotationErrors emits a Warning event for every annotation of the Ingress that is invalid or not supported.
+func (lbc *LoadBalancerController) recordAnnotationErrors(ing *networking.Ingress, key string) {
+	for _, err := range configs.ValidateIngressAnnotations(ing, lbc.isNginxPlus) {
+		lbc.recorder.Eventf(ing, api_v1.EventTypeWarning, "InvalidAnnotation", "%v has an invalid annotation: %v", key, err)
+	}
+}
+
 func (lbc *LoadBalancerController) updateIngressMetrics() {
 	counters := lbc.configurator.GetIngressCounts()
 	for nType, count := range counters {
@@ -825,6 +1212,24 @@ func (lbc *LoadBalancerController) syncExternalService(task task) {
 			glog.Errorf("error updating ingress status in syncExternalService: %v", err)
 		}
 	}
+	lbc.updateCustomResourcesExternalEndpoints()
+}
+
+// updateCustomResourcesExternalEndpoints updates the external endpoints in the status of
+// the VirtualServer and VirtualServerRoute resources.
+func (lbc *LoadBalancerController) updateCustomResourcesExternalEndpoints() {
+	if !lbc.areCustomResourcesEnabled || !lbc.reportCustomResourceStatusEnabled() {
+		return
+	}
+
+	err := lbc.statusUpdater.UpdateVirtualServersExternalEndpoints(lbc.getVirtualServers())
+	if err != nil {
+		glog.Errorf("error updating VirtualServer status: %v", err)
+	}
+	err = lbc.statusUpdater.UpdateVirtualServerRoutesExternalEndpoints(lbc.getVirtualServerRoutes())
+	if err != nil {
+		glog.Errorf("error updating VirtualServerRoute status: %v", err)
+	}
 }
 
 func (lbc *LoadBalancerController) syncWallarmTarantool(task task) {
@@ -856,6 +1261,16 @@ func (lbc *LoadBalancerController) reportStatusEnabled() bool {
 	return false
 }
 
+// reportCustomResourceStatusEnabled determines if we should attempt to report the status of
+// the VirtualServer and VirtualServerRoute resources. Unlike the Ingress status, it is always reported,
+// but only by the leader if leader election is enabled.
+func (lbc *LoadBalancerController) reportCustomResourceStatusEnabled() bool {
+	if lbc.isLeaderElectionEnabled {
+		return lbc.leaderElector != nil && lbc.leaderElector.IsLeader()
+	}
+	return true
+}
+
 func (lbc *LoadBalancerController) syncSecret(task task) {
 	key := task.Key
 	obj, secrExists, err := lbc.secretLister.Store.GetByKey(key)
@@ -912,7 +1327,7 @@ func (lbc *LoadBalancerController) isSpecialSecret(secretName string) bool {
 	return secretName == lbc.defaultServerSecret || secretName == lbc.wildcardTLSSecret
 }
 
-func (lbc *LoadBalancerController) handleRegularSecretDeletion(key string, ings []extensions.Ingress, virtualServers []*conf_v1alpha1.VirtualServer) {
+func (lbc *LoadBalancerController) handleRegularSecretDeletion(key string, ings []networking.Ingress, virtualServers []*conf_v1alpha1.VirtualServer) {
 	eventType := api_v1.EventTypeWarning
 	title := "Missing Secret"
 	message := fmt.Sprintf("Secret %v was removed", key)
@@ -940,7 +1355,7 @@ func (lbc *LoadBalancerController) handleRegularSecretDeletion(key string, ings
 	lbc.emitEventForVirtualServers(eventType, title, message, virtualServers)
 }
 
-func (lbc *LoadBalancerController) handleSecretUpdate(secret *api_v1.Secret, ings []extensions.Ingress, virtualServers []*conf_v1alpha1.VirtualServer) {
+func (lbc *LoadBalancerController) handleSecretUpdate(secret *api_v1.Secret, ings []networking.Ingress, virtualServers []*conf_v1alpha1.VirtualServer) {
 	secretNsName := secret.Namespace + "/" + secret.Name
 
 	err := lbc.ValidateSecret(secret)
@@ -959,24 +1374,29 @@ func (lbc *LoadBalancerController) handleSecretUpdate(secret *api_v1.Secret, ing
 	title := "Updated"
 	message := fmt.Sprintf("Configuration was updated due to updated secret %v", secretNsName)
 
+	regular, mergeable := lbc.createIngresses(ings)
+
+	virtualServerExes := lbc.virtualServersToVirtualServerExes(virtualServers)
+
 	// we can safely ignore the error because the secret is valid in this function
 	kind, _ := GetSecretKind(secret)
 
+	var addOrUpdateErr error
 	if kind == JWK {
-		lbc.configurator.AddOrUpdateJWKSecret(secret)
+		addOrUpdateErr = lbc.configurator.AddOrUpdateJWKSecret(secret, regular, mergeable, virtualServerExes)
+	} else if kind == CA {
+		addOrUpdateErr = lbc.configurator.AddOrUpdateCASecret(secret, regular, mergeable, virtualServerExes)
 	} else {
-		regular, mergeable := lbc.createIngresses(ings)
-
-		virtualServerExes := lbc.virtualServersToVirtualServerExes(virtualServers)
+		addOrUpdateErr = lbc.configurator.AddOrUpdateTLSSecret(secret, regular, mergeable, virtualServerExes)
+	}
 
-		if err := lbc.configurator.AddOrUpdateTLSSecret(secret, regular, mergeable, virtualServerExes); err != nil {
-			glog.Errorf("Error when updating Secret %v: %v", secretNsName, err)
-			lbc.recorder.Eventf(secret, api_v1.EventTypeWarning, "UpdatedWithError", "%v was updated, but not applied: %v", secretNsName, err)
+	if addOrUpdateErr != nil {
+		glog.Errorf("Error when updating Secret %v: %v", secretNsName, addOrUpdateErr)
+		lbc.recorder.Eventf(secret, api_v1.EventTypeWarning, "UpdatedWithError", "%v was updated, but not applied: %v", secretNsName, addOrUpdateErr)
 
-			eventType = api_v1.EventTypeWarning
-			title = "UpdatedWithError"
-			message = fmt.Sprintf("Configuration was updated due to updated secret %v, but not applied: %v", secretNsName, err)
-		}
+		eventType = api_v1.EventTypeWarning
+		title = "UpdatedWithError"
+		message = fmt.Sprintf("Configuration was updated due to updated secret %v, but not applied: %v", secretNsName, addOrUpdateErr)
 	}
 
 	lbc.emitEventForIngresses(eventType, title, message, ings)
@@ -1010,7 +1430,7 @@ func (lbc *LoadBalancerController) handleSpecialSecretUpdate(secret *api_v1.Secr
 	lbc.recorder.Eventf(secret, api_v1.EventTypeNormal, "Updated", "the special Secret %v was updated", secretNsName)
 }
 
-func (lbc *LoadBalancerController) emitEventForIngresses(eventType string, title string, message string, ings []extensions.Ingress) {
+func (lbc *LoadBalancerController) emitEventForIngresses(eventType string, title string, message string, ings []networking.Ingress) {
 	for _, ing := range ings {
 		lbc.recorder.Eventf(&ing, eventType, title, message)
 		if isMinion(&ing) {
@@ -1031,7 +1451,7 @@ func (lbc *LoadBalancerController) emitEventForVirtualServers(eventType string,
 	}
 }
 
-func (lbc *LoadBalancerController) createIngresses(ings []extensions.Ingress) (regular []configs.IngressEx, mergeable []configs.MergeableIngresses) {
+func (lbc *LoadBalancerController) createIngresses(ings []networking.Ingress) (regular []configs.IngressEx, mergeable []configs.MergeableIngresses) {
 	for i := range ings {
 		if isMaster(&ings[i]) {
 			mergeableIng, err := lbc.createMergableIngresses(&ings[i])
@@ -1069,7 +1489,7 @@ func (lbc *LoadBalancerController) createIngresses(ings []extensions.Ingress) (r
 	return regular, mergeable
 }
 
-func (lbc *LoadBalancerController) findIngressesForSecret(secretNamespace string, secretName string) (ings []extensions.Ingress, error error) {
+func (lbc *LoadBalancerController) findIngressesForSecret(secretNamespace string, secretName string) (ings []networking.Ingress, error error) {
 	allIngs, err := lbc.ingressLister.List()
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't get the list of Ingress resources: %v", err)
@@ -1095,6 +1515,16 @@ items:
 					continue items
 				}
 			}
+			if clientCASecret, exists := ing.Annotations[configs.ClientCertificateSecretAnnotation]; exists {
+				if clientCASecret == secretName {
+					ings = append(ings, ing)
+					continue items
+				}
+			}
+			if isSecretReferencedByProxySSL(&ing, secretName) {
+				ings = append(ings, ing)
+				continue items
+			}
 			if lbc.isNginxPlus {
 				if jwtKey, exists := ing.Annotations[configs.JWTKeyAnnotation]; exists {
 					if jwtKey == secretName {
@@ -1106,18 +1536,23 @@ items:
 		}
 
 		// we're dealing with a minion
-		// minions can only have JWT secrets
-		if lbc.isNginxPlus {
-			master, err := lbc.FindMasterForMinion(&ing)
-			if err != nil {
-				glog.Infof("Ignoring Ingress %v(Minion): %v", ing.Name, err)
-				continue
-			}
+		// minions can only have JWT and proxy SSL secrets
+		master, err := lbc.FindMasterForMinion(&ing)
+		if err != nil {
+			glog.Infof("Ignoring Ingress %v(Minion): %v", ing.Name, err)
+			continue
+		}
 
-			if !lbc.configurator.HasMinion(master, &ing) {
-				continue
-			}
+		if !lbc.configurator.HasMinion(master, &ing) {
+			continue
+		}
+
+		if isSecretReferencedByProxySSL(&ing, secretName) {
+			ings = append(ings, ing)
+			continue
+		}
 
+		if lbc.isNginxPlus {
 			if jwtKey, exists := ing.Annotations[configs.JWTKeyAnnotation]; exists {
 				if jwtKey == secretName {
 					ings = append(ings, ing)
@@ -1129,6 +1564,15 @@ items:
 	return ings, nil
 }
 
+func isSecretReferencedByProxySSL(ing *networking.Ingress, secretName string) bool {
+	for _, annotation := range []string{configs.ProxySSLTrustedCertSecretAnnotation, configs.ProxySSLSecretAnnotation} {
+		if name, exists := ing.Annotations[annotation]; exists && name == secretName {
+			return true
+		}
+	}
+	return false
+}
+
 // EnqueueIngressForService enqueues the ingress for the given service
 func (lbc *LoadBalancerController) EnqueueIngressForService(svc *api_v1.Service) {
 	ings := lbc.getIngressesForService(svc)
@@ -1160,7 +1604,36 @@ func (lbc *LoadBalancerController) EnqueueVirtualServersForService(service *api_
 	}
 }
 
-func (lbc *LoadBalancerController) getIngressesForService(svc *api_v1.Service) []extensions.Ingress {
+// EnqueueVirtualServersForPod enqueues VirtualServers that reference a service of the pod with a subselector,
+// so that the upstreams reflect a change of the labels of the pod.
+func (lbc *LoadBalancerController) EnqueueVirtualServersForPod(pod *api_v1.Pod) {
+	for _, svc := range lbc.getServicesForPod(pod) {
+		if !lbc.isServiceReferencedWithSubselector(svc) {
+			continue
+		}
+		lbc.EnqueueVirtualServersForService(svc)
+	}
+}
+
+// EnqueueTransportServersForService enqueues TransportServers for the given service.
+func (lbc *LoadBalancerController) EnqueueTransportServersForService(service *api_v1.Service) {
+	transportServers := findTransportServersForService(lbc.getTransportServers(), service)
+	for _, ts := range transportServers {
+		lbc.syncQueue.Enqueue(ts)
+	}
+}
+
+// enqueueTransportServersForListener enqueues TransportServers with the given listener key,
+// so that a TransportServer rejected because of a listener conflict can claim the listener once it is released.
+func (lbc *LoadBalancerController) enqueueTransportServersForListener(listenerKey string) {
+	for _, ts := range lbc.getTransportServers() {
+		if getListenerKeyForTransportServer(ts) == listenerKey {
+			lbc.syncQueue.Enqueue(ts)
+		}
+	}
+}
+
+func (lbc *LoadBalancerController) getIngressesForService(svc *api_v1.Service) []networking.Ingress {
 	ings, err := lbc.ingressLister.GetServiceIngress(svc)
 	if err != nil {
 		glog.V(3).Infof("For service %v: %v", svc.Name, err)
@@ -1169,8 +1642,8 @@ func (lbc *LoadBalancerController) getIngressesForService(svc *api_v1.Service) [
 	return ings
 }
 
-func (lbc *LoadBalancerController) getIngressForEndpoints(obj interface{}) []extensions.Ingress {
-	var ings []extensions.Ingress
+func (lbc *LoadBalancerController) getIngressForEndpoints(obj interface{}) []networking.Ingress {
+	var ings []networking.Ingress
 	endp := obj.(*api_v1.Endpoints)
 	svcKey := endp.GetNamespace() + "/" + endp.GetName()
 	svcObj, svcExists, err := lbc.svcLister.GetByKey(svcKey)
@@ -1219,6 +1692,47 @@ func (lbc *LoadBalancerController) getVirtualServersForService(service *api_v1.S
 	return result
 }
 
+func (lbc *LoadBalancerController) getServicesForPod(pod *api_v1.Pod) []*api_v1.Service {
+	var result []*api_v1.Service
+
+	for _, obj := range lbc.svcLister.List() {
+		svc := obj.(*api_v1.Service)
+		if svc.Namespace != pod.Namespace || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			result = append(result, svc)
+		}
+	}
+
+	return result
+}
+
+func (lbc *LoadBalancerController) isServiceReferencedWithSubselector(service *api_v1.Service) bool {
+	for _, vs := range lbc.getVirtualServers() {
+		if vs.Namespace == service.Namespace && hasUpstreamWithSubselectorForService(vs.Spec.Upstreams, service.Name) {
+			return true
+		}
+	}
+
+	for _, vsr := range lbc.getVirtualServerRoutes() {
+		if vsr.Namespace == service.Namespace && hasUpstreamWithSubselectorForService(vsr.Spec.Upstreams, service.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasUpstreamWithSubselectorForService(upstreams []conf_v1alpha1.Upstream, serviceName string) bool {
+	for _, u := range upstreams {
+		if u.Service == serviceName && len(u.Subselector) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func findVirtualServersForService(virtualServers []*conf_v1alpha1.VirtualServer, service *api_v1.Service) []*conf_v1alpha1.VirtualServer {
 	var result []*conf_v1alpha1.VirtualServer
 
@@ -1269,15 +1783,189 @@ func findVirtualServerRoutesForService(virtualServerRoutes []*conf_v1alpha1.Virt
 	return result
 }
 
-func (lbc *LoadBalancerController) getVirtualServersForSecret(secretNamespace string, secretName string) []*conf_v1alpha1.VirtualServer {
+func (lbc *LoadBalancerController) getTransportServersForEndpoints(endpoints *api_v1.Endpoints) []*conf_v1alpha1.TransportServer {
+	svcKey := fmt.Sprintf("%s/%s", endpoints.Namespace, endpoints.Name)
+
+	svc, exists, err := lbc.svcLister.GetByKey(svcKey)
+	if err != nil {
+		glog.V(3).Infof("Error getting service %v from the cache: %v", svcKey, err)
+		return nil
+	}
+	if !exists {
+		glog.V(3).Infof("Service %v doesn't exist", svcKey)
+		return nil
+	}
+
+	allTransportServers := lbc.getTransportServers()
+	transportServers := findTransportServersForService(allTransportServers, svc.(*api_v1.Service))
+
+	// only the TransportServers that hold their listeners are present in NGINX configuration
+	var result []*conf_v1alpha1.TransportServer
+	for _, ts := range transportServers {
+		holder := findTransportServerHoldingListener(allTransportServers, getListenerKeyForTransportServer(ts))
+		if holder != nil && isSameTransportServer(holder, ts) {
+			result = append(result, ts)
+		}
+	}
+
+	return result
+}
+
+func findTransportServersForService(transportServers []*conf_v1alpha1.TransportServer, service *api_v1.Service) []*conf_v1alpha1.TransportServer {
+	var result []*conf_v1alpha1.TransportServer
+
+	for _, ts := range transportServers {
+		if ts.Namespace != service.Namespace {
+			continue
+		}
+
+		for _, u := range ts.Spec.Upstreams {
+			if u.Service == service.Name {
+				result = append(result, ts)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// getListenerKeyForTransportServer returns the key of the listener of the TransportServer. TLS Passthrough TransportServers
+// share the same listener, so their keys also include the host.
+func getListenerKeyForTransportServer(ts *conf_v1alpha1.TransportServer) string {
+	if ts.Spec.Listener.Protocol == conf_v1alpha1.TLSPassthroughProtocol {
+		return fmt.Sprintf("%d/%s/%s", ts.Spec.Listener.Port, ts.Spec.Listener.Protocol, ts.Spec.Host)
+	}
+
+	return fmt.Sprintf("%d/%s", ts.Spec.Listener.Port, ts.Spec.Listener.Protocol)
+}
+
+// findTransportServerHoldingListener returns the TransportServer that holds the listener with the given key.
+// If several TransportServers define the same listener, the oldest one holds it.
+func findTransportServerHoldingListener(transportServers []*conf_v1alpha1.TransportServer, listenerKey string) *conf_v1alpha1.TransportServer {
+	var holder *conf_v1alpha1.TransportServer
+
+	for _, ts := range transportServers {
+		if getListenerKeyForTransportServer(ts) != listenerKey {
+			continue
+		}
+
+		if holder == nil || isOlderTransportServer(ts, holder) {
+			holder = ts
+		}
+	}
+
+	return holder
+}
+
+// getListenerConflictMessage returns the message that explains why a TransportServer can't use its listener,
+// which is held by another TransportServer. For TLS Passthrough TransportServers, the conflict is caused by the host.
+func getListenerConflictMessage(ts *conf_v1alpha1.TransportServer, holder *conf_v1alpha1.TransportServer) string {
+	if ts.Spec.Listener.Protocol == conf_v1alpha1.TLSPassthroughProtocol {
+		return fmt.Sprintf("host %v is already used by TLS Passthrough TransportServer %v/%v", ts.Spec.Host, holder.Namespace, holder.Name)
+	}
+
+	return fmt.Sprintf("listener %v is already used by TransportServer %v/%v", getListenerKeyForTransportServer(ts), holder.Namespace, holder.Name)
+}
+
+func isSameTransportServer(ts *conf_v1alpha1.TransportServer, other *conf_v1alpha1.TransportServer) bool {
+	return ts.Namespace == other.Namespace && ts.Name == other.Name
+}
+
+func isOlderTransportServer(ts *conf_v1alpha1.TransportServer, other *conf_v1alpha1.TransportServer) bool {
+	if !ts.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return ts.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+
+	return fmt.Sprintf("%s/%s", ts.Namespace, ts.Name) < fmt.Sprintf("%s/%s", other.Namespace, other.Name)
+}
+
+func (lbc *LoadBalancerController) getTransportServers() []*conf_v1alpha1.TransportServer {
+	var transportServers []*conf_v1alpha1.TransportServer
+
+	for _, obj := range lbc.transportServerLister.List() {
+		ts := obj.(*conf_v1alpha1.TransportServer)
+
+		err := validation.ValidateTransportServer(ts, lbc.isNginxPlus, lbc.isTLSPassthroughEnabled)
+		if err != nil {
+			glog.V(3).Infof("Skipping invalid TransportServer %s/%s: %v", ts.Namespace, ts.Name, err)
+			continue
+		}
+
+		transportServers = append(transportServers, ts)
+	}
+
+	return transportServers
+}
+
+func (lbc *LoadBalancerController) getVirtualServersForSecret(secretNamespace string, secretName string) []*conf_v1alpha1.VirtualServer {
 	virtualServers := lbc.getVirtualServers()
-	return findVirtualServersForSecret(virtualServers, secretNamespace, secretName)
+	virtualServerRoutes := lbc.getVirtualServerRoutes()
+	result := findVirtualServersForSecret(virtualServers, secretNamespace, secretName)
+
+	// find VirtualServers that reference the Secret through the upstreams of VirtualServerRoutes
+	for _, vsr := range findVirtualServerRoutesForSecret(virtualServerRoutes, secretNamespace, secretName) {
+		result = append(result, findVirtualServersForVirtualServerRoute(virtualServers, vsr)...)
+	}
+
+	if !lbc.isNginxPlus {
+		return removeDuplicateVirtualServers(result)
+	}
+
+	// find VirtualServers that reference the Secret through JWT policies
+	for _, pol := range findPoliciesForSecret(lbc.getAllPolicies(), secretNamespace, secretName) {
+		policyKey := fmt.Sprintf("%s/%s", pol.Namespace, pol.Name)
+		result = append(result, findVirtualServersForPolicyKey(virtualServers, virtualServerRoutes, policyKey)...)
+	}
+
+	return removeDuplicateVirtualServers(result)
+}
+
+func findPoliciesForSecret(policies []*conf_v1alpha1.Policy, secretNamespace string, secretName string) []*conf_v1alpha1.Policy {
+	var result []*conf_v1alpha1.Policy
+
+	for _, pol := range policies {
+		if pol.Spec.JWTAuth == nil {
+			continue
+		}
+
+		if pol.Namespace == secretNamespace && pol.Spec.JWTAuth.Secret == secretName {
+			result = append(result, pol)
+		}
+	}
+
+	return result
+}
+
+func removeDuplicateVirtualServers(virtualServers []*conf_v1alpha1.VirtualServer) []*conf_v1alpha1.VirtualServer {
+	var result []*conf_v1alpha1.VirtualServer
+	keys := make(map[string]bool)
+
+	for _, vs := range virtualServers {
+		key := fmt.Sprintf("%s/%s", vs.Namespace, vs.Name)
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		result = append(result, vs)
+	}
+
+	return result
 }
 
 func findVirtualServersForSecret(virtualServers []*conf_v1alpha1.VirtualServer, secretNamespace string, secretName string) []*conf_v1alpha1.VirtualServer {
 	var result []*conf_v1alpha1.VirtualServer
 
 	for _, vs := range virtualServers {
+		if vs.Namespace != secretNamespace {
+			continue
+		}
+
+		if isSecretReferencedByUpstreams(vs.Spec.Upstreams, secretName) {
+			result = append(result, vs)
+			continue
+		}
+
 		if vs.Spec.TLS == nil {
 			continue
 		}
@@ -1285,7 +1973,12 @@ func findVirtualServersForSecret(virtualServers []*conf_v1alpha1.VirtualServer,
 			continue
 		}
 
-		if vs.Namespace == secretNamespace && vs.Spec.TLS.Secret == secretName {
+		if vs.Spec.TLS.Secret == secretName {
+			result = append(result, vs)
+			continue
+		}
+
+		if vs.Spec.TLS.ClientCertificate != nil && vs.Spec.TLS.ClientCertificate.Secret == secretName {
 			result = append(result, vs)
 		}
 	}
@@ -1293,13 +1986,37 @@ func findVirtualServersForSecret(virtualServers []*conf_v1alpha1.VirtualServer,
 	return result
 }
 
+func findVirtualServerRoutesForSecret(virtualServerRoutes []*conf_v1alpha1.VirtualServerRoute, secretNamespace string, secretName string) []*conf_v1alpha1.VirtualServerRoute {
+	var result []*conf_v1alpha1.VirtualServerRoute
+
+	for _, vsr := range virtualServerRoutes {
+		if vsr.Namespace == secretNamespace && isSecretReferencedByUpstreams(vsr.Spec.Upstreams, secretName) {
+			result = append(result, vsr)
+		}
+	}
+
+	return result
+}
+
+func isSecretReferencedByUpstreams(upstreams []conf_v1alpha1.Upstream, secretName string) bool {
+	for _, u := range upstreams {
+		if u.TLS == nil || !u.TLS.Enable {
+			continue
+		}
+		if u.TLS.TrustedCertSecret == secretName || u.TLS.Secret == secretName {
+			return true
+		}
+	}
+	return false
+}
+
 func (lbc *LoadBalancerController) getVirtualServers() []*conf_v1alpha1.VirtualServer {
 	var virtualServers []*conf_v1alpha1.VirtualServer
 
 	for _, obj := range lbc.virtualServerLister.List() {
 		vs := obj.(*conf_v1alpha1.VirtualServer)
 
-		err := validation.ValidateVirtualServer(vs)
+		err := validation.ValidateVirtualServer(vs, lbc.isNginxPlus)
 		if err != nil {
 			glog.V(3).Infof("Skipping invalid VirtualServer %s/%s: %v", vs.Namespace, vs.Name, err)
 			continue
@@ -1311,13 +2028,31 @@ func (lbc *LoadBalancerController) getVirtualServers() []*conf_v1alpha1.VirtualS
 	return virtualServers
 }
 
+func (lbc *LoadBalancerController) getAllPolicies() []*conf_v1alpha1.Policy {
+	var policies []*conf_v1alpha1.Policy
+
+	for _, obj := range lbc.policyLister.List() {
+		pol := obj.(*conf_v1alpha1.Policy)
+
+		err := validation.ValidatePolicy(pol, lbc.isNginxPlus)
+		if err != nil {
+			glog.V(3).Infof("Skipping invalid Policy %s/%s: %v", pol.Namespace, pol.Name, err)
+			continue
+		}
+
+		policies = append(policies, pol)
+	}
+
+	return policies
+}
+
 func (lbc *LoadBalancerController) getVirtualServerRoutes() []*conf_v1alpha1.VirtualServerRoute {
 	var virtualServerRoutes []*conf_v1alpha1.VirtualServerRoute
 
 	for _, obj := range lbc.virtualServerRouteLister.List() {
 		vsr := obj.(*conf_v1alpha1.VirtualServerRoute)
 
-		err := validation.ValidateVirtualServerRoute(vsr)
+		err := validation.ValidateVirtualServerRoute(vsr, lbc.isNginxPlus)
 		if err != nil {
 			glog.V(3).Infof("Skipping invalid VirtualServerRoute %s/%s: %v", vsr.Namespace, vsr.Name, err)
 			continue
@@ -1359,6 +2094,46 @@ func findVirtualServersForVirtualServerRouteKey(virtualServers []*conf_v1alpha1.
 	return result
 }
 
+func (lbc *LoadBalancerController) enqueueVirtualServersForPolicyKey(key string) int {
+	virtualServers := findVirtualServersForPolicyKey(lbc.getVirtualServers(), lbc.getVirtualServerRoutes(), key)
+
+	for _, vs := range virtualServers {
+		lbc.syncQueue.Enqueue(vs)
+	}
+
+	return len(virtualServers)
+}
+
+func findVirtualServersForPolicyKey(virtualServers []*conf_v1alpha1.VirtualServer, virtualServerRoutes []*conf_v1alpha1.VirtualServerRoute,
+	key string) []*conf_v1alpha1.VirtualServer {
+	var result []*conf_v1alpha1.VirtualServer
+
+	// find VirtualServers that reference the Policy
+	for _, vs := range virtualServers {
+		if isPolicyKeyReferenced(getPolicyKeysForVirtualServer(vs), key) {
+			result = append(result, vs)
+		}
+	}
+
+	// find VirtualServers that reference VirtualServerRoutes that reference the Policy
+	for _, vsr := range virtualServerRoutes {
+		if isPolicyKeyReferenced(getPolicyKeysForVirtualServerRoute(vsr), key) {
+			result = append(result, findVirtualServersForVirtualServerRoute(virtualServers, vsr)...)
+		}
+	}
+
+	return result
+}
+
+func isPolicyKeyReferenced(policyKeys []string, key string) bool {
+	for _, k := range policyKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 func (lbc *LoadBalancerController) getAndValidateSecret(secretKey string) (*api_v1.Secret, error) {
 	secretObject, secretExists, err := lbc.secretLister.GetByKey(secretKey)
 	if err != nil {
@@ -1376,7 +2151,47 @@ func (lbc *LoadBalancerController) getAndValidateSecret(secretKey string) (*api_
 	return secret, nil
 }
 
-func (lbc *LoadBalancerController) createIngress(ing *extensions.Ingress) (*configs.IngressEx, error) {
+func (lbc *LoadBalancerController) getAndValidateJWKSecret(secretKey string) (*api_v1.Secret, error) {
+	secretObject, secretExists, err := lbc.secretLister.GetByKey(secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving secret %v", secretKey)
+	}
+	if !secretExists {
+		return nil, fmt.Errorf("secret %v not found", secretKey)
+	}
+	secret := secretObject.(*api_v1.Secret)
+
+	err = ValidateJWKSecret(secret)
+	if err != nil {
+		return nil, fmt.Errorf("error validating secret %v: %v", secretKey, err)
+	}
+	return secret, nil
+}
+
+func (lbc *LoadBalancerController) getAndValidateCASecret(secretKey string) (*api_v1.Secret, error) {
+	secretObject, secretExists, err := lbc.secretLister.GetByKey(secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving secret %v", secretKey)
+	}
+	if !secretExists {
+		return nil, fmt.Errorf("secret %v not found", secretKey)
+	}
+	secret := secretObject.(*api_v1.Secret)
+
+	err = ValidateCASecret(secret)
+	if err != nil {
+		return nil, fmt.Errorf("error validating secret %v: %v", secretKey, err)
+	}
+	return secret, nil
+}
+
+func (lbc *LoadBalancerController) createIngress(ing *networking.Ingress) (*configs.IngressEx, error) {
+	if lbc.areAnnotationsStrict {
+		if allErrs := configs.ValidateIngressAnnotations(ing, lbc.isNginxPlus); len(allErrs) > 0 {
+			return nil, &invalidAnnotationsError{errs: allErrs}
+		}
+	}
+
 	ingEx := &configs.IngressEx{
 		Ingress: ing,
 	}
@@ -1393,6 +2208,36 @@ func (lbc *LoadBalancerController) createIngress(ing *extensions.Ingress) (*conf
 		ingEx.TLSSecrets[secretName] = secret
 	}
 
+	if clientCASecret, exists := ingEx.Ingress.Annotations[configs.ClientCertificateSecretAnnotation]; exists && !isMinion(ing) {
+		secretKey := ing.Namespace + "/" + clientCASecret
+		secret, err := lbc.getAndValidateCASecret(secretKey)
+		if err != nil {
+			glog.Warningf("Error trying to get the secret %v for Ingress %v: %v", clientCASecret, ing.Name, err)
+		} else {
+			ingEx.ClientCASecret = secret
+		}
+	}
+
+	if trustedCertSecret, exists := ingEx.Ingress.Annotations[configs.ProxySSLTrustedCertSecretAnnotation]; exists && !isMaster(ing) {
+		secretKey := ing.Namespace + "/" + trustedCertSecret
+		secret, err := lbc.getAndValidateCASecret(secretKey)
+		if err != nil {
+			glog.Warningf("Error trying to get the secret %v for Ingress %v: %v", trustedCertSecret, ing.Name, err)
+		} else {
+			ingEx.ProxySSLSecrets.TrustedCert = secret
+		}
+	}
+
+	if clientSecret, exists := ingEx.Ingress.Annotations[configs.ProxySSLSecretAnnotation]; exists && !isMaster(ing) {
+		secretKey := ing.Namespace + "/" + clientSecret
+		secret, err := lbc.getAndValidateSecret(secretKey)
+		if err != nil {
+			glog.Warningf("Error trying to get the secret %v for Ingress %v: %v", clientSecret, ing.Name, err)
+		} else {
+			ingEx.ProxySSLSecrets.Client = secret
+		}
+	}
+
 	if lbc.isNginxPlus {
 		if jwtKey, exists := ingEx.Ingress.Annotations[configs.JWTKeyAnnotation]; exists {
 			secretName := jwtKey
@@ -1419,6 +2264,9 @@ func (lbc *LoadBalancerController) createIngress(ing *extensions.Ingress) (*conf
 	ingEx.Endpoints = make(map[string][]string)
 	ingEx.HealthChecks = make(map[string]*api_v1.Probe)
 	ingEx.ExternalNameSvcs = make(map[string]bool)
+	if lbc.controllerZone != "" {
+		ingEx.LocalZoneEndpoints = make(map[string]bool)
+	}
 
 	if ing.Spec.Backend != nil {
 		endps := []string{}
@@ -1431,6 +2279,9 @@ func (lbc *LoadBalancerController) createIngress(ing *extensions.Ingress) (*conf
 			if err == nil && external && lbc.isNginxPlus {
 				ingEx.ExternalNameSvcs[svc.Name] = true
 			}
+			if err == nil && !external {
+				lbc.addLocalZoneEndpoints(ingEx.LocalZoneEndpoints, svc, endps)
+			}
 		}
 
 		if err != nil {
@@ -1468,6 +2319,9 @@ func (lbc *LoadBalancerController) createIngress(ing *extensions.Ingress) (*conf
 				if err == nil && external && lbc.isNginxPlus {
 					ingEx.ExternalNameSvcs[svc.Name] = true
 				}
+				if err == nil && !external {
+					lbc.addLocalZoneEndpoints(ingEx.LocalZoneEndpoints, svc, endps)
+				}
 			}
 
 			if err != nil {
@@ -1516,7 +2370,12 @@ func newVirtualServerRouteErrorFromVSR(virtualServerRoute *conf_v1alpha1.Virtual
 	}
 }
 
-func (lbc *LoadBalancerController) createVirtualServer(virtualServer *conf_v1alpha1.VirtualServer) (*configs.VirtualServerEx, []virtualServerRouteError) {
+type policyError struct {
+	PolicyNsName string
+	Error        error
+}
+
+func (lbc *LoadBalancerController) createVirtualServer(virtualServer *conf_v1alpha1.VirtualServer) (*configs.VirtualServerEx, []virtualServerRouteError, []policyError) {
 	virtualServerEx := configs.VirtualServerEx{
 		VirtualServer: virtualServer,
 	}
@@ -1531,11 +2390,41 @@ func (lbc *LoadBalancerController) createVirtualServer(virtualServer *conf_v1alp
 		}
 	}
 
+	if virtualServer.Spec.TLS != nil && virtualServer.Spec.TLS.ClientCertificate != nil {
+		secretKey := virtualServer.Namespace + "/" + virtualServer.Spec.TLS.ClientCertificate.Secret
+		secret, err := lbc.getAndValidateCASecret(secretKey)
+		if err != nil {
+			glog.Warningf("Error trying to get the secret %v for VirtualServer %v: %v", secretKey, virtualServer.Name, err)
+		} else {
+			virtualServerEx.ClientCASecret = secret
+		}
+	}
+
 	endpoints := make(map[string][]string)
+	healthChecks := make(map[string]*api_v1.Probe)
+	upstreamCASecrets := make(map[string]*api_v1.Secret)
+	upstreamTLSSecrets := make(map[string]*api_v1.Secret)
+
+	var localZoneEndpoints map[string]bool
+	if lbc.controllerZone != "" {
+		localZoneEndpoints = make(map[string]bool)
+	}
+
+	lbc.addUpstreamTLSSecrets(virtualServer.Spec.Upstreams, virtualServer.Namespace, virtualServer.Name, upstreamCASecrets, upstreamTLSSecrets)
 
 	for _, u := range virtualServer.Spec.Upstreams {
-		endpointsKey := configs.GenerateEndpointsKey(virtualServer.Namespace, u.Service, u.Port)
-		endpoints[endpointsKey] = lbc.getEndpointsForService(virtualServer.Namespace, u.Service, int(u.Port))
+		endpointsKey := configs.GenerateEndpointsKey(virtualServer.Namespace, u.Service, u.Subselector, u.Port)
+		endpoints[endpointsKey] = lbc.getEndpointsForService(virtualServer.Namespace, u.Service, u.Subselector, int(u.Port))
+
+		if u.ZoneAwareRouting {
+			lbc.addLocalZoneEndpointsForService(localZoneEndpoints, virtualServer.Namespace, u.Service, endpoints[endpointsKey])
+		}
+
+		if lbc.isNginxPlus && u.HealthCheck != nil && u.HealthCheck.Enable {
+			if probe := lbc.getHealthCheckForService(virtualServer.Namespace, u.Service, int(u.Port)); probe != nil {
+				healthChecks[endpointsKey] = probe
+			}
+		}
 	}
 
 	var virtualServerRoutes []*conf_v1alpha1.VirtualServerRoute
@@ -1569,7 +2458,7 @@ func (lbc *LoadBalancerController) createVirtualServer(virtualServer *conf_v1alp
 
 		vsr := obj.(*conf_v1alpha1.VirtualServerRoute)
 
-		err = validation.ValidateVirtualServerRouteForVirtualServer(vsr, virtualServer.Spec.Host, r.Path)
+		err = validation.ValidateVirtualServerRouteForVirtualServer(vsr, virtualServer.Spec.Host, r.Path, lbc.isNginxPlus)
 		if err != nil {
 			glog.Warningf("VirtualServer %s/%s references invalid VirtualServerRoute %s: %v", virtualServer.Name, virtualServer.Namespace, vsrKey, err)
 			virtualServerRouteErrors = append(virtualServerRouteErrors, newVirtualServerRouteErrorFromVSR(vsr, err))
@@ -1578,20 +2467,189 @@ func (lbc *LoadBalancerController) createVirtualServer(virtualServer *conf_v1alp
 
 		virtualServerRoutes = append(virtualServerRoutes, vsr)
 
+		lbc.addUpstreamTLSSecrets(vsr.Spec.Upstreams, vsr.Namespace, virtualServer.Name, upstreamCASecrets, upstreamTLSSecrets)
+
 		for _, u := range vsr.Spec.Upstreams {
-			endpointsKey := configs.GenerateEndpointsKey(vsr.Namespace, u.Service, u.Port)
-			endpoints[endpointsKey] = lbc.getEndpointsForService(vsr.Namespace, u.Service, int(u.Port))
+			endpointsKey := configs.GenerateEndpointsKey(vsr.Namespace, u.Service, u.Subselector, u.Port)
+			endpoints[endpointsKey] = lbc.getEndpointsForService(vsr.Namespace, u.Service, u.Subselector, int(u.Port))
+
+			if u.ZoneAwareRouting {
+				lbc.addLocalZoneEndpointsForService(localZoneEndpoints, vsr.Namespace, u.Service, endpoints[endpointsKey])
+			}
+
+			if lbc.isNginxPlus && u.HealthCheck != nil && u.HealthCheck.Enable {
+				if probe := lbc.getHealthCheckForService(vsr.Namespace, u.Service, int(u.Port)); probe != nil {
+					healthChecks[endpointsKey] = probe
+				}
+			}
 		}
 	}
 
+	policyKeys := getPolicyKeysForVirtualServer(virtualServer)
+	for _, vsr := range virtualServerRoutes {
+		policyKeys = append(policyKeys, getPolicyKeysForVirtualServerRoute(vsr)...)
+	}
+
+	policies, policyErrors := lbc.getPolicies(policyKeys)
+
+	jwtKeys, jwtPolicyErrors := lbc.getJWTKeysForPolicies(policies)
+	policyErrors = append(policyErrors, jwtPolicyErrors...)
+
 	virtualServerEx.Endpoints = endpoints
+	virtualServerEx.HealthChecks = healthChecks
 	virtualServerEx.VirtualServerRoutes = virtualServerRoutes
+	virtualServerEx.Policies = policies
+	virtualServerEx.JWTKeys = jwtKeys
+	virtualServerEx.UpstreamCASecrets = upstreamCASecrets
+	virtualServerEx.UpstreamTLSSecrets = upstreamTLSSecrets
+	virtualServerEx.LocalZoneEndpoints = localZoneEndpoints
+
+	return &virtualServerEx, virtualServerRouteErrors, policyErrors
+}
+
+// addUpstreamTLSSecrets adds the valid Secrets referenced by the TLS configuration of the upstreams to the caSecrets
+// (trusted CA certificates) and tlsSecrets (client certificates) maps, keyed by the namespace/name of a Secret.
+func (lbc *LoadBalancerController) addUpstreamTLSSecrets(upstreams []conf_v1alpha1.Upstream, namespace string, virtualServerName string,
+	caSecrets map[string]*api_v1.Secret, tlsSecrets map[string]*api_v1.Secret) {
+	for _, u := range upstreams {
+		if u.TLS == nil || !u.TLS.Enable {
+			continue
+		}
+
+		if u.TLS.TrustedCertSecret != "" {
+			secretKey := namespace + "/" + u.TLS.TrustedCertSecret
+			secret, err := lbc.getAndValidateCASecret(secretKey)
+			if err != nil {
+				glog.Warningf("Error trying to get the secret %v for VirtualServer %v: %v", secretKey, virtualServerName, err)
+			} else {
+				caSecrets[secretKey] = secret
+			}
+		}
+
+		if u.TLS.Secret != "" {
+			secretKey := namespace + "/" + u.TLS.Secret
+			secret, err := lbc.getAndValidateSecret(secretKey)
+			if err != nil {
+				glog.Warningf("Error trying to get the secret %v for VirtualServer %v: %v", secretKey, virtualServerName, err)
+			} else {
+				tlsSecrets[secretKey] = secret
+			}
+		}
+	}
+}
+
+// getPolicies returns the valid Policies with the given keys along with the errors for the missing and invalid ones.
+func (lbc *LoadBalancerController) getPolicies(policyKeys []string) (map[string]*conf_v1alpha1.Policy, []policyError) {
+	policies := make(map[string]*conf_v1alpha1.Policy)
+	var policyErrors []policyError
+
+	processed := make(map[string]bool)
+
+	for _, key := range policyKeys {
+		if processed[key] {
+			continue
+		}
+		processed[key] = true
 
-	return &virtualServerEx, virtualServerRouteErrors
+		obj, exists, err := lbc.policyLister.GetByKey(key)
+		if err != nil {
+			policyErrors = append(policyErrors, policyError{PolicyNsName: key, Error: err})
+			continue
+		}
+
+		if !exists {
+			policyErrors = append(policyErrors, policyError{PolicyNsName: key, Error: errors.New("Policy doesn't exist")})
+			continue
+		}
+
+		pol := obj.(*conf_v1alpha1.Policy)
+
+		err = validation.ValidatePolicy(pol, lbc.isNginxPlus)
+		if err != nil {
+			policyErrors = append(policyErrors, policyError{PolicyNsName: key, Error: fmt.Errorf("Policy is invalid: %v", err)})
+			continue
+		}
+
+		policies[key] = pol
+	}
+
+	return policies, policyErrors
+}
+
+// getJWTKeysForPolicies returns the valid JWK Secrets referenced by the JWT policies along with the errors for the
+// policies that reference missing or invalid Secrets. Such policies are removed from the policies map.
+func (lbc *LoadBalancerController) getJWTKeysForPolicies(policies map[string]*conf_v1alpha1.Policy) (map[string]*api_v1.Secret, []policyError) {
+	jwtKeys := make(map[string]*api_v1.Secret)
+	var policyErrors []policyError
+
+	for key, pol := range policies {
+		if pol.Spec.JWTAuth == nil {
+			continue
+		}
+
+		secretKey := fmt.Sprintf("%s/%s", pol.Namespace, pol.Spec.JWTAuth.Secret)
+
+		secret, err := lbc.getAndValidateJWKSecret(secretKey)
+		if err != nil {
+			policyErrors = append(policyErrors, policyError{PolicyNsName: key, Error: fmt.Errorf("JWK Secret is invalid: %v", err)})
+			delete(policies, key)
+			continue
+		}
+
+		jwtKeys[secretKey] = secret
+	}
+
+	return jwtKeys, policyErrors
+}
+
+func getPolicyKeysForVirtualServer(virtualServer *conf_v1alpha1.VirtualServer) []string {
+	keys := getPolicyKeys(virtualServer.Spec.Policies, virtualServer.Namespace)
+
+	for _, r := range virtualServer.Spec.Routes {
+		keys = append(keys, getPolicyKeys(r.Policies, virtualServer.Namespace)...)
+	}
+
+	return keys
+}
+
+func getPolicyKeysForVirtualServerRoute(virtualServerRoute *conf_v1alpha1.VirtualServerRoute) []string {
+	var keys []string
+
+	for _, r := range virtualServerRoute.Spec.Subroutes {
+		keys = append(keys, getPolicyKeys(r.Policies, virtualServerRoute.Namespace)...)
+	}
+
+	return keys
+}
+
+func getPolicyKeys(policyRefs []conf_v1alpha1.PolicyReference, ownerNamespace string) []string {
+	var keys []string
+
+	for _, p := range policyRefs {
+		keys = append(keys, configs.GeneratePolicyKey(p, ownerNamespace))
+	}
+
+	return keys
+}
+
+func (lbc *LoadBalancerController) createTransportServer(transportServer *conf_v1alpha1.TransportServer) *configs.TransportServerEx {
+	endpoints := make(map[string][]string)
+
+	for _, u := range transportServer.Spec.Upstreams {
+		endpointsKey := configs.GenerateEndpointsKey(transportServer.Namespace, u.Service, nil, u.Port)
+		endpoints[endpointsKey] = lbc.getEndpointsForService(transportServer.Namespace, u.Service, nil, int(u.Port))
+	}
+
+	return &configs.TransportServerEx{
+		TransportServer: transportServer,
+		Endpoints:       endpoints,
+	}
 }
 
-func (lbc *LoadBalancerController) getEndpointsForService(namespace string, name string, port int) []string {
-	backend := &extensions.IngressBackend{
+// getEndpointsForService returns the endpoints of the service for the given port. If the subselector is not empty,
+// only the endpoints of the pods that match the subselector are returned.
+func (lbc *LoadBalancerController) getEndpointsForService(namespace string, name string, subselector map[string]string, port int) []string {
+	backend := &networking.IngressBackend{
 		ServiceName: name,
 		ServicePort: intstr.FromInt(port),
 	}
@@ -1602,6 +2660,15 @@ func (lbc *LoadBalancerController) getEndpointsForService(namespace string, name
 		return nil
 	}
 
+	if len(subselector) > 0 {
+		endps, err := lbc.getEndpointsForSubselector(backend, svc, subselector)
+		if err != nil {
+			glog.Warningf("Error retrieving endpoints for the service %v with the subselector %v: %v", name, subselector, err)
+			return nil
+		}
+		return endps
+	}
+
 	endps, _, err := lbc.getEndpointsForIngressBackend(backend, namespace, svc)
 	if err != nil {
 		glog.Warningf("Error retrieving endpoints for the service %v: %v", name, err)
@@ -1611,6 +2678,66 @@ func (lbc *LoadBalancerController) getEndpointsForService(namespace string, name
 	return endps
 }
 
+func (lbc *LoadBalancerController) getEndpointsForSubselector(backend *networking.IngressBackend, svc *api_v1.Service, subselector map[string]string) ([]string, error) {
+	endps, err := lbc.getServiceEndpoints(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	svcPort := lbc.getServicePortForIngressPort(backend.ServicePort, svc)
+	if svcPort == nil {
+		return nil, fmt.Errorf("No port %v in service %s", backend.ServicePort, svc.Name)
+	}
+
+	targetPort, err := lbc.getTargetPort(svcPort, svc)
+	if err != nil {
+		return nil, fmt.Errorf("Error determining target port for port %v: %v", backend.ServicePort, err)
+	}
+
+	selector := labels.SelectorFromSet(subselector)
+
+	var endpoints []string
+	for _, subset := range endps.Subsets {
+		for _, port := range subset.Ports {
+			if port.Port != targetPort {
+				continue
+			}
+			for _, address := range subset.Addresses {
+				if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+					continue
+				}
+
+				podKey := fmt.Sprintf("%s/%s", address.TargetRef.Namespace, address.TargetRef.Name)
+				obj, exists, err := lbc.podLister.GetByKey(podKey)
+				if err != nil {
+					glog.V(3).Infof("Error getting pod %v from the cache: %v", podKey, err)
+					continue
+				}
+				if !exists {
+					glog.V(3).Infof("Pod %v doesn't exist", podKey)
+					continue
+				}
+
+				if selector.Matches(labels.Set(obj.(*api_v1.Pod).Labels)) {
+					endpoints = append(endpoints, fmt.Sprintf("%v:%v", address.IP, port.Port))
+				}
+			}
+		}
+	}
+
+	return endpoints, nil
+}
+
+// getHealthCheckForService returns the readiness probe of the pods of the service for the given port.
+func (lbc *LoadBalancerController) getHealthCheckForService(namespace string, name string, port int) *api_v1.Probe {
+	backend := &networking.IngressBackend{
+		ServiceName: name,
+		ServicePort: intstr.FromInt(port),
+	}
+
+	return lbc.getHealthChecksForIngressBackend(backend, namespace)
+}
+
 func (lbc *LoadBalancerController) getPodsForIngressBackend(svc *api_v1.Service, namespace string) *api_v1.PodList {
 	pods, err := lbc.client.CoreV1().Pods(svc.Namespace).List(meta_v1.ListOptions{LabelSelector: labels.Set(svc.Spec.Selector).String()})
 	if err != nil {
@@ -1620,7 +2747,7 @@ func (lbc *LoadBalancerController) getPodsForIngressBackend(svc *api_v1.Service,
 	return pods
 }
 
-func (lbc *LoadBalancerController) getHealthChecksForIngressBackend(backend *extensions.IngressBackend, namespace string) *api_v1.Probe {
+func (lbc *LoadBalancerController) getHealthChecksForIngressBackend(backend *networking.IngressBackend, namespace string) *api_v1.Probe {
 	svc, err := lbc.getServiceForIngressBackend(backend, namespace)
 	if err != nil {
 		glog.V(3).Infof("Error getting service %v: %v", backend.ServiceName, err)
@@ -1668,14 +2795,14 @@ func compareContainerPortAndServicePort(containerPort api_v1.ContainerPort, svcP
 	return false
 }
 
-func (lbc *LoadBalancerController) getExternalEndpointsForIngressBackend(backend *extensions.IngressBackend, namespace string, svc *api_v1.Service) []string {
+func (lbc *LoadBalancerController) getExternalEndpointsForIngressBackend(backend *networking.IngressBackend, namespace string, svc *api_v1.Service) []string {
 	endpoint := fmt.Sprintf("%s:%d", svc.Spec.ExternalName, int32(backend.ServicePort.IntValue()))
 	endpoints := []string{endpoint}
 	return endpoints
 }
 
-func (lbc *LoadBalancerController) getEndpointsForIngressBackend(backend *extensions.IngressBackend, namespace string, svc *api_v1.Service) (result []string, isExternal bool, err error) {
-	endps, err := lbc.endpointLister.GetServiceEndpoints(svc)
+func (lbc *LoadBalancerController) getEndpointsForIngressBackend(backend *networking.IngressBackend, namespace string, svc *api_v1.Service) (result []string, isExternal bool, err error) {
+	endps, err := lbc.getServiceEndpoints(svc)
 	if err != nil {
 		if svc.Spec.Type == api_v1.ServiceTypeExternalName {
 			if !lbc.isNginxPlus {
@@ -1769,7 +2896,7 @@ func (lbc *LoadBalancerController) getTargetPort(svcPort *api_v1.ServicePort, sv
 	return portNum, nil
 }
 
-func (lbc *LoadBalancerController) getServiceForIngressBackend(backend *extensions.IngressBackend, namespace string) (*api_v1.Service, error) {
+func (lbc *LoadBalancerController) getServiceForIngressBackend(backend *networking.IngressBackend, namespace string) (*api_v1.Service, error) {
 	svcKey := namespace + "/" + backend.ServiceName
 	svcObj, svcExists, err := lbc.svcLister.GetByKey(svcKey)
 	if err != nil {
@@ -1784,19 +2911,92 @@ func (lbc *LoadBalancerController) getServiceForIngressBackend(backend *extensio
 }
 
 // IsNginxIngress checks if resource ingress class annotation (if exists) is matching with ingress controller class
-// If annotation is absent and use-ingress-class-only enabled - ingress resource would ignore
-func (lbc *LoadBalancerController) IsNginxIngress(ing *extensions.Ingress) bool {
+// If annotation is absent, the ingressClassName field (if exists) must reference an IngressClass of the ingress controller
+// If both are absent and use-ingress-class-only enabled - ingress resource would ignore
+func (lbc *LoadBalancerController) IsNginxIngress(ing *networking.Ingress) bool {
 	if class, exists := ing.Annotations[ingressClassKey]; exists {
 		if lbc.useIngressClassOnly {
 			return class == lbc.ingressClass
 		}
 		return class == lbc.ingressClass || class == ""
 	}
+	if ing.Spec.IngressClassName != nil {
+		return lbc.isNginxIngressClass(*ing.Spec.IngressClassName)
+	}
 	return !lbc.useIngressClassOnly
 }
 
+// isNginxIngressClass checks if the IngressClass with the name is handled by the ingress controller.
+// If the cluster doesn't serve IngressClass resources, the name must be equal to the ingress controller class.
+func (lbc *LoadBalancerController) isNginxIngressClass(name string) bool {
+	if !lbc.isIngressClassAPIAvailable {
+		return name == lbc.ingressClass
+	}
+
+	obj, exists, err := lbc.ingressClassLister.GetByKey(name)
+	if err != nil {
+		glog.V(3).Infof("Error getting IngressClass %v from the cache: %v", name, err)
+		return false
+	}
+	if !exists {
+		return false
+	}
+
+	return lbc.matchesIngressClass(obj.(*networking.IngressClass))
+}
+
+// matchesIngressClass checks if the IngressClass is handled by the ingress controller. The controller of the class
+// must be ingressControllerName. If the class has parameters, they must reference the ConfigMap of the ingress
+// controller, including its namespace. Otherwise, the name of the class must be equal to the ingress controller class.
+func (lbc *LoadBalancerController) matchesIngressClass(class *networking.IngressClass) bool {
+	if class.Spec.Controller != ingressControllerName {
+		return false
+	}
+
+	params := class.Spec.Parameters
+	if params == nil {
+		return class.Name == lbc.ingressClass
+	}
+
+	if lbc.nginxConfigMapName == "" {
+		return false
+	}
+
+	isCoreGroup := params.APIGroup == nil || *params.APIGroup == ""
+	isNamespaced := params.Scope != nil && *params.Scope == networking.IngressClassParametersReferenceScopeNamespace
+
+	return isCoreGroup && params.Kind == "ConfigMap" && isNamespaced &&
+		params.Namespace != nil && *params.Namespace == lbc.nginxConfigMapNamespace && params.Name == lbc.nginxConfigMapName
+}
+
+// enqueueIngressesForIngressClass enqueues the Ingress resources that reference the IngressClass with the name.
+// A minion is synced through its master.
+func (lbc *LoadBalancerController) enqueueIngressesForIngressClass(name string) {
+	for _, obj := range lbc.ingressLister.Store.List() {
+		ing := obj.(*networking.Ingress)
+		if ing.Spec.IngressClassName == nil || *ing.Spec.IngressClassName != name {
+			continue
+		}
+		if _, exists := ing.Annotations[ingressClassKey]; exists {
+			continue
+		}
+
+		if isMinion(ing) {
+			master, err := lbc.FindMasterForMinion(ing)
+			if err != nil {
+				glog.V(3).Infof("Ignoring Ingress %v(Minion): %v", ing.Name, err)
+				continue
+			}
+			lbc.syncQueue.Enqueue(master)
+			continue
+		}
+
+		lbc.syncQueue.Enqueue(ing)
+	}
+}
+
 // isHealthCheckEnabled checks if health checks are enabled so we can only query pods if enabled.
-func (lbc *LoadBalancerController) isHealthCheckEnabled(ing *extensions.Ingress) bool {
+func (lbc *LoadBalancerController) isHealthCheckEnabled(ing *networking.Ingress) bool {
 	if healthCheckEnabled, exists, err := configs.GetMapKeyAsBool(ing.Annotations, "nginx.com/health-checks", ing); exists {
 		if err != nil {
 			glog.Error(err)
@@ -1806,21 +3006,26 @@ func (lbc *LoadBalancerController) isHealthCheckEnabled(ing *extensions.Ingress)
 	return false
 }
 
-// ValidateSecret validates that the secret follows the TLS Secret format.
+// ValidateSecret validates that the secret follows the TLS or the CA Secret format.
 // For NGINX Plus, it also checks if the secret follows the JWK Secret format.
 func (lbc *LoadBalancerController) ValidateSecret(secret *api_v1.Secret) error {
 	err1 := ValidateTLSSecret(secret)
+	err2 := ValidateCASecret(secret)
 	if !lbc.isNginxPlus {
-		return err1
+		if err1 == nil || err2 == nil {
+			return nil
+		}
+
+		return fmt.Errorf("Secret is not a TLS or CA secret")
 	}
 
-	err2 := ValidateJWKSecret(secret)
+	err3 := ValidateJWKSecret(secret)
 
-	if err1 == nil || err2 == nil {
+	if err1 == nil || err2 == nil || err3 == nil {
 		return nil
 	}
 
-	return fmt.Errorf("Secret is not a TLS or JWK secret")
+	return fmt.Errorf("Secret is not a TLS, CA or JWK secret")
 }
 
 // getMinionsForHost returns a list of all minion ingress resources for a given master
@@ -1836,7 +3041,7 @@ func (lbc *LoadBalancerController) getMinionsForMaster(master *configs.IngressEx
 	})
 
 	var minions []*configs.IngressEx
-	var minionPaths = make(map[string]*extensions.Ingress)
+	var minionPaths = make(map[string]*networking.Ingress)
 
 	for i := range ings.Items {
 		if !lbc.IsNginxIngress(&ings.Items[i]) {
@@ -1857,7 +3062,7 @@ func (lbc *LoadBalancerController) getMinionsForMaster(master *configs.IngressEx
 			continue
 		}
 
-		uniquePaths := []extensions.HTTPIngressPath{}
+		uniquePaths := []networking.HTTPIngressPath{}
 		for _, path := range ings.Items[i].Spec.Rules[0].HTTP.Paths {
 			if val, ok := minionPaths[path.Path]; ok {
 				glog.Errorf("Ingress Resource %v/%v with the 'nginx.org/mergeable-ingress-type' annotation set to 'minion' cannot contain the same path as another ingress resource, %v/%v.",
@@ -1886,10 +3091,10 @@ func (lbc *LoadBalancerController) getMinionsForMaster(master *configs.IngressEx
 }
 
 // FindMasterForMinion returns a master for a given minion
-func (lbc *LoadBalancerController) FindMasterForMinion(minion *extensions.Ingress) (*extensions.Ingress, error) {
+func (lbc *LoadBalancerController) FindMasterForMinion(minion *networking.Ingress) (*networking.Ingress, error) {
 	ings, err := lbc.ingressLister.List()
 	if err != nil {
-		return &extensions.Ingress{}, err
+		return &networking.Ingress{}, err
 	}
 
 	for i := range ings.Items {
@@ -1912,7 +3117,7 @@ func (lbc *LoadBalancerController) FindMasterForMinion(minion *extensions.Ingres
 	return nil, err
 }
 
-func (lbc *LoadBalancerController) createMergableIngresses(master *extensions.Ingress) (*configs.MergeableIngresses, error) {
+func (lbc *LoadBalancerController) createMergableIngresses(master *networking.Ingress) (*configs.MergeableIngresses, error) {
 	mergeableIngresses := configs.MergeableIngresses{}
 
 	if len(master.Spec.Rules) != 1 {
@@ -1920,7 +3125,7 @@ func (lbc *LoadBalancerController) createMergableIngresses(master *extensions.In
 		return &mergeableIngresses, err
 	}
 
-	var empty extensions.HTTPIngressRuleValue
+	var empty networking.HTTPIngressRuleValue
 	if master.Spec.Rules[0].HTTP != nil {
 		if master.Spec.Rules[0].HTTP != &empty {
 			if len(master.Spec.Rules[0].HTTP.Paths) != 0 {
@@ -1931,11 +3136,14 @@ func (lbc *LoadBalancerController) createMergableIngresses(master *extensions.In
 	}
 
 	// Makes sure there is an empty path assigned to a master, to allow for lbc.createIngress() to pass
-	master.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{
-		Paths: []extensions.HTTPIngressPath{},
+	master.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+		Paths: []networking.HTTPIngressPath{},
 	}
 
 	masterIngEx, err := lbc.createIngress(master)
+	if isInvalidAnnotationsError(err) {
+		return &mergeableIngresses, err
+	}
 	if err != nil {
 		err := fmt.Errorf("Error creating Ingress Resource %v/%v: %v", master.Namespace, master.Name, err)
 		return &mergeableIngresses, err
@@ -1958,7 +3166,7 @@ func (lbc *LoadBalancerController) isWallarmTarantoolService(svc *api_v1.Service
 }
 
 func (lbc *LoadBalancerController) enqueueEndpointsForService(svc *api_v1.Service) {
-	endp, err := lbc.endpointLister.GetServiceEndpoints(svc)
+	endp, err := lbc.getServiceEndpoints(svc)
 
 	if err != nil {
 		glog.V(3).Infof("ignoring service %v: %v", svc.Name, err)
@@ -1968,6 +3176,56 @@ func (lbc *LoadBalancerController) enqueueEndpointsForService(svc *api_v1.Servic
 	lbc.syncQueue.Enqueue(&endp)
 }
 
+// enqueueEndpointsForEndpointSlice enqueues the endpoints of the service of the EndpointSlice. The endpoints of
+// a service are always synced as a whole, because they are aggregated from all slices of the service.
+func (lbc *LoadBalancerController) enqueueEndpointsForEndpointSlice(slice *discovery_v1beta1.EndpointSlice) {
+	svcName, exists := slice.Labels[discovery_v1beta1.LabelServiceName]
+	if !exists {
+		glog.V(3).Infof("Ignoring EndpointSlice %v/%v without the %v label", slice.Namespace, slice.Name, discovery_v1beta1.LabelServiceName)
+		return
+	}
+
+	lbc.AddSyncQueue(&api_v1.Endpoints{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: slice.Namespace,
+			Name:      svcName,
+		},
+	})
+}
+
+// getServiceEndpoints returns the endpoints of the service either from the Endpoints resource or
+// from the EndpointSlice resources of the service.
+func (lbc *LoadBalancerController) getServiceEndpoints(svc *api_v1.Service) (api_v1.Endpoints, error) {
+	if lbc.useEndpointSlices {
+		return lbc.endpointSliceLister.GetServiceEndpoints(svc)
+	}
+	return lbc.endpointLister.GetServiceEndpoints(svc)
+}
+
+// getEndpointsByKey returns the endpoints of the service with the given key. For the EndpointSlice resources,
+// the endpoints exist if the service exists. A service without slices, for example, after its last slice
+// was deleted, has no endpoints, so that the upstreams of the service are updated with no servers.
+func (lbc *LoadBalancerController) getEndpointsByKey(key string) (interface{}, bool, error) {
+	if !lbc.useEndpointSlices {
+		return lbc.endpointLister.GetByKey(key)
+	}
+
+	obj, exists, err := lbc.svcLister.GetByKey(key)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	svc := obj.(*api_v1.Service)
+	slices, err := lbc.endpointSliceLister.getServiceEndpointSlices(svc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	endps := aggregateEndpointSlices(svc.Namespace, svc.Name, slices)
+
+	return &endps, true, nil
+}
+
 func (lbc *LoadBalancerController) getServiceForEndpoints(endp *api_v1.Endpoints) *api_v1.Service {
 	svcKey := endp.GetNamespace() + "/" + endp.GetName()
 	svcObj, svcExists, err := lbc.svcLister.GetByKey(svcKey)
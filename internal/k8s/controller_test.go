@@ -14,13 +14,14 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	v1 "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 )
 
 func TestIsNginxIngress(t *testing.T) {
@@ -28,7 +29,7 @@ func TestIsNginxIngress(t *testing.T) {
 
 	var testsWithoutIngressClassOnly = []struct {
 		lbc      *LoadBalancerController
-		ing      *extensions.Ingress
+		ing      *networking.Ingress
 		expected bool
 	}{
 		{
@@ -37,7 +38,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: false,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{ingressClassKey: ""},
 				},
@@ -50,7 +51,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: false,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{ingressClassKey: "gce"},
 				},
@@ -63,7 +64,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: false,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{ingressClassKey: ingressClass},
 				},
@@ -76,7 +77,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: false,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{},
 				},
@@ -87,7 +88,7 @@ func TestIsNginxIngress(t *testing.T) {
 
 	var testsWithIngressClassOnly = []struct {
 		lbc      *LoadBalancerController
-		ing      *extensions.Ingress
+		ing      *networking.Ingress
 		expected bool
 	}{
 		{
@@ -96,7 +97,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: true,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{ingressClassKey: ""},
 				},
@@ -109,7 +110,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: true,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{ingressClassKey: "gce"},
 				},
@@ -122,7 +123,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: true,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{ingressClassKey: ingressClass},
 				},
@@ -135,7 +136,7 @@ func TestIsNginxIngress(t *testing.T) {
 				useIngressClassOnly: true,
 				metricsCollector:    collectors.NewControllerFakeCollector(),
 			},
-			&extensions.Ingress{
+			&networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Annotations: map[string]string{},
 				},
@@ -168,6 +169,196 @@ func TestIsNginxIngress(t *testing.T) {
 
 }
 
+func TestIsNginxIngressWithIngressClassName(t *testing.T) {
+	coreGroup := ""
+	otherGroup := "example.com"
+	namespaceScope := networking.IngressClassParametersReferenceScopeNamespace
+	clusterScope := networking.IngressClassParametersReferenceScopeCluster
+	namespace := "nginx-ingress"
+	otherNamespace := "default"
+
+	ingressClassLister := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	classes := []*networking.IngressClass{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "nginx"},
+			Spec:       networking.IngressClassSpec{Controller: ingressControllerName},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "other-controller"},
+			Spec:       networking.IngressClassSpec{Controller: "example.com/ingress-controller"},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "nginx-internal"},
+			Spec:       networking.IngressClassSpec{Controller: ingressControllerName},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "nginx-with-params"},
+			Spec: networking.IngressClassSpec{
+				Controller: ingressControllerName,
+				Parameters: &networking.IngressClassParametersReference{
+					APIGroup:  &coreGroup,
+					Kind:      "ConfigMap",
+					Name:      "nginx-config",
+					Scope:     &namespaceScope,
+					Namespace: &namespace,
+				},
+			},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "nginx-with-other-params"},
+			Spec: networking.IngressClassSpec{
+				Controller: ingressControllerName,
+				Parameters: &networking.IngressClassParametersReference{
+					APIGroup:  &otherGroup,
+					Kind:      "ConfigMap",
+					Name:      "nginx-config",
+					Scope:     &namespaceScope,
+					Namespace: &namespace,
+				},
+			},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "nginx-with-params-in-other-namespace"},
+			Spec: networking.IngressClassSpec{
+				Controller: ingressControllerName,
+				Parameters: &networking.IngressClassParametersReference{
+					Kind:      "ConfigMap",
+					Name:      "nginx-config",
+					Scope:     &namespaceScope,
+					Namespace: &otherNamespace,
+				},
+			},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "nginx-with-cluster-params"},
+			Spec: networking.IngressClassSpec{
+				Controller: ingressControllerName,
+				Parameters: &networking.IngressClassParametersReference{
+					Kind:  "ConfigMap",
+					Name:  "nginx-config",
+					Scope: &clusterScope,
+				},
+			},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "other-controller-with-params"},
+			Spec: networking.IngressClassSpec{
+				Controller: "example.com/ingress-controller",
+				Parameters: &networking.IngressClassParametersReference{
+					Kind:      "ConfigMap",
+					Name:      "nginx-config",
+					Scope:     &namespaceScope,
+					Namespace: &namespace,
+				},
+			},
+		},
+	}
+	for _, class := range classes {
+		err := ingressClassLister.Add(class)
+		if err != nil {
+			t.Fatalf("Error adding IngressClass to the lister: %v", err)
+		}
+	}
+
+	lbc := &LoadBalancerController{
+		ingressClass:               "nginx",
+		useIngressClassOnly:        true,
+		isIngressClassAPIAvailable: true,
+		ingressClassLister:         ingressClassLister,
+		nginxConfigMapNamespace:    "nginx-ingress",
+		nginxConfigMapName:         "nginx-config",
+	}
+
+	tests := []struct {
+		className string
+		expected  bool
+		msg       string
+	}{
+		{
+			className: "nginx",
+			expected:  true,
+			msg:       "class of the ingress controller",
+		},
+		{
+			className: "other-controller",
+			expected:  false,
+			msg:       "class of another controller",
+		},
+		{
+			className: "nginx-internal",
+			expected:  false,
+			msg:       "class without parameters with a different name",
+		},
+		{
+			className: "nginx-with-params",
+			expected:  true,
+			msg:       "class with parameters that reference the configmap",
+		},
+		{
+			className: "nginx-with-other-params",
+			expected:  false,
+			msg:       "class with parameters that reference another resource",
+		},
+		{
+			className: "nginx-with-params-in-other-namespace",
+			expected:  false,
+			msg:       "class with parameters that reference a configmap in another namespace",
+		},
+		{
+			className: "nginx-with-cluster-params",
+			expected:  false,
+			msg:       "class with cluster-scoped parameters",
+		},
+		{
+			className: "other-controller-with-params",
+			expected:  false,
+			msg:       "class of another controller with parameters that reference the configmap",
+		},
+		{
+			className: "missing",
+			expected:  false,
+			msg:       "missing class",
+		},
+	}
+
+	for _, test := range tests {
+		ing := &networking.Ingress{
+			Spec: networking.IngressSpec{
+				IngressClassName: &test.className,
+			},
+		}
+
+		result := lbc.IsNginxIngress(ing)
+		if result != test.expected {
+			t.Errorf("IsNginxIngress() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+
+	className := "nginx"
+	ing := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Annotations: map[string]string{ingressClassKey: "gce"},
+		},
+		Spec: networking.IngressSpec{
+			IngressClassName: &className,
+		},
+	}
+	if lbc.IsNginxIngress(ing) {
+		t.Errorf("IsNginxIngress() returned true for an Ingress with the class annotation of another controller")
+	}
+
+	lbc.isIngressClassAPIAvailable = false
+	className = "nginx-internal"
+	ing = &networking.Ingress{
+		Spec: networking.IngressSpec{
+			IngressClassName: &className,
+		},
+	}
+	if lbc.IsNginxIngress(ing) {
+		t.Errorf("IsNginxIngress() returned true for an Ingress with a different class when the IngressClass API is not available")
+	}
+}
+
 func TestCreateMergableIngresses(t *testing.T) {
 	cafeMaster, coffeeMinion, teaMinion, lbc := getMergableDefaults()
 
@@ -223,15 +414,15 @@ func TestCreateMergableIngressesInvalidMaster(t *testing.T) {
 	cafeMaster, _, _, lbc := getMergableDefaults()
 
 	// Test Error when Master has a Path
-	cafeMaster.Spec.Rules = []extensions.IngressRule{
+	cafeMaster.Spec.Rules = []networking.IngressRule{
 		{
 			Host: "ok.com",
-			IngressRuleValue: extensions.IngressRuleValue{
-				HTTP: &extensions.HTTPIngressRuleValue{
-					Paths: []extensions.HTTPIngressPath{
+			IngressRuleValue: networking.IngressRuleValue{
+				HTTP: &networking.HTTPIngressRuleValue{
+					Paths: []networking.HTTPIngressPath{
 						{
 							Path: "/coffee",
-							Backend: extensions.IngressBackend{
+							Backend: networking.IngressBackend{
 								ServiceName: "coffee-svc",
 								ServicePort: intstr.IntOrString{
 									StrVal: "80",
@@ -259,8 +450,8 @@ func TestFindMasterForMinion(t *testing.T) {
 	cafeMaster, coffeeMinion, teaMinion, lbc := getMergableDefaults()
 
 	// Makes sure there is an empty path assigned to a master, to allow for lbc.createIngress() to pass
-	cafeMaster.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{
-		Paths: []extensions.HTTPIngressPath{},
+	cafeMaster.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+		Paths: []networking.HTTPIngressPath{},
 	}
 
 	err := lbc.ingressLister.Add(&cafeMaster)
@@ -295,6 +486,56 @@ func TestFindMasterForMinion(t *testing.T) {
 	}
 }
 
+func TestSyncIngMinionWithInvalidAnnotationsInStrictMode(t *testing.T) {
+	cafeMaster, coffeeMinion, _, lbc := getMergableDefaults()
+
+	lbc.areAnnotationsStrict = true
+	recorder := record.NewFakeRecorder(10)
+	lbc.recorder = recorder
+	lbc.syncQueue = newTaskQueue(func(task) {})
+
+	// Makes sure there is an empty path assigned to a master, to allow for lbc.createIngress() to pass
+	cafeMaster.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+		Paths: []networking.HTTPIngressPath{},
+	}
+	coffeeMinion.Annotations["nginx.org/proxy-buffering"] = "maybe"
+
+	err := lbc.ingressLister.Add(&cafeMaster)
+	if err != nil {
+		t.Errorf("Error adding Ingress %v to the ingress lister: %v", &cafeMaster.Name, err)
+	}
+
+	err = lbc.ingressLister.Add(&coffeeMinion)
+	if err != nil {
+		t.Errorf("Error adding Ingress %v to the ingress lister: %v", &coffeeMinion.Name, err)
+	}
+
+	_, err = lbc.createIngress(&coffeeMinion)
+	if !isInvalidAnnotationsError(err) {
+		t.Errorf("createIngress() returned %v but expected an invalidAnnotationsError", err)
+	}
+
+	lbc.syncIngMinion(task{Kind: ingressMinion, Key: "default/coffee-minion"})
+
+	expectedEvents := []string{
+		`Warning InvalidAnnotation default/coffee-minion has an invalid annotation: metadata.annotations[nginx.org/proxy-buffering]: Invalid value: "maybe": must be a boolean`,
+		`Warning Rejected default/coffee-minion was rejected: Ingress has invalid annotations: metadata.annotations[nginx.org/proxy-buffering]: Invalid value: "maybe": must be a boolean`,
+	}
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	if !reflect.DeepEqual(events, expectedEvents) {
+		t.Errorf("syncIngMinion() emitted the events %q but expected %q", events, expectedEvents)
+	}
+
+	// the minion was not applied before, so the master is not enqueued
+	if lbc.syncQueue.queue.Len() != 0 {
+		t.Errorf("syncIngMinion() enqueued %d tasks but expected none", lbc.syncQueue.queue.Len())
+	}
+}
+
 func TestFindMasterForMinionNoMaster(t *testing.T) {
 	_, coffeeMinion, teaMinion, lbc := getMergableDefaults()
 
@@ -325,11 +566,11 @@ func TestFindMasterForMinionInvalidMinion(t *testing.T) {
 	cafeMaster, coffeeMinion, _, lbc := getMergableDefaults()
 
 	// Makes sure there is an empty path assigned to a master, to allow for lbc.createIngress() to pass
-	cafeMaster.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{
-		Paths: []extensions.HTTPIngressPath{},
+	cafeMaster.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+		Paths: []networking.HTTPIngressPath{},
 	}
 
-	coffeeMinion.Spec.Rules = []extensions.IngressRule{
+	coffeeMinion.Spec.Rules = []networking.IngressRule{
 		{
 			Host: "ok.com",
 		},
@@ -358,8 +599,8 @@ func TestGetMinionsForMaster(t *testing.T) {
 	cafeMaster, coffeeMinion, teaMinion, lbc := getMergableDefaults()
 
 	// Makes sure there is an empty path assigned to a master, to allow for lbc.createIngress() to pass
-	cafeMaster.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{
-		Paths: []extensions.HTTPIngressPath{},
+	cafeMaster.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+		Paths: []networking.HTTPIngressPath{},
 	}
 
 	err := lbc.ingressLister.Add(&cafeMaster)
@@ -416,11 +657,11 @@ func TestGetMinionsForMasterInvalidMinion(t *testing.T) {
 	cafeMaster, coffeeMinion, teaMinion, lbc := getMergableDefaults()
 
 	// Makes sure there is an empty path assigned to a master, to allow for lbc.createIngress() to pass
-	cafeMaster.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{
-		Paths: []extensions.HTTPIngressPath{},
+	cafeMaster.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+		Paths: []networking.HTTPIngressPath{},
 	}
 
-	teaMinion.Spec.Rules = []extensions.IngressRule{
+	teaMinion.Spec.Rules = []networking.IngressRule{
 		{
 			Host: "ok.com",
 		},
@@ -480,13 +721,13 @@ func TestGetMinionsForMasterConflictingPaths(t *testing.T) {
 	cafeMaster, coffeeMinion, teaMinion, lbc := getMergableDefaults()
 
 	// Makes sure there is an empty path assigned to a master, to allow for lbc.createIngress() to pass
-	cafeMaster.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{
-		Paths: []extensions.HTTPIngressPath{},
+	cafeMaster.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+		Paths: []networking.HTTPIngressPath{},
 	}
 
-	coffeeMinion.Spec.Rules[0].HTTP.Paths = append(coffeeMinion.Spec.Rules[0].HTTP.Paths, extensions.HTTPIngressPath{
+	coffeeMinion.Spec.Rules[0].HTTP.Paths = append(coffeeMinion.Spec.Rules[0].HTTP.Paths, networking.HTTPIngressPath{
 		Path: "/tea",
-		Backend: extensions.IngressBackend{
+		Backend: networking.IngressBackend{
 			ServiceName: "tea-svc",
 			ServicePort: intstr.IntOrString{
 				StrVal: "80",
@@ -546,8 +787,8 @@ func TestGetMinionsForMasterConflictingPaths(t *testing.T) {
 	}
 }
 
-func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion extensions.Ingress, lbc LoadBalancerController) {
-	cafeMaster = extensions.Ingress{
+func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion networking.Ingress, lbc LoadBalancerController) {
+	cafeMaster = networking.Ingress{
 		TypeMeta: meta_v1.TypeMeta{},
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "cafe-master",
@@ -557,16 +798,16 @@ func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion extensions.Ingre
 				"nginx.org/mergeable-ingress-type": "master",
 			},
 		},
-		Spec: extensions.IngressSpec{
-			Rules: []extensions.IngressRule{
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
 				{
 					Host: "ok.com",
 				},
 			},
 		},
-		Status: extensions.IngressStatus{},
+		Status: networking.IngressStatus{},
 	}
-	coffeeMinion = extensions.Ingress{
+	coffeeMinion = networking.Ingress{
 		TypeMeta: meta_v1.TypeMeta{},
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "coffee-minion",
@@ -576,16 +817,16 @@ func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion extensions.Ingre
 				"nginx.org/mergeable-ingress-type": "minion",
 			},
 		},
-		Spec: extensions.IngressSpec{
-			Rules: []extensions.IngressRule{
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
 				{
 					Host: "ok.com",
-					IngressRuleValue: extensions.IngressRuleValue{
-						HTTP: &extensions.HTTPIngressRuleValue{
-							Paths: []extensions.HTTPIngressPath{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
 								{
 									Path: "/coffee",
-									Backend: extensions.IngressBackend{
+									Backend: networking.IngressBackend{
 										ServiceName: "coffee-svc",
 										ServicePort: intstr.IntOrString{
 											StrVal: "80",
@@ -598,9 +839,9 @@ func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion extensions.Ingre
 				},
 			},
 		},
-		Status: extensions.IngressStatus{},
+		Status: networking.IngressStatus{},
 	}
-	teaMinion = extensions.Ingress{
+	teaMinion = networking.Ingress{
 		TypeMeta: meta_v1.TypeMeta{},
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "tea-minion",
@@ -610,13 +851,13 @@ func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion extensions.Ingre
 				"nginx.org/mergeable-ingress-type": "minion",
 			},
 		},
-		Spec: extensions.IngressSpec{
-			Rules: []extensions.IngressRule{
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
 				{
 					Host: "ok.com",
-					IngressRuleValue: extensions.IngressRuleValue{
-						HTTP: &extensions.HTTPIngressRuleValue{
-							Paths: []extensions.HTTPIngressPath{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
 								{
 									Path: "/tea",
 								},
@@ -626,7 +867,7 @@ func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion extensions.Ingre
 				},
 			},
 		},
-		Status: extensions.IngressStatus{},
+		Status: networking.IngressStatus{},
 	}
 
 	ingExMap := make(map[string]*configs.IngressEx)
@@ -653,10 +894,10 @@ func getMergableDefaults() (cafeMaster, coffeeMinion, teaMinion extensions.Ingre
 	}
 	lbc.svcLister, _ = cache.NewInformer(
 		cache.NewListWatchFromClient(lbc.client.ExtensionsV1beta1().RESTClient(), "services", "default", fields.Everything()),
-		&extensions.Ingress{}, time.Duration(1), nil)
+		&networking.Ingress{}, time.Duration(1), nil)
 	lbc.ingressLister.Store, _ = cache.NewInformer(
 		cache.NewListWatchFromClient(lbc.client.ExtensionsV1beta1().RESTClient(), "ingresses", "default", fields.Everything()),
-		&extensions.Ingress{}, time.Duration(1), nil)
+		&networking.Ingress{}, time.Duration(1), nil)
 
 	return
 }
@@ -895,10 +1136,170 @@ func TestGetServicePortForIngressPort(t *testing.T) {
 	}
 }
 
+func TestGetEndpointsForSubselector(t *testing.T) {
+	lbc := LoadBalancerController{
+		endpointLister: storeToEndpointLister{Store: cache.NewStore(cache.MetaNamespaceKeyFunc)},
+		podLister:      cache.NewStore(cache.MetaNamespaceKeyFunc),
+	}
+
+	svc := &v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee-svc",
+			Namespace: "default",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "coffee-v1",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "coffee", "version": "v1"},
+			},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "coffee-v2",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "coffee", "version": "v2"},
+			},
+		},
+	}
+	for _, pod := range pods {
+		err := lbc.podLister.Add(pod)
+		if err != nil {
+			t.Fatalf("Failed to add a pod to the store: %v", err)
+		}
+	}
+
+	endpoints := &v1.Endpoints{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee-svc",
+			Namespace: "default",
+		},
+		Subsets: []v1.EndpointSubset{
+			{
+				Addresses: []v1.EndpointAddress{
+					{
+						IP:        "10.0.0.1",
+						TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "coffee-v1", Namespace: "default"},
+					},
+					{
+						IP:        "10.0.0.2",
+						TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "coffee-v2", Namespace: "default"},
+					},
+					{
+						IP: "10.0.0.3",
+					},
+				},
+				Ports: []v1.EndpointPort{
+					{
+						Port: 8080,
+					},
+				},
+			},
+		},
+	}
+	err := lbc.endpointLister.Add(endpoints)
+	if err != nil {
+		t.Fatalf("Failed to add endpoints to the store: %v", err)
+	}
+
+	backend := &networking.IngressBackend{
+		ServiceName: "coffee-svc",
+		ServicePort: intstr.FromInt(80),
+	}
+
+	tests := []struct {
+		subselector map[string]string
+		expected    []string
+		msg         string
+	}{
+		{
+			subselector: map[string]string{"version": "v1"},
+			expected:    []string{"10.0.0.1:8080"},
+			msg:         "subselector matches one pod",
+		},
+		{
+			subselector: map[string]string{"app": "coffee"},
+			expected:    []string{"10.0.0.1:8080", "10.0.0.2:8080"},
+			msg:         "subselector matches all pods",
+		},
+		{
+			subselector: map[string]string{"version": "v3"},
+			expected:    nil,
+			msg:         "subselector matches no pods",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := lbc.getEndpointsForSubselector(backend, svc, test.subselector)
+		if err != nil {
+			t.Errorf("getEndpointsForSubselector() returned an unexpected error %v for the case of %s", err, test.msg)
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("getEndpointsForSubselector() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+
+	backend.ServicePort = intstr.FromInt(81)
+	_, err = lbc.getEndpointsForSubselector(backend, svc, map[string]string{"version": "v1"})
+	if err == nil {
+		t.Errorf("getEndpointsForSubselector() returned no error for a non-existing port")
+	}
+}
+
+func TestHasUpstreamWithSubselectorForService(t *testing.T) {
+	upstreams := []conf_v1alpha1.Upstream{
+		{
+			Name:    "tea",
+			Service: "tea-svc",
+		},
+		{
+			Name:        "coffee-v2",
+			Service:     "coffee-svc",
+			Subselector: map[string]string{"version": "v2"},
+		},
+	}
+
+	tests := []struct {
+		serviceName string
+		expected    bool
+	}{
+		{
+			serviceName: "coffee-svc",
+			expected:    true,
+		},
+		{
+			serviceName: "tea-svc",
+			expected:    false,
+		},
+		{
+			serviceName: "juice-svc",
+			expected:    false,
+		},
+	}
+
+	for _, test := range tests {
+		result := hasUpstreamWithSubselectorForService(upstreams, test.serviceName)
+		if result != test.expected {
+			t.Errorf("hasUpstreamWithSubselectorForService() returned %v but expected %v for the service %s", result, test.expected, test.serviceName)
+		}
+	}
+}
+
 func TestFindIngressesForSecret(t *testing.T) {
 	testCases := []struct {
 		secret         v1.Secret
-		ingress        extensions.Ingress
+		ingress        networking.Ingress
 		expectedToFind bool
 		desc           string
 	}{
@@ -909,13 +1310,13 @@ func TestFindIngressesForSecret(t *testing.T) {
 					Namespace: "namespace-1",
 				},
 			},
-			ingress: extensions.Ingress{
+			ingress: networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Name:      "my-ingress",
 					Namespace: "namespace-1",
 				},
-				Spec: extensions.IngressSpec{
-					TLS: []extensions.IngressTLS{
+				Spec: networking.IngressSpec{
+					TLS: []networking.IngressTLS{
 						{
 							SecretName: "my-tls-secret",
 						},
@@ -932,13 +1333,13 @@ func TestFindIngressesForSecret(t *testing.T) {
 					Namespace: "namespace-1",
 				},
 			},
-			ingress: extensions.Ingress{
+			ingress: networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Name:      "my-ingress",
 					Namespace: "namespace-2",
 				},
-				Spec: extensions.IngressSpec{
-					TLS: []extensions.IngressTLS{
+				Spec: networking.IngressSpec{
+					TLS: []networking.IngressTLS{
 						{
 							SecretName: "my-tls-secret",
 						},
@@ -955,7 +1356,7 @@ func TestFindIngressesForSecret(t *testing.T) {
 					Namespace: "namespace-1",
 				},
 			},
-			ingress: extensions.Ingress{
+			ingress: networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Name:      "my-ingress",
 					Namespace: "namespace-1",
@@ -974,7 +1375,7 @@ func TestFindIngressesForSecret(t *testing.T) {
 					Namespace: "namespace-1",
 				},
 			},
-			ingress: extensions.Ingress{
+			ingress: networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Name:      "my-ingress",
 					Namespace: "namespace-2",
@@ -997,7 +1398,7 @@ func TestFindIngressesForSecret(t *testing.T) {
 				t.Fatalf("templateExecutor could not start: %v", err)
 			}
 
-			templateExecutorV2, err := version2.NewTemplateExecutor("../configs/version2/nginx-plus.virtualserver.tmpl")
+			templateExecutorV2, err := version2.NewTemplateExecutor("../configs/version2/nginx-plus.virtualserver.tmpl", "../configs/version2/nginx-plus.transportserver.tmpl")
 			if err != nil {
 				t.Fatalf("templateExecutorV2 could not start: %v", err)
 			}
@@ -1015,7 +1416,7 @@ func TestFindIngressesForSecret(t *testing.T) {
 
 			lbc.ingressLister.Store, _ = cache.NewInformer(
 				cache.NewListWatchFromClient(lbc.client.ExtensionsV1beta1().RESTClient(), "ingresses", "default", fields.Everything()),
-				&extensions.Ingress{}, time.Duration(1), nil)
+				&networking.Ingress{}, time.Duration(1), nil)
 
 			lbc.secretLister.Store, lbc.secretController = cache.NewInformer(
 				cache.NewListWatchFromClient(lbc.client.CoreV1().RESTClient(), "secrets", "default", fields.Everything()),
@@ -1068,7 +1469,7 @@ func TestFindIngressesForSecret(t *testing.T) {
 func TestFindIngressesForSecretWithMinions(t *testing.T) {
 	testCases := []struct {
 		secret         v1.Secret
-		ingress        extensions.Ingress
+		ingress        networking.Ingress
 		expectedToFind bool
 		desc           string
 	}{
@@ -1079,7 +1480,7 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 					Namespace: "default",
 				},
 			},
-			ingress: extensions.Ingress{
+			ingress: networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Name:      "cafe-ingress-tea-minion",
 					Namespace: "default",
@@ -1089,16 +1490,16 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 						configs.JWTKeyAnnotation:           "my-jwk-secret",
 					},
 				},
-				Spec: extensions.IngressSpec{
-					Rules: []extensions.IngressRule{
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
 						{
 							Host: "cafe.example.com",
-							IngressRuleValue: extensions.IngressRuleValue{
-								HTTP: &extensions.HTTPIngressRuleValue{
-									Paths: []extensions.HTTPIngressPath{
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{
 										{
 											Path: "/tea",
-											Backend: extensions.IngressBackend{
+											Backend: networking.IngressBackend{
 												ServiceName: "tea-svc",
 												ServicePort: intstr.FromString("80"),
 											},
@@ -1120,7 +1521,7 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 					Namespace: "namespace-1",
 				},
 			},
-			ingress: extensions.Ingress{
+			ingress: networking.Ingress{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Name:      "cafe-ingress-tea-minion",
 					Namespace: "default",
@@ -1130,16 +1531,16 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 						configs.JWTKeyAnnotation:           "my-jwk-secret",
 					},
 				},
-				Spec: extensions.IngressSpec{
-					Rules: []extensions.IngressRule{
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
 						{
 							Host: "cafe.example.com",
-							IngressRuleValue: extensions.IngressRuleValue{
-								HTTP: &extensions.HTTPIngressRuleValue{
-									Paths: []extensions.HTTPIngressPath{
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{
 										{
 											Path: "/tea",
-											Backend: extensions.IngressBackend{
+											Backend: networking.IngressBackend{
 												ServiceName: "tea-svc",
 												ServicePort: intstr.FromString("80"),
 											},
@@ -1156,7 +1557,7 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 		},
 	}
 
-	master := extensions.Ingress{
+	master := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "cafe-ingress-master",
 			Namespace: "default",
@@ -1165,13 +1566,13 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 				"nginx.org/mergeable-ingress-type": "master",
 			},
 		},
-		Spec: extensions.IngressSpec{
-			Rules: []extensions.IngressRule{
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
 				{
 					Host: "cafe.example.com",
-					IngressRuleValue: extensions.IngressRuleValue{
-						HTTP: &extensions.HTTPIngressRuleValue{ // HTTP must not be nil for Master
-							Paths: []extensions.HTTPIngressPath{},
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{ // HTTP must not be nil for Master
+							Paths: []networking.HTTPIngressPath{},
 						},
 					},
 				},
@@ -1188,7 +1589,7 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 				t.Fatalf("templateExecutor could not start: %v", err)
 			}
 
-			templateExecutorV2, err := version2.NewTemplateExecutor("../configs/version2/nginx-plus.virtualserver.tmpl")
+			templateExecutorV2, err := version2.NewTemplateExecutor("../configs/version2/nginx-plus.virtualserver.tmpl", "../configs/version2/nginx-plus.transportserver.tmpl")
 			if err != nil {
 				t.Fatalf("templateExecutorV2 could not start: %v", err)
 			}
@@ -1206,7 +1607,7 @@ func TestFindIngressesForSecretWithMinions(t *testing.T) {
 
 			lbc.ingressLister.Store, _ = cache.NewInformer(
 				cache.NewListWatchFromClient(lbc.client.ExtensionsV1beta1().RESTClient(), "ingresses", "default", fields.Everything()),
-				&extensions.Ingress{}, time.Duration(1), nil)
+				&networking.Ingress{}, time.Duration(1), nil)
 
 			lbc.secretLister.Store, lbc.secretController = cache.NewInformer(
 				cache.NewListWatchFromClient(lbc.client.CoreV1().RESTClient(), "secrets", "default", fields.Everything()),
@@ -1437,9 +1838,42 @@ func TestFindVirtualServersForSecret(t *testing.T) {
 		},
 	}
 
-	virtualServers := []*conf_v1alpha1.VirtualServer{&vs1, &vs2, &vs3, &vs4, &vs5}
+	vs6 := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-6",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.VirtualServerSpec{
+			TLS: &conf_v1alpha1.TLS{
+				Secret: "some-secret",
+				ClientCertificate: &conf_v1alpha1.ClientCertificate{
+					Secret: "test-secret",
+				},
+			},
+		},
+	}
+
+	vs7 := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-7",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.VirtualServerSpec{
+			Upstreams: []conf_v1alpha1.Upstream{
+				{
+					Name: "backend",
+					TLS: &conf_v1alpha1.UpstreamTLS{
+						Enable:            true,
+						TrustedCertSecret: "test-secret",
+					},
+				},
+			},
+		},
+	}
+
+	virtualServers := []*conf_v1alpha1.VirtualServer{&vs1, &vs2, &vs3, &vs4, &vs5, &vs6, &vs7}
 
-	expected := []*conf_v1alpha1.VirtualServer{&vs4}
+	expected := []*conf_v1alpha1.VirtualServer{&vs4, &vs6, &vs7}
 
 	result := findVirtualServersForSecret(virtualServers, "ns-1", "test-secret")
 	if !reflect.DeepEqual(result, expected) {
@@ -1447,6 +1881,190 @@ func TestFindVirtualServersForSecret(t *testing.T) {
 	}
 }
 
+func TestFindVirtualServerRoutesForSecret(t *testing.T) {
+	vsr1 := conf_v1alpha1.VirtualServerRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vsr-1",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.VirtualServerRouteSpec{
+			Upstreams: []conf_v1alpha1.Upstream{
+				{
+					Name: "backend",
+				},
+			},
+		},
+	}
+	vsr2 := conf_v1alpha1.VirtualServerRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vsr-2",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.VirtualServerRouteSpec{
+			Upstreams: []conf_v1alpha1.Upstream{
+				{
+					Name: "backend",
+					TLS: &conf_v1alpha1.UpstreamTLS{
+						Enable: true,
+						Secret: "test-secret",
+					},
+				},
+			},
+		},
+	}
+	vsr3 := conf_v1alpha1.VirtualServerRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vsr-3",
+			Namespace: "ns-2",
+		},
+		Spec: conf_v1alpha1.VirtualServerRouteSpec{
+			Upstreams: []conf_v1alpha1.Upstream{
+				{
+					Name: "backend",
+					TLS: &conf_v1alpha1.UpstreamTLS{
+						Enable: true,
+						Secret: "test-secret",
+					},
+				},
+			},
+		},
+	}
+
+	virtualServerRoutes := []*conf_v1alpha1.VirtualServerRoute{&vsr1, &vsr2, &vsr3}
+
+	expected := []*conf_v1alpha1.VirtualServerRoute{&vsr2}
+
+	result := findVirtualServerRoutesForSecret(virtualServerRoutes, "ns-1", "test-secret")
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("findVirtualServerRoutesForSecret returned %v but expected %v", result, expected)
+	}
+}
+
+func TestIsSecretReferencedByProxySSL(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    bool
+		msg         string
+	}{
+		{
+			annotations: map[string]string{},
+			expected:    false,
+			msg:         "no annotations",
+		},
+		{
+			annotations: map[string]string{"nginx.org/proxy-ssl-trusted-cert-secret": "test-secret"},
+			expected:    true,
+			msg:         "trusted cert secret",
+		},
+		{
+			annotations: map[string]string{"nginx.org/proxy-ssl-secret": "test-secret"},
+			expected:    true,
+			msg:         "client certificate secret",
+		},
+		{
+			annotations: map[string]string{"nginx.org/proxy-ssl-secret": "some-secret"},
+			expected:    false,
+			msg:         "another secret",
+		},
+	}
+
+	for _, test := range tests {
+		ing := &networking.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Annotations: test.annotations,
+			},
+		}
+
+		result := isSecretReferencedByProxySSL(ing, "test-secret")
+		if result != test.expected {
+			t.Errorf("isSecretReferencedByProxySSL() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestFindPoliciesForSecret(t *testing.T) {
+	pol1 := &conf_v1alpha1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "pol-1",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.PolicySpec{
+			AccessControl: &conf_v1alpha1.AccessControl{
+				Allow: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+	pol2 := &conf_v1alpha1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "pol-2",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.PolicySpec{
+			JWTAuth: &conf_v1alpha1.JWTAuth{
+				Realm:  "My API",
+				Secret: "some-secret",
+			},
+		},
+	}
+	pol3 := &conf_v1alpha1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "pol-3",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.PolicySpec{
+			JWTAuth: &conf_v1alpha1.JWTAuth{
+				Realm:  "My API",
+				Secret: "test-secret",
+			},
+		},
+	}
+	pol4 := &conf_v1alpha1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "pol-4",
+			Namespace: "ns-2",
+		},
+		Spec: conf_v1alpha1.PolicySpec{
+			JWTAuth: &conf_v1alpha1.JWTAuth{
+				Realm:  "My API",
+				Secret: "test-secret",
+			},
+		},
+	}
+
+	policies := []*conf_v1alpha1.Policy{pol1, pol2, pol3, pol4}
+
+	expected := []*conf_v1alpha1.Policy{pol3}
+
+	result := findPoliciesForSecret(policies, "ns-1", "test-secret")
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("findPoliciesForSecret returned %v but expected %v", result, expected)
+	}
+}
+
+func TestRemoveDuplicateVirtualServers(t *testing.T) {
+	vs1 := &conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-1",
+			Namespace: "ns-1",
+		},
+	}
+	vs2 := &conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-1",
+			Namespace: "ns-2",
+		},
+	}
+
+	virtualServers := []*conf_v1alpha1.VirtualServer{vs1, vs2, vs1}
+
+	expected := []*conf_v1alpha1.VirtualServer{vs1, vs2}
+
+	result := removeDuplicateVirtualServers(virtualServers)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("removeDuplicateVirtualServers returned %v but expected %v", result, expected)
+	}
+}
+
 func TestFindVirtualServersForVirtualServerRoute(t *testing.T) {
 	vs1 := conf_v1alpha1.VirtualServer{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -1507,3 +2125,320 @@ func TestFindVirtualServersForVirtualServerRoute(t *testing.T) {
 		t.Errorf("findVirtualServersForVirtualServerRoute returned %v but expected %v", result, expected)
 	}
 }
+
+func TestFindVirtualServersForPolicyKey(t *testing.T) {
+	vs1 := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-1",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.VirtualServerSpec{
+			Policies: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "test-policy",
+				},
+			},
+		},
+	}
+	vs2 := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-2",
+			Namespace: "ns-2",
+		},
+		Spec: conf_v1alpha1.VirtualServerSpec{
+			Routes: []conf_v1alpha1.Route{
+				{
+					Path: "/",
+					Policies: []conf_v1alpha1.PolicyReference{
+						{
+							Name:      "test-policy",
+							Namespace: "ns-1",
+						},
+					},
+				},
+			},
+		},
+	}
+	vs3 := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-3",
+			Namespace: "ns-2",
+		},
+		Spec: conf_v1alpha1.VirtualServerSpec{
+			Policies: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "test-policy",
+				},
+			},
+			Routes: []conf_v1alpha1.Route{
+				{
+					Path:  "/",
+					Route: "ns-1/vsr-1",
+				},
+			},
+		},
+	}
+	vs4 := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vs-4",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.VirtualServerSpec{
+			Policies: []conf_v1alpha1.PolicyReference{
+				{
+					Name: "some-policy",
+				},
+			},
+		},
+	}
+	virtualServers := []*conf_v1alpha1.VirtualServer{&vs1, &vs2, &vs3, &vs4}
+
+	vsr1 := conf_v1alpha1.VirtualServerRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "vsr-1",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.VirtualServerRouteSpec{
+			Subroutes: []conf_v1alpha1.Route{
+				{
+					Path: "/",
+					Policies: []conf_v1alpha1.PolicyReference{
+						{
+							Name: "test-policy",
+						},
+					},
+				},
+			},
+		},
+	}
+	virtualServerRoutes := []*conf_v1alpha1.VirtualServerRoute{&vsr1}
+
+	expected := []*conf_v1alpha1.VirtualServer{&vs1, &vs2, &vs3}
+
+	result := findVirtualServersForPolicyKey(virtualServers, virtualServerRoutes, "ns-1/test-policy")
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("findVirtualServersForPolicyKey returned %v but expected %v", result, expected)
+	}
+}
+
+func TestFindTransportServersForService(t *testing.T) {
+	ts1 := conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ts-1",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			Upstreams: []conf_v1alpha1.TransportServerUpstream{
+				{
+					Service: "test-service",
+				},
+			},
+		},
+	}
+	ts2 := conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ts-2",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			Upstreams: []conf_v1alpha1.TransportServerUpstream{
+				{
+					Service: "some-service",
+				},
+			},
+		},
+	}
+	ts3 := conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ts-3",
+			Namespace: "ns-2",
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			Upstreams: []conf_v1alpha1.TransportServerUpstream{
+				{
+					Service: "test-service",
+				},
+			},
+		},
+	}
+	transportServers := []*conf_v1alpha1.TransportServer{&ts1, &ts2, &ts3}
+
+	service := v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: "ns-1",
+		},
+	}
+
+	expected := []*conf_v1alpha1.TransportServer{&ts1}
+
+	result := findTransportServersForService(transportServers, &service)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("findTransportServersForService returned %v but expected %v", result, expected)
+	}
+}
+
+func TestFindTransportServerHoldingListener(t *testing.T) {
+	listener := conf_v1alpha1.TransportServerListener{
+		Port:     5353,
+		Protocol: "UDP",
+	}
+	now := meta_v1.Now()
+	earlier := meta_v1.NewTime(now.Add(-time.Minute))
+
+	ts1 := conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "ts-1",
+			Namespace:         "default",
+			CreationTimestamp: now,
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			Listener: listener,
+		},
+	}
+	ts2 := conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "ts-2",
+			Namespace:         "default",
+			CreationTimestamp: earlier,
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			Listener: listener,
+		},
+	}
+	ts3 := conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "ts-3",
+			Namespace:         "default",
+			CreationTimestamp: earlier,
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			Listener: conf_v1alpha1.TransportServerListener{
+				Port:     5353,
+				Protocol: "TCP",
+			},
+		},
+	}
+	ts4 := conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "ts-0",
+			Namespace:         "default",
+			CreationTimestamp: now,
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			Listener: listener,
+		},
+	}
+
+	tests := []struct {
+		transportServers []*conf_v1alpha1.TransportServer
+		expected         *conf_v1alpha1.TransportServer
+		msg              string
+	}{
+		{
+			transportServers: []*conf_v1alpha1.TransportServer{&ts3},
+			expected:         nil,
+			msg:              "no TransportServers with the listener",
+		},
+		{
+			transportServers: []*conf_v1alpha1.TransportServer{&ts1, &ts2, &ts3},
+			expected:         &ts2,
+			msg:              "the oldest TransportServer holds the listener",
+		},
+		{
+			transportServers: []*conf_v1alpha1.TransportServer{&ts1, &ts4},
+			expected:         &ts4,
+			msg:              "the first TransportServer by key holds the listener for the same creation time",
+		},
+	}
+
+	for _, test := range tests {
+		result := findTransportServerHoldingListener(test.transportServers, "5353/UDP")
+		if result != test.expected {
+			t.Errorf("findTransportServerHoldingListener returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGetListenerKeyForTransportServer(t *testing.T) {
+	tests := []struct {
+		ts       *conf_v1alpha1.TransportServer
+		expected string
+	}{
+		{
+			ts: &conf_v1alpha1.TransportServer{
+				Spec: conf_v1alpha1.TransportServerSpec{
+					Listener: conf_v1alpha1.TransportServerListener{
+						Port:     5353,
+						Protocol: "UDP",
+					},
+				},
+			},
+			expected: "5353/UDP",
+		},
+		{
+			ts: &conf_v1alpha1.TransportServer{
+				Spec: conf_v1alpha1.TransportServerSpec{
+					Listener: conf_v1alpha1.TransportServerListener{
+						Port:     443,
+						Protocol: "TLS_PASSTHROUGH",
+					},
+					Host: "app.example.com",
+				},
+			},
+			expected: "443/TLS_PASSTHROUGH/app.example.com",
+		},
+	}
+
+	for _, test := range tests {
+		result := getListenerKeyForTransportServer(test.ts)
+		if result != test.expected {
+			t.Errorf("getListenerKeyForTransportServer() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
+
+func TestGetListenerConflictMessage(t *testing.T) {
+	holder := &conf_v1alpha1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ts-1",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		ts       *conf_v1alpha1.TransportServer
+		expected string
+	}{
+		{
+			ts: &conf_v1alpha1.TransportServer{
+				Spec: conf_v1alpha1.TransportServerSpec{
+					Listener: conf_v1alpha1.TransportServerListener{
+						Port:     5353,
+						Protocol: "UDP",
+					},
+				},
+			},
+			expected: "listener 5353/UDP is already used by TransportServer default/ts-1",
+		},
+		{
+			ts: &conf_v1alpha1.TransportServer{
+				Spec: conf_v1alpha1.TransportServerSpec{
+					Listener: conf_v1alpha1.TransportServerListener{
+						Port:     443,
+						Protocol: "TLS_PASSTHROUGH",
+					},
+					Host: "app.example.com",
+				},
+			},
+			expected: "host app.example.com is already used by TLS Passthrough TransportServer default/ts-1",
+		},
+	}
+
+	for _, test := range tests {
+		result := getListenerConflictMessage(test.ts, holder)
+		if result != test.expected {
+			t.Errorf("getListenerConflictMessage() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
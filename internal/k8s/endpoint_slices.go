@@ -0,0 +1,303 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	discovery_v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/discovery/v1beta1"
+	api_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// endpointSliceServiceIndex is the name of the index of EndpointSlices by the key of their service.
+	endpointSliceServiceIndex = "service"
+	// zoneLabel is the label of nodes and the topology key of endpoints that holds the zone.
+	zoneLabel = "topology.kubernetes.io/zone"
+	// hostnameLabel is the topology key of endpoints that holds the node name.
+	hostnameLabel = "kubernetes.io/hostname"
+)
+
+// DiscoverEndpointSliceAPI checks if the cluster serves the discovery.k8s.io EndpointSlice resources,
+// which are available in Kubernetes 1.17 or newer.
+func DiscoverEndpointSliceAPI(client discovery.DiscoveryInterface) (bool, error) {
+	resources, err := client.ServerResourcesForGroupVersion(discovery_v1beta1.SchemeGroupVersion.String())
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == "endpointslices" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// endpointSliceServiceIndexFunc indexes EndpointSlices by the namespace/name key of their service.
+func endpointSliceServiceIndexFunc(obj interface{}) ([]string, error) {
+	slice, ok := obj.(*discovery_v1beta1.EndpointSlice)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object %T", obj)
+	}
+
+	svcName, exists := slice.Labels[discovery_v1beta1.LabelServiceName]
+	if !exists {
+		return nil, nil
+	}
+
+	return []string{slice.Namespace + "/" + svcName}, nil
+}
+
+// storeToEndpointSliceLister makes an Indexer that lists EndpointSlices
+type storeToEndpointSliceLister struct {
+	cache.Indexer
+}
+
+// getServiceEndpointSlices returns the EndpointSlices of a service, sorted by name.
+func (s *storeToEndpointSliceLister) getServiceEndpointSlices(svc *api_v1.Service) ([]*discovery_v1beta1.EndpointSlice, error) {
+	objs, err := s.Indexer.ByIndex(endpointSliceServiceIndex, svc.Namespace+"/"+svc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var slices []*discovery_v1beta1.EndpointSlice
+	for _, obj := range objs {
+		slices = append(slices, obj.(*discovery_v1beta1.EndpointSlice))
+	}
+
+	sort.Slice(slices, func(i, j int) bool {
+		return slices[i].Name < slices[j].Name
+	})
+
+	return slices, nil
+}
+
+// GetServiceEndpoints returns the endpoints of a service, aggregated from its EndpointSlices.
+func (s *storeToEndpointSliceLister) GetServiceEndpoints(svc *api_v1.Service) (api_v1.Endpoints, error) {
+	slices, err := s.getServiceEndpointSlices(svc)
+	if err != nil {
+		return api_v1.Endpoints{}, err
+	}
+	if len(slices) == 0 {
+		return api_v1.Endpoints{}, fmt.Errorf("could not find endpoint slices for service: %v", svc.Name)
+	}
+
+	return aggregateEndpointSlices(svc.Namespace, svc.Name, slices), nil
+}
+
+// GetServiceEndpointsTopology returns the topology of the endpoints of a service, keyed by the IP of the endpoint.
+func (s *storeToEndpointSliceLister) GetServiceEndpointsTopology(svc *api_v1.Service) (map[string]endpointTopology, error) {
+	slices, err := s.getServiceEndpointSlices(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	return getEndpointSlicesTopology(slices), nil
+}
+
+// endpointTopology describes where an endpoint runs and which zones should consume it.
+type endpointTopology struct {
+	nodeName string
+	zone     string
+	// hints are the zones for which the EndpointSlice controller recommends the endpoint.
+	hints []string
+}
+
+// getEndpointSlicesTopology returns the topology of the endpoints of the EndpointSlices, keyed by the IP of the endpoint.
+func getEndpointSlicesTopology(slices []*discovery_v1beta1.EndpointSlice) map[string]endpointTopology {
+	topology := make(map[string]endpointTopology)
+
+	for _, slice := range slices {
+		if slice.AddressType == discovery_v1beta1.AddressTypeFQDN {
+			continue
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if len(endpoint.Addresses) == 0 {
+				continue
+			}
+
+			t := endpointTopology{
+				nodeName: getEndpointNodeName(endpoint),
+				zone:     endpoint.Topology[zoneLabel],
+			}
+			if endpoint.Hints != nil {
+				for _, zone := range endpoint.Hints.ForZones {
+					t.hints = append(t.hints, zone.Name)
+				}
+			}
+
+			topology[endpoint.Addresses[0]] = t
+		}
+	}
+
+	return topology
+}
+
+func getEndpointNodeName(endpoint discovery_v1beta1.Endpoint) string {
+	if endpoint.NodeName != nil {
+		return *endpoint.NodeName
+	}
+	return endpoint.Topology[hostnameLabel]
+}
+
+// endpointSubset accumulates the endpoints of the EndpointSlices with the same ports.
+type endpointSubset struct {
+	ports              []api_v1.EndpointPort
+	ready              []api_v1.EndpointAddress
+	servingTerminating []api_v1.EndpointAddress
+	notReady           []api_v1.EndpointAddress
+	ips                map[string]bool
+}
+
+// aggregateEndpointSlices merges the EndpointSlices of a service into a single Endpoints object, so that the rest
+// of the controller can work with the endpoints in the same way regardless of their source. The endpoints of the
+// slices with the same ports are merged into one subset. Ready endpoints become addresses, while the rest become
+// not ready addresses. If a subset doesn't have any ready endpoints, the endpoints that are terminating but still
+// serving are used as addresses instead, so that the traffic is not dropped while the pods are shutting down.
+func aggregateEndpointSlices(namespace string, name string, slices []*discovery_v1beta1.EndpointSlice) api_v1.Endpoints {
+	var keys []string
+	subsets := make(map[string]*endpointSubset)
+
+	for _, slice := range slices {
+		if slice.AddressType == discovery_v1beta1.AddressTypeFQDN {
+			continue
+		}
+
+		ports := convertEndpointPorts(slice.Ports)
+		key := getEndpointPortsKey(ports)
+
+		subset, exists := subsets[key]
+		if !exists {
+			subset = &endpointSubset{
+				ports: ports,
+				ips:   make(map[string]bool),
+			}
+			subsets[key] = subset
+			keys = append(keys, key)
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if len(endpoint.Addresses) == 0 {
+				continue
+			}
+
+			// an endpoint might temporarily belong to several slices while the slices are being updated
+			ip := endpoint.Addresses[0]
+			if subset.ips[ip] {
+				continue
+			}
+			subset.ips[ip] = true
+
+			address := api_v1.EndpointAddress{
+				IP:        ip,
+				TargetRef: endpoint.TargetRef,
+			}
+			if endpoint.Hostname != nil {
+				address.Hostname = *endpoint.Hostname
+			}
+			if nodeName := getEndpointNodeName(endpoint); nodeName != "" {
+				address.NodeName = &nodeName
+			}
+
+			ready, serving, terminating := getEndpointConditions(endpoint.Conditions)
+			if ready {
+				subset.ready = append(subset.ready, address)
+			} else if serving && terminating {
+				subset.servingTerminating = append(subset.servingTerminating, address)
+			} else {
+				subset.notReady = append(subset.notReady, address)
+			}
+		}
+	}
+
+	endps := api_v1.Endpoints{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	for _, key := range keys {
+		subset := subsets[key]
+
+		addresses := subset.ready
+		notReadyAddresses := subset.notReady
+		if len(addresses) == 0 {
+			addresses = subset.servingTerminating
+		} else {
+			notReadyAddresses = append(notReadyAddresses, subset.servingTerminating...)
+		}
+
+		if len(addresses) == 0 && len(notReadyAddresses) == 0 {
+			continue
+		}
+
+		endps.Subsets = append(endps.Subsets, api_v1.EndpointSubset{
+			Addresses:         addresses,
+			NotReadyAddresses: notReadyAddresses,
+			Ports:             subset.ports,
+		})
+	}
+
+	return endps
+}
+
+// getEndpointConditions returns the conditions of an endpoint. A missing ready condition means that the endpoint
+// is ready, and a missing serving condition means that the endpoint is serving if it is ready. A terminating endpoint
+// is never considered ready.
+func getEndpointConditions(conditions discovery_v1beta1.EndpointConditions) (ready bool, serving bool, terminating bool) {
+	terminating = conditions.Terminating != nil && *conditions.Terminating
+	ready = (conditions.Ready == nil || *conditions.Ready) && !terminating
+
+	serving = ready
+	if conditions.Serving != nil {
+		serving = *conditions.Serving
+	}
+
+	return ready, serving, terminating
+}
+
+func convertEndpointPorts(slicePorts []discovery_v1beta1.EndpointPort) []api_v1.EndpointPort {
+	var ports []api_v1.EndpointPort
+
+	for _, slicePort := range slicePorts {
+		if slicePort.Port == nil {
+			continue
+		}
+
+		port := api_v1.EndpointPort{
+			Port:     *slicePort.Port,
+			Protocol: api_v1.ProtocolTCP,
+		}
+		if slicePort.Name != nil {
+			port.Name = *slicePort.Name
+		}
+		if slicePort.Protocol != nil {
+			port.Protocol = *slicePort.Protocol
+		}
+
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+func getEndpointPortsKey(ports []api_v1.EndpointPort) string {
+	var keys []string
+	for _, port := range ports {
+		keys = append(keys, fmt.Sprintf("%s/%s/%d", port.Name, port.Protocol, port.Port))
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
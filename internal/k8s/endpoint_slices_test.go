@@ -0,0 +1,384 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	discovery_v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/discovery/v1beta1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+)
+
+func boolPointer(b bool) *bool {
+	return &b
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func int32Pointer(i int32) *int32 {
+	return &i
+}
+
+func createEndpointSlice(name string, svcName string, port int32, endpoints []discovery_v1beta1.Endpoint) *discovery_v1beta1.EndpointSlice {
+	return &discovery_v1beta1.EndpointSlice{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{discovery_v1beta1.LabelServiceName: svcName},
+		},
+		AddressType: discovery_v1beta1.AddressTypeIPv4,
+		Ports: []discovery_v1beta1.EndpointPort{
+			{
+				Port: int32Pointer(port),
+			},
+		},
+		Endpoints: endpoints,
+	}
+}
+
+func TestAggregateEndpointSlices(t *testing.T) {
+	tests := []struct {
+		slices   []*discovery_v1beta1.EndpointSlice
+		expected []v1.EndpointSubset
+		msg      string
+	}{
+		{
+			slices: []*discovery_v1beta1.EndpointSlice{
+				createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+					{
+						Addresses: []string{"10.0.0.1"},
+						NodeName:  stringPointer("node-1"),
+					},
+					{
+						Addresses:  []string{"10.0.0.2"},
+						Conditions: discovery_v1beta1.EndpointConditions{Ready: boolPointer(false)},
+					},
+				}),
+				createEndpointSlice("coffee-svc-b", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+					{
+						Addresses:  []string{"10.0.0.3"},
+						Conditions: discovery_v1beta1.EndpointConditions{Ready: boolPointer(true)},
+						Topology:   map[string]string{hostnameLabel: "node-2"},
+					},
+					{
+						Addresses: []string{"10.0.0.1"},
+						NodeName:  stringPointer("node-1"),
+					},
+				}),
+			},
+			expected: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{
+						{IP: "10.0.0.1", NodeName: stringPointer("node-1")},
+						{IP: "10.0.0.3", NodeName: stringPointer("node-2")},
+					},
+					NotReadyAddresses: []v1.EndpointAddress{
+						{IP: "10.0.0.2"},
+					},
+					Ports: []v1.EndpointPort{{Port: 8080, Protocol: v1.ProtocolTCP}},
+				},
+			},
+			msg: "slices with the same ports",
+		},
+		{
+			slices: []*discovery_v1beta1.EndpointSlice{
+				createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+					{
+						Addresses: []string{"10.0.0.1"},
+					},
+				}),
+				createEndpointSlice("coffee-svc-b", "coffee-svc", 9090, []discovery_v1beta1.Endpoint{
+					{
+						Addresses: []string{"10.0.0.2"},
+					},
+				}),
+			},
+			expected: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{{IP: "10.0.0.1"}},
+					Ports:     []v1.EndpointPort{{Port: 8080, Protocol: v1.ProtocolTCP}},
+				},
+				{
+					Addresses: []v1.EndpointAddress{{IP: "10.0.0.2"}},
+					Ports:     []v1.EndpointPort{{Port: 9090, Protocol: v1.ProtocolTCP}},
+				},
+			},
+			msg: "slices with different ports",
+		},
+		{
+			slices: []*discovery_v1beta1.EndpointSlice{
+				createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+					{
+						Addresses: []string{"10.0.0.1"},
+					},
+					{
+						Addresses: []string{"10.0.0.2"},
+						Conditions: discovery_v1beta1.EndpointConditions{
+							Ready:       boolPointer(false),
+							Serving:     boolPointer(true),
+							Terminating: boolPointer(true),
+						},
+					},
+				}),
+			},
+			expected: []v1.EndpointSubset{
+				{
+					Addresses:         []v1.EndpointAddress{{IP: "10.0.0.1"}},
+					NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.0.2"}},
+					Ports:             []v1.EndpointPort{{Port: 8080, Protocol: v1.ProtocolTCP}},
+				},
+			},
+			msg: "serving terminating endpoint with a ready endpoint",
+		},
+		{
+			slices: []*discovery_v1beta1.EndpointSlice{
+				createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+					{
+						Addresses: []string{"10.0.0.1"},
+						Conditions: discovery_v1beta1.EndpointConditions{
+							Ready:       boolPointer(false),
+							Serving:     boolPointer(false),
+							Terminating: boolPointer(true),
+						},
+					},
+					{
+						Addresses: []string{"10.0.0.2"},
+						Conditions: discovery_v1beta1.EndpointConditions{
+							Ready:       boolPointer(false),
+							Serving:     boolPointer(true),
+							Terminating: boolPointer(true),
+						},
+					},
+				}),
+			},
+			expected: []v1.EndpointSubset{
+				{
+					Addresses:         []v1.EndpointAddress{{IP: "10.0.0.2"}},
+					NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.0.1"}},
+					Ports:             []v1.EndpointPort{{Port: 8080, Protocol: v1.ProtocolTCP}},
+				},
+			},
+			msg: "serving terminating endpoint without ready endpoints",
+		},
+		{
+			slices: []*discovery_v1beta1.EndpointSlice{
+				createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, nil),
+			},
+			expected: nil,
+			msg:      "slice without endpoints",
+		},
+	}
+
+	for _, test := range tests {
+		result := aggregateEndpointSlices("default", "coffee-svc", test.slices)
+		if result.Namespace != "default" || result.Name != "coffee-svc" {
+			t.Errorf("aggregateEndpointSlices() returned the endpoints %v/%v for the case of %s", result.Namespace, result.Name, test.msg)
+		}
+		if !reflect.DeepEqual(result.Subsets, test.expected) {
+			t.Errorf("aggregateEndpointSlices() returned %+v but expected %+v for the case of %s", result.Subsets, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGetEndpointConditions(t *testing.T) {
+	tests := []struct {
+		conditions          discovery_v1beta1.EndpointConditions
+		expectedReady       bool
+		expectedServing     bool
+		expectedTerminating bool
+		msg                 string
+	}{
+		{
+			conditions:      discovery_v1beta1.EndpointConditions{},
+			expectedReady:   true,
+			expectedServing: true,
+			msg:             "no conditions",
+		},
+		{
+			conditions: discovery_v1beta1.EndpointConditions{
+				Ready: boolPointer(false),
+			},
+			msg: "not ready",
+		},
+		{
+			conditions: discovery_v1beta1.EndpointConditions{
+				Terminating: boolPointer(true),
+			},
+			expectedTerminating: true,
+			msg:                 "terminating without other conditions",
+		},
+		{
+			conditions: discovery_v1beta1.EndpointConditions{
+				Ready:       boolPointer(false),
+				Serving:     boolPointer(true),
+				Terminating: boolPointer(true),
+			},
+			expectedServing:     true,
+			expectedTerminating: true,
+			msg:                 "serving terminating",
+		},
+	}
+
+	for _, test := range tests {
+		ready, serving, terminating := getEndpointConditions(test.conditions)
+		if ready != test.expectedReady || serving != test.expectedServing || terminating != test.expectedTerminating {
+			t.Errorf("getEndpointConditions() returned %v, %v, %v but expected %v, %v, %v for the case of %s",
+				ready, serving, terminating, test.expectedReady, test.expectedServing, test.expectedTerminating, test.msg)
+		}
+	}
+}
+
+func TestGetEndpointSlicesTopology(t *testing.T) {
+	slices := []*discovery_v1beta1.EndpointSlice{
+		createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+			{
+				Addresses: []string{"10.0.0.1"},
+				NodeName:  stringPointer("node-1"),
+				Topology:  map[string]string{zoneLabel: "zone-a"},
+				Hints: &discovery_v1beta1.EndpointHints{
+					ForZones: []discovery_v1beta1.ForZone{{Name: "zone-a"}, {Name: "zone-b"}},
+				},
+			},
+			{
+				Addresses: []string{"10.0.0.2"},
+				Topology:  map[string]string{hostnameLabel: "node-2"},
+			},
+		}),
+	}
+
+	expected := map[string]endpointTopology{
+		"10.0.0.1": {nodeName: "node-1", zone: "zone-a", hints: []string{"zone-a", "zone-b"}},
+		"10.0.0.2": {nodeName: "node-2"},
+	}
+
+	result := getEndpointSlicesTopology(slices)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("getEndpointSlicesTopology() returned %+v but expected %+v", result, expected)
+	}
+}
+
+func TestGetEndpointsForIngressBackendWithEndpointSlices(t *testing.T) {
+	lbc := LoadBalancerController{
+		useEndpointSlices: true,
+		endpointSliceLister: storeToEndpointSliceLister{
+			Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{endpointSliceServiceIndex: endpointSliceServiceIndexFunc}),
+		},
+	}
+
+	slices := []*discovery_v1beta1.EndpointSlice{
+		createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+			{Addresses: []string{"10.0.0.1"}},
+		}),
+		createEndpointSlice("coffee-svc-b", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+			{Addresses: []string{"10.0.0.2"}},
+		}),
+		createEndpointSlice("tea-svc-a", "tea-svc", 8080, []discovery_v1beta1.Endpoint{
+			{Addresses: []string{"10.0.0.3"}},
+		}),
+	}
+	for _, slice := range slices {
+		err := lbc.endpointSliceLister.Add(slice)
+		if err != nil {
+			t.Fatalf("Failed to add an EndpointSlice to the indexer: %v", err)
+		}
+	}
+
+	svc := &v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee-svc",
+			Namespace: "default",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+	backend := &networking.IngressBackend{
+		ServiceName: "coffee-svc",
+		ServicePort: intstr.FromInt(80),
+	}
+
+	expected := []string{"10.0.0.1:8080", "10.0.0.2:8080"}
+
+	result, isExternal, err := lbc.getEndpointsForIngressBackend(backend, "default", svc)
+	if err != nil {
+		t.Fatalf("getEndpointsForIngressBackend() returned an unexpected error: %v", err)
+	}
+	if isExternal {
+		t.Errorf("getEndpointsForIngressBackend() returned an external service")
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("getEndpointsForIngressBackend() returned %v but expected %v", result, expected)
+	}
+
+	svc.Name = "milk-svc"
+	_, _, err = lbc.getEndpointsForIngressBackend(backend, "default", svc)
+	if err == nil {
+		t.Errorf("getEndpointsForIngressBackend() returned no error for a service without EndpointSlices")
+	}
+}
+
+func TestGetEndpointsByKeyWithEndpointSlices(t *testing.T) {
+	lbc := LoadBalancerController{
+		useEndpointSlices: true,
+		svcLister:         cache.NewStore(cache.MetaNamespaceKeyFunc),
+		endpointSliceLister: storeToEndpointSliceLister{
+			Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{endpointSliceServiceIndex: endpointSliceServiceIndexFunc}),
+		},
+	}
+
+	err := lbc.svcLister.Add(&v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee-svc",
+			Namespace: "default",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to add a service to the store: %v", err)
+	}
+
+	slice := createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+		{Addresses: []string{"10.0.0.1"}},
+	})
+	err = lbc.endpointSliceLister.Add(slice)
+	if err != nil {
+		t.Fatalf("Failed to add an EndpointSlice to the indexer: %v", err)
+	}
+
+	obj, exists, err := lbc.getEndpointsByKey("default/coffee-svc")
+	if err != nil || !exists {
+		t.Fatalf("getEndpointsByKey() returned %v, %v for a service with EndpointSlices", exists, err)
+	}
+	if subsets := obj.(*v1.Endpoints).Subsets; len(subsets) != 1 || len(subsets[0].Addresses) != 1 {
+		t.Errorf("getEndpointsByKey() returned the subsets %v but expected one subset with one address", subsets)
+	}
+
+	// the last EndpointSlice of the service is deleted
+	err = lbc.endpointSliceLister.Delete(slice)
+	if err != nil {
+		t.Fatalf("Failed to delete an EndpointSlice from the indexer: %v", err)
+	}
+
+	obj, exists, err = lbc.getEndpointsByKey("default/coffee-svc")
+	if err != nil || !exists {
+		t.Fatalf("getEndpointsByKey() returned %v, %v for a service without EndpointSlices", exists, err)
+	}
+	endps := obj.(*v1.Endpoints)
+	if endps.Namespace != "default" || endps.Name != "coffee-svc" || len(endps.Subsets) != 0 {
+		t.Errorf("getEndpointsByKey() returned %+v but expected the empty endpoints of default/coffee-svc", endps)
+	}
+
+	_, exists, err = lbc.getEndpointsByKey("default/tea-svc")
+	if err != nil || exists {
+		t.Errorf("getEndpointsByKey() returned %v, %v for a missing service", exists, err)
+	}
+}
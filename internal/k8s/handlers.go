@@ -5,8 +5,9 @@ import (
 	"sort"
 
 	"github.com/golang/glog"
+	discovery_v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/discovery/v1beta1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/client-go/tools/cache"
 
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
@@ -87,11 +88,46 @@ func createEndpointHandlers(lbc *LoadBalancerController) cache.ResourceEventHand
 	}
 }
 
+// createEndpointSliceHandlers builds the handler funcs for endpoint slices
+func createEndpointSliceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			slice := obj.(*discovery_v1beta1.EndpointSlice)
+			glog.V(3).Infof("Adding EndpointSlice: %v", slice.Name)
+			lbc.enqueueEndpointsForEndpointSlice(slice)
+		},
+		DeleteFunc: func(obj interface{}) {
+			slice, isSlice := obj.(*discovery_v1beta1.EndpointSlice)
+			if !isSlice {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				slice, ok = deletedState.Obj.(*discovery_v1beta1.EndpointSlice)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-EndpointSlice object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing EndpointSlice: %v", slice.Name)
+			lbc.enqueueEndpointsForEndpointSlice(slice)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old, cur) {
+				curSlice := cur.(*discovery_v1beta1.EndpointSlice)
+				glog.V(3).Infof("EndpointSlice %v changed, syncing", curSlice.Name)
+				lbc.enqueueEndpointsForEndpointSlice(curSlice)
+			}
+		},
+	}
+}
+
 // createIngressHandlers builds the handler funcs for ingresses
 func createIngressHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			ingress := obj.(*v1beta1.Ingress)
+			ingress := obj.(*networking.Ingress)
 			if !lbc.IsNginxIngress(ingress) {
 				glog.Infof("Ignoring Ingress %v based on Annotation %v", ingress.Name, ingressClassKey)
 				return
@@ -100,14 +136,14 @@ func createIngressHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 			lbc.AddSyncQueue(obj)
 		},
 		DeleteFunc: func(obj interface{}) {
-			ingress, isIng := obj.(*v1beta1.Ingress)
+			ingress, isIng := obj.(*networking.Ingress)
 			if !isIng {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
 					glog.V(3).Infof("Error received unexpected object: %v", obj)
 					return
 				}
-				ingress, ok = deletedState.Obj.(*v1beta1.Ingress)
+				ingress, ok = deletedState.Obj.(*networking.Ingress)
 				if !ok {
 					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Ingress object: %v", deletedState.Obj)
 					return
@@ -130,8 +166,8 @@ func createIngressHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 			}
 		},
 		UpdateFunc: func(old, current interface{}) {
-			c := current.(*v1beta1.Ingress)
-			o := old.(*v1beta1.Ingress)
+			c := current.(*networking.Ingress)
+			o := old.(*networking.Ingress)
 			if !lbc.IsNginxIngress(c) {
 				return
 			}
@@ -143,6 +179,42 @@ func createIngressHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 	}
 }
 
+// createIngressClassHandlers builds the handler funcs for ingress classes
+func createIngressClassHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			class := obj.(*networking.IngressClass)
+			glog.V(3).Infof("Adding IngressClass: %v", class.Name)
+			lbc.enqueueIngressesForIngressClass(class.Name)
+		},
+		DeleteFunc: func(obj interface{}) {
+			class, isClass := obj.(*networking.IngressClass)
+			if !isClass {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				class, ok = deletedState.Obj.(*networking.IngressClass)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-IngressClass object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing IngressClass: %v", class.Name)
+			lbc.enqueueIngressesForIngressClass(class.Name)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldClass := old.(*networking.IngressClass)
+			curClass := cur.(*networking.IngressClass)
+			if !reflect.DeepEqual(oldClass.Spec, curClass.Spec) {
+				glog.V(3).Infof("IngressClass %v changed, syncing", curClass.Name)
+				lbc.enqueueIngressesForIngressClass(curClass.Name)
+			}
+		},
+	}
+}
+
 // createSecretHandlers builds the handler funcs for secrets
 func createSecretHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
@@ -209,6 +281,7 @@ func createServiceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 
 			if lbc.areCustomResourcesEnabled {
 				lbc.EnqueueVirtualServersForService(svc)
+				lbc.EnqueueTransportServersForService(svc)
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -235,6 +308,7 @@ func createServiceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 
 			if lbc.areCustomResourcesEnabled {
 				lbc.EnqueueVirtualServersForService(svc)
+				lbc.EnqueueTransportServersForService(svc)
 			}
 
 		},
@@ -256,6 +330,7 @@ func createServiceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 
 					if lbc.areCustomResourcesEnabled {
 						lbc.EnqueueVirtualServersForService(curSvc)
+						lbc.EnqueueTransportServersForService(curSvc)
 					}
 				}
 			}
@@ -339,8 +414,10 @@ func createVirtualServerHandlers(lbc *LoadBalancerController) cache.ResourceEven
 			lbc.AddSyncQueue(vs)
 		},
 		UpdateFunc: func(old, cur interface{}) {
+			oldVs := old.(*conf_v1alpha1.VirtualServer)
 			curVs := cur.(*conf_v1alpha1.VirtualServer)
-			if !reflect.DeepEqual(old, cur) {
+			// the status is updated by the Ingress Controller, so changes in the status are ignored
+			if !reflect.DeepEqual(oldVs.Spec, curVs.Spec) {
 				glog.V(3).Infof("VirtualServer %v changed, syncing", curVs.Name)
 				lbc.AddSyncQueue(curVs)
 			}
@@ -373,11 +450,127 @@ func createVirtualServerRouteHandlers(lbc *LoadBalancerController) cache.Resourc
 			lbc.AddSyncQueue(vsr)
 		},
 		UpdateFunc: func(old, cur interface{}) {
+			oldVsr := old.(*conf_v1alpha1.VirtualServerRoute)
 			curVsr := cur.(*conf_v1alpha1.VirtualServerRoute)
-			if !reflect.DeepEqual(old, cur) {
+			// the status is updated by the Ingress Controller, so changes in the status are ignored
+			if !reflect.DeepEqual(oldVsr.Spec, curVsr.Spec) {
 				glog.V(3).Infof("VirtualServerRoute %v changed, syncing", curVsr.Name)
 				lbc.AddSyncQueue(curVsr)
 			}
 		},
 	}
 }
+
+func createTransportServerHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ts := obj.(*conf_v1alpha1.TransportServer)
+			glog.V(3).Infof("Adding TransportServer: %v", ts.Name)
+			lbc.AddSyncQueue(ts)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ts, isTs := obj.(*conf_v1alpha1.TransportServer)
+			if !isTs {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				ts, ok = deletedState.Obj.(*conf_v1alpha1.TransportServer)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-TransportServer object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing TransportServer: %v", ts.Name)
+			lbc.AddSyncQueue(ts)
+			lbc.enqueueTransportServersForListener(getListenerKeyForTransportServer(ts))
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldTs := old.(*conf_v1alpha1.TransportServer)
+			curTs := cur.(*conf_v1alpha1.TransportServer)
+			if !reflect.DeepEqual(oldTs.Spec, curTs.Spec) {
+				glog.V(3).Infof("TransportServer %v changed, syncing", curTs.Name)
+				lbc.AddSyncQueue(curTs)
+				oldListenerKey := getListenerKeyForTransportServer(oldTs)
+				if oldListenerKey != getListenerKeyForTransportServer(curTs) {
+					lbc.enqueueTransportServersForListener(oldListenerKey)
+				}
+			}
+		},
+	}
+}
+
+func createPolicyHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pol := obj.(*conf_v1alpha1.Policy)
+			glog.V(3).Infof("Adding Policy: %v", pol.Name)
+			lbc.AddSyncQueue(pol)
+		},
+		DeleteFunc: func(obj interface{}) {
+			pol, isPol := obj.(*conf_v1alpha1.Policy)
+			if !isPol {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				pol, ok = deletedState.Obj.(*conf_v1alpha1.Policy)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Policy object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing Policy: %v", pol.Name)
+			lbc.AddSyncQueue(pol)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldPol := old.(*conf_v1alpha1.Policy)
+			curPol := cur.(*conf_v1alpha1.Policy)
+			if !reflect.DeepEqual(oldPol.Spec, curPol.Spec) {
+				glog.V(3).Infof("Policy %v changed, syncing", curPol.Name)
+				lbc.AddSyncQueue(curPol)
+			}
+		},
+	}
+}
+
+// createPodHandlers builds the handler funcs for pods. Adding or removing a pod also changes the Endpoints of its services,
+// but the pod can reach the cache after the Endpoints are handled. In that case, the endpoint of the pod is skipped
+// by the subselector, so the VirtualServers are resynced once the pod is added.
+func createPodHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pod := obj.(*v1.Pod)
+			glog.V(3).Infof("Adding Pod: %v", pod.Name)
+			lbc.EnqueueVirtualServersForPod(pod)
+		},
+		DeleteFunc: func(obj interface{}) {
+			pod, isPod := obj.(*v1.Pod)
+			if !isPod {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				pod, ok = deletedState.Obj.(*v1.Pod)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Pod object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing Pod: %v", pod.Name)
+			lbc.EnqueueVirtualServersForPod(pod)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldPod := old.(*v1.Pod)
+			curPod := cur.(*v1.Pod)
+			if !reflect.DeepEqual(oldPod.Labels, curPod.Labels) {
+				glog.V(3).Infof("Labels of Pod %v changed, syncing", curPod.Name)
+				lbc.EnqueueVirtualServersForPod(oldPod)
+				lbc.EnqueueVirtualServersForPod(curPod)
+			}
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package k8s
+
+import (
+	"encoding/json"
+
+	"github.com/golang/glog"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	extensionsv1beta1 "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DiscoverIngressAPIs checks if the cluster serves the networking.k8s.io Ingress and IngressClass resources.
+// Clusters older than 1.14 only serve the Ingress resources of the extensions/v1beta1 API, while the IngressClass
+// resource is only available in 1.18 or newer.
+func DiscoverIngressAPIs(client discovery.DiscoveryInterface) (isIngressAvailable bool, isIngressClassAvailable bool, err error) {
+	resources, err := client.ServerResourcesForGroupVersion(networking.SchemeGroupVersion.String())
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+
+	for _, resource := range resources.APIResources {
+		switch resource.Name {
+		case "ingresses":
+			isIngressAvailable = true
+		case "ingressclasses":
+			isIngressClassAvailable = true
+		}
+	}
+
+	return isIngressAvailable, isIngressClassAvailable, nil
+}
+
+// convertIngress converts an Ingress or an IngressList between the extensions/v1beta1 and networking.k8s.io/v1beta1
+// types. Both APIs share the same schema, except for the fields that only exist in networking.k8s.io.
+func convertIngress(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// newExtensionsIngressListWatch creates a ListWatch for the extensions/v1beta1 Ingress resources, which
+// converts them to the networking.k8s.io/v1beta1 type, so that the controller can work with a single type.
+func newExtensionsIngressListWatch(client kubernetes.Interface, namespace string) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options meta_v1.ListOptions) (runtime.Object, error) {
+			list, err := client.ExtensionsV1beta1().Ingresses(namespace).List(options)
+			if err != nil {
+				return nil, err
+			}
+
+			var result networking.IngressList
+			err = convertIngress(list, &result)
+			return &result, err
+		},
+		WatchFunc: func(options meta_v1.ListOptions) (watch.Interface, error) {
+			w, err := client.ExtensionsV1beta1().Ingresses(namespace).Watch(options)
+			if err != nil {
+				return nil, err
+			}
+
+			return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+				ing, ok := event.Object.(*extensions.Ingress)
+				if !ok {
+					return event, true
+				}
+
+				var result networking.Ingress
+				err := convertIngress(ing, &result)
+				if err != nil {
+					glog.Errorf("Error converting Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
+					return event, false
+				}
+
+				event.Object = &result
+				return event, true
+			}), nil
+		},
+	}
+}
+
+// ingressStatusClient gets and updates the status of Ingress resources.
+type ingressStatusClient interface {
+	Get(name string, options meta_v1.GetOptions) (*networking.Ingress, error)
+	UpdateStatus(ing *networking.Ingress) (*networking.Ingress, error)
+}
+
+// extensionsIngressClient is an ingressStatusClient for the clusters that only serve the extensions/v1beta1 API.
+type extensionsIngressClient struct {
+	client extensionsv1beta1.IngressInterface
+}
+
+func (c *extensionsIngressClient) Get(name string, options meta_v1.GetOptions) (*networking.Ingress, error) {
+	ing, err := c.client.Get(name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var result networking.Ingress
+	err = convertIngress(ing, &result)
+	return &result, err
+}
+
+func (c *extensionsIngressClient) UpdateStatus(ing *networking.Ingress) (*networking.Ingress, error) {
+	var extIng extensions.Ingress
+	err := convertIngress(ing, &extIng)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedIng, err := c.client.UpdateStatus(&extIng)
+	if err != nil {
+		return nil, err
+	}
+
+	var result networking.Ingress
+	err = convertIngress(updatedIng, &result)
+	return &result, err
+}
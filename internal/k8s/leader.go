@@ -49,11 +49,23 @@ func newLeaderElector(client kubernetes.Interface, callbacks leaderelection.Lead
 func createLeaderHandler(lbc *LoadBalancerController) leaderelection.LeaderCallbacks {
 	return leaderelection.LeaderCallbacks{
 		OnStartedLeading: func(ctx context.Context) {
-			glog.V(3).Info("started leading, updating ingress status")
-			ingresses, mergeableIngresses := lbc.GetManagedIngresses()
-			err := lbc.UpdateManagedAndMergeableIngresses(ingresses, mergeableIngresses)
-			if err != nil {
-				glog.V(3).Infof("error updating status when starting leading: %v", err)
+			if lbc.reportIngressStatus {
+				glog.V(3).Info("started leading, updating ingress status")
+				ingresses, mergeableIngresses := lbc.GetManagedIngresses()
+				err := lbc.UpdateManagedAndMergeableIngresses(ingresses, mergeableIngresses)
+				if err != nil {
+					glog.V(3).Infof("error updating status when starting leading: %v", err)
+				}
+			}
+
+			if lbc.areCustomResourcesEnabled {
+				glog.V(3).Info("started leading, resyncing VirtualServers and VirtualServerRoutes to update their status")
+				for _, obj := range lbc.virtualServerLister.List() {
+					lbc.AddSyncQueue(obj)
+				}
+				for _, obj := range lbc.virtualServerRouteLister.List() {
+					lbc.AddSyncQueue(obj)
+				}
 			}
 		},
 		OnStoppedLeading: func() {
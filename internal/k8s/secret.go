@@ -9,11 +9,19 @@ import (
 // JWTKeyKey is the key of the data field of a Secret where the JWK must be stored.
 const JWTKeyKey = "jwk"
 
+// CAKey is the key of the data field of a Secret where the CA certificate bundle must be stored.
+const CAKey = "ca.crt"
+
+// CRLKey is the key of the data field of a Secret where the optional certificate revocation list is stored.
+const CRLKey = "ca.crl"
+
 const (
 	// TLS Secret
 	TLS = iota
 	// JWK Secret
 	JWK
+	// CA Secret
+	CA
 )
 
 // ValidateTLSSecret validates the secret. If it is valid, the function returns nil.
@@ -38,6 +46,15 @@ func ValidateJWKSecret(secret *v1.Secret) error {
 	return nil
 }
 
+// ValidateCASecret validates the secret. If it is valid, the function returns nil.
+func ValidateCASecret(secret *v1.Secret) error {
+	if _, exists := secret.Data[CAKey]; !exists {
+		return fmt.Errorf("Secret doesn't have %v", CAKey)
+	}
+
+	return nil
+}
+
 // GetSecretKind returns the kind of the Secret.
 func GetSecretKind(secret *v1.Secret) (int, error) {
 	if err := ValidateTLSSecret(secret); err == nil {
@@ -46,6 +63,9 @@ func GetSecretKind(secret *v1.Secret) (int, error) {
 	if err := ValidateJWKSecret(secret); err == nil {
 		return JWK, nil
 	}
+	if err := ValidateCASecret(secret); err == nil {
+		return CA, nil
+	}
 
 	return 0, fmt.Errorf("Unknown Secret")
 }
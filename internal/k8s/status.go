@@ -7,30 +7,37 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	api_v1 "k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	extensionsv1beta1 "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	"k8s.io/client-go/tools/cache"
 )
 
 // statusUpdater reports Ingress status information via the kubernetes
 // API, primarily the IP or host of the LoadBalancer Service exposing the
 // Ingress Controller, or an external IP specified in the ConfigMap.
+// It also reports the state of the VirtualServer and VirtualServerRoute resources.
 type statusUpdater struct {
 	client                   kubernetes.Interface
+	confClient               k8s_nginx.Interface
 	namespace                string
 	externalServiceName      string
+	useExtensionsIngressAPI  bool
 	externalStatusAddress    string
 	externalServiceAddresses []string
 	status                   []api_v1.LoadBalancerIngress
 	keyFunc                  func(obj interface{}) (string, error)
 	ingLister                *storeToIngressLister
+	virtualServerLister      cache.Store
+	virtualServerRouteLister cache.Store
 }
 
 // UpdateManagedAndMergeableIngresses handles the full return format of LoadBalancerController.getManagedIngresses
-func (su *statusUpdater) UpdateManagedAndMergeableIngresses(managedIngresses []v1beta1.Ingress, mergableIngExes map[string]*configs.MergeableIngresses) error {
-	ings := []v1beta1.Ingress{}
+func (su *statusUpdater) UpdateManagedAndMergeableIngresses(managedIngresses []networking.Ingress, mergableIngExes map[string]*configs.MergeableIngresses) error {
+	ings := []networking.Ingress{}
 	ings = append(ings, managedIngresses...)
 	for _, mergableIngEx := range mergableIngExes {
 		for _, minion := range mergableIngEx.Minions {
@@ -42,7 +49,7 @@ func (su *statusUpdater) UpdateManagedAndMergeableIngresses(managedIngresses []v
 
 // UpdateMergableIngresses is a convience passthru to update Ingresses with our configs.MergableIngresses type
 func (su *statusUpdater) UpdateMergableIngresses(mergableIngresses *configs.MergeableIngresses) error {
-	ings := []v1beta1.Ingress{}
+	ings := []networking.Ingress{}
 	ingExes := []*configs.IngressEx{}
 
 	ingExes = append(ingExes, mergableIngresses.Master)
@@ -55,17 +62,17 @@ func (su *statusUpdater) UpdateMergableIngresses(mergableIngresses *configs.Merg
 }
 
 // ClearIngressStatus clears the Ingress status.
-func (su *statusUpdater) ClearIngressStatus(ing v1beta1.Ingress) error {
+func (su *statusUpdater) ClearIngressStatus(ing networking.Ingress) error {
 	return su.updateIngressWithStatus(ing, []api_v1.LoadBalancerIngress{})
 }
 
 // UpdateIngressStatus updates the status on the selected Ingress.
-func (su *statusUpdater) UpdateIngressStatus(ing v1beta1.Ingress) error {
+func (su *statusUpdater) UpdateIngressStatus(ing networking.Ingress) error {
 	return su.updateIngressWithStatus(ing, su.status)
 }
 
 // updateIngressWithStatus sets the provided status on the selected Ingress.
-func (su *statusUpdater) updateIngressWithStatus(ing v1beta1.Ingress, status []api_v1.LoadBalancerIngress) error {
+func (su *statusUpdater) updateIngressWithStatus(ing networking.Ingress, status []api_v1.LoadBalancerIngress) error {
 	if reflect.DeepEqual(ing.Status.LoadBalancer.Ingress, status) {
 		return nil
 	}
@@ -88,7 +95,7 @@ func (su *statusUpdater) updateIngressWithStatus(ing v1beta1.Ingress, status []a
 	}
 
 	ingCopy.Status.LoadBalancer.Ingress = status
-	clientIngress := su.client.ExtensionsV1beta1().Ingresses(ingCopy.Namespace)
+	clientIngress := su.ingressClient(ingCopy.Namespace)
 	_, err = clientIngress.UpdateStatus(ingCopy)
 	if err != nil {
 		glog.V(3).Infof("error setting ingress status: %v", err)
@@ -102,9 +109,17 @@ func (su *statusUpdater) updateIngressWithStatus(ing v1beta1.Ingress, status []a
 	return nil
 }
 
+// ingressClient returns a client for the Ingress resources of the API that the cluster serves.
+func (su *statusUpdater) ingressClient(namespace string) ingressStatusClient {
+	if su.useExtensionsIngressAPI {
+		return &extensionsIngressClient{client: su.client.ExtensionsV1beta1().Ingresses(namespace)}
+	}
+	return su.confClient.NetworkingV1beta1().Ingresses(namespace)
+}
+
 // BulkUpdateIngressStatus sets the status field on the selected Ingresses, specifically
 // the External IP field.
-func (su *statusUpdater) BulkUpdateIngressStatus(ings []v1beta1.Ingress) error {
+func (su *statusUpdater) BulkUpdateIngressStatus(ings []networking.Ingress) error {
 	if len(ings) < 1 {
 		glog.V(3).Info("no ingresses to update")
 		return nil
@@ -125,7 +140,7 @@ func (su *statusUpdater) BulkUpdateIngressStatus(ings []v1beta1.Ingress) error {
 // retryStatusUpdate fetches a fresh copy of the Ingress from the k8s API, checks if it still needs to be
 // updated, and then attempts to update. We often need to fetch fresh copies due to the
 // k8s API using ResourceVersion to stop updates on stale items.
-func (su *statusUpdater) retryStatusUpdate(clientIngress extensionsv1beta1.IngressInterface, ingCopy *v1beta1.Ingress) error {
+func (su *statusUpdater) retryStatusUpdate(clientIngress ingressStatusClient, ingCopy *networking.Ingress) error {
 	apiIng, err := clientIngress.Get(ingCopy.Name, metav1.GetOptions{})
 	if err != nil {
 		glog.V(3).Infof("error getting ingress resource: %v", err)
@@ -213,3 +228,166 @@ func (su *statusUpdater) SaveStatusFromExternalService(svc *api_v1.Service) {
 	}
 	su.saveStatus(ips)
 }
+
+// externalEndpointPorts are the ports NGINX listens on for VirtualServer and VirtualServerRoute resources.
+const externalEndpointPorts = "[80,443]"
+
+// generateExternalEndpoints converts the saved status to the external endpoints of
+// the VirtualServer and VirtualServerRoute resources.
+func (su *statusUpdater) generateExternalEndpoints() []conf_v1alpha1.ExternalEndpoint {
+	var endpoints []conf_v1alpha1.ExternalEndpoint
+	for _, status := range su.status {
+		endpoints = append(endpoints, conf_v1alpha1.ExternalEndpoint{
+			IP:       status.IP,
+			Hostname: status.Hostname,
+			Ports:    externalEndpointPorts,
+		})
+	}
+	return endpoints
+}
+
+// UpdateVirtualServerStatus updates the status of a VirtualServer.
+func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1alpha1.VirtualServer, state string, reason string, message string) error {
+	status := conf_v1alpha1.VirtualServerStatus{
+		State:              state,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: vs.Generation,
+		ExternalEndpoints:  su.generateExternalEndpoints(),
+	}
+	return su.updateVirtualServerWithStatus(vs, status)
+}
+
+// UpdateVirtualServersExternalEndpoints updates the external endpoints in the status of VirtualServers,
+// keeping the rest of the status.
+func (su *statusUpdater) UpdateVirtualServersExternalEndpoints(virtualServers []*conf_v1alpha1.VirtualServer) error {
+	failed := false
+	for _, vs := range virtualServers {
+		status := vs.Status
+		status.ExternalEndpoints = su.generateExternalEndpoints()
+		err := su.updateVirtualServerWithStatus(vs, status)
+		if err != nil {
+			failed = true
+		}
+	}
+	if failed {
+		return fmt.Errorf("not all VirtualServers updated")
+	}
+	return nil
+}
+
+func (su *statusUpdater) updateVirtualServerWithStatus(vs *conf_v1alpha1.VirtualServer, status conf_v1alpha1.VirtualServerStatus) error {
+	// Get a pristine VirtualServer from the Store, because the provided one might be outdated.
+	vsCopy := vs.DeepCopy()
+	if su.virtualServerLister != nil {
+		obj, exists, err := su.virtualServerLister.Get(vs)
+		if err != nil {
+			glog.V(3).Infof("error getting VirtualServer from Store: %v", err)
+			return err
+		}
+		if !exists {
+			glog.V(3).Infof("VirtualServer %v/%v doesn't exist in Store", vs.Namespace, vs.Name)
+			return nil
+		}
+		vsCopy = obj.(*conf_v1alpha1.VirtualServer).DeepCopy()
+	}
+
+	if reflect.DeepEqual(vsCopy.Status, status) {
+		return nil
+	}
+
+	vsCopy.Status = status
+	clientVirtualServers := su.confClient.K8sV1alpha1().VirtualServers(vsCopy.Namespace)
+	_, err := clientVirtualServers.UpdateStatus(vsCopy)
+	if err != nil {
+		glog.V(3).Infof("error setting VirtualServer status: %v", err)
+
+		// retry with a fresh copy of the VirtualServer from the k8s API
+		apiVs, err := clientVirtualServers.Get(vsCopy.Name, metav1.GetOptions{})
+		if err != nil {
+			glog.V(3).Infof("error getting VirtualServer resource: %v", err)
+			return err
+		}
+		apiVs.Status = status
+		_, err = clientVirtualServers.UpdateStatus(apiVs)
+		if err != nil {
+			glog.V(3).Infof("update retry failed: %v", err)
+			return err
+		}
+	}
+	glog.V(3).Infof("updated status for VirtualServer: %v %v", vsCopy.Namespace, vsCopy.Name)
+	return nil
+}
+
+// UpdateVirtualServerRouteStatus updates the status of a VirtualServerRoute.
+func (su *statusUpdater) UpdateVirtualServerRouteStatus(vsr *conf_v1alpha1.VirtualServerRoute, state string, reason string, message string) error {
+	status := conf_v1alpha1.VirtualServerRouteStatus{
+		State:              state,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: vsr.Generation,
+		ExternalEndpoints:  su.generateExternalEndpoints(),
+	}
+	return su.updateVirtualServerRouteWithStatus(vsr, status)
+}
+
+// UpdateVirtualServerRoutesExternalEndpoints updates the external endpoints in the status of VirtualServerRoutes,
+// keeping the rest of the status.
+func (su *statusUpdater) UpdateVirtualServerRoutesExternalEndpoints(virtualServerRoutes []*conf_v1alpha1.VirtualServerRoute) error {
+	failed := false
+	for _, vsr := range virtualServerRoutes {
+		status := vsr.Status
+		status.ExternalEndpoints = su.generateExternalEndpoints()
+		err := su.updateVirtualServerRouteWithStatus(vsr, status)
+		if err != nil {
+			failed = true
+		}
+	}
+	if failed {
+		return fmt.Errorf("not all VirtualServerRoutes updated")
+	}
+	return nil
+}
+
+func (su *statusUpdater) updateVirtualServerRouteWithStatus(vsr *conf_v1alpha1.VirtualServerRoute, status conf_v1alpha1.VirtualServerRouteStatus) error {
+	// Get a pristine VirtualServerRoute from the Store, because the provided one might be outdated.
+	vsrCopy := vsr.DeepCopy()
+	if su.virtualServerRouteLister != nil {
+		obj, exists, err := su.virtualServerRouteLister.Get(vsr)
+		if err != nil {
+			glog.V(3).Infof("error getting VirtualServerRoute from Store: %v", err)
+			return err
+		}
+		if !exists {
+			glog.V(3).Infof("VirtualServerRoute %v/%v doesn't exist in Store", vsr.Namespace, vsr.Name)
+			return nil
+		}
+		vsrCopy = obj.(*conf_v1alpha1.VirtualServerRoute).DeepCopy()
+	}
+
+	if reflect.DeepEqual(vsrCopy.Status, status) {
+		return nil
+	}
+
+	vsrCopy.Status = status
+	clientVirtualServerRoutes := su.confClient.K8sV1alpha1().VirtualServerRoutes(vsrCopy.Namespace)
+	_, err := clientVirtualServerRoutes.UpdateStatus(vsrCopy)
+	if err != nil {
+		glog.V(3).Infof("error setting VirtualServerRoute status: %v", err)
+
+		// retry with a fresh copy of the VirtualServerRoute from the k8s API
+		apiVsr, err := clientVirtualServerRoutes.Get(vsrCopy.Name, metav1.GetOptions{})
+		if err != nil {
+			glog.V(3).Infof("error getting VirtualServerRoute resource: %v", err)
+			return err
+		}
+		apiVsr.Status = status
+		_, err = clientVirtualServerRoutes.UpdateStatus(apiVsr)
+		if err != nil {
+			glog.V(3).Infof("update retry failed: %v", err)
+			return err
+		}
+	}
+	glog.V(3).Infof("updated status for VirtualServerRoute: %v %v", vsrCopy.Namespace, vsrCopy.Name)
+	return nil
+}
@@ -1,8 +1,12 @@
 package k8s
 
 import (
+	"reflect"
 	"testing"
 
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	conf_fake "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/fake"
 	v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,12 +16,12 @@ import (
 )
 
 func TestStatusUpdate(t *testing.T) {
-	ing := extensions.Ingress{
+	ing := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "ing-1",
 			Namespace: "namespace",
 		},
-		Status: extensions.IngressStatus{
+		Status: networking.IngressStatus{
 			LoadBalancer: v1.LoadBalancerStatus{
 				Ingress: []v1.LoadBalancerIngress{
 					{
@@ -27,15 +31,15 @@ func TestStatusUpdate(t *testing.T) {
 			},
 		},
 	}
-	fakeClient := fake.NewSimpleClientset(
-		&extensions.IngressList{Items: []extensions.Ingress{
+	fakeClient := conf_fake.NewSimpleClientset(
+		&networking.IngressList{Items: []networking.Ingress{
 			ing,
 		}},
 	)
 	ingLister := storeToIngressLister{}
 	ingLister.Store, _ = cache.NewInformer(
-		cache.NewListWatchFromClient(fakeClient.ExtensionsV1beta1().RESTClient(), "ingresses", "nginx-ingress", fields.Everything()),
-		&extensions.Ingress{}, 2, nil)
+		cache.NewListWatchFromClient(fakeClient.NetworkingV1beta1().RESTClient(), "ingresses", "nginx-ingress", fields.Everything()),
+		&networking.Ingress{}, 2, nil)
 
 	err := ingLister.Store.Add(&ing)
 	if err != nil {
@@ -43,7 +47,8 @@ func TestStatusUpdate(t *testing.T) {
 	}
 
 	su := statusUpdater{
-		client:                fakeClient,
+		client:                fake.NewSimpleClientset(),
+		confClient:            fakeClient,
 		namespace:             "namespace",
 		externalServiceName:   "service-name",
 		externalStatusAddress: "123.123.123.123",
@@ -54,7 +59,7 @@ func TestStatusUpdate(t *testing.T) {
 	if err != nil {
 		t.Errorf("error clearing ing status: %v", err)
 	}
-	ings, _ := fakeClient.ExtensionsV1beta1().Ingresses("namespace").List(meta_v1.ListOptions{})
+	ings, _ := fakeClient.NetworkingV1beta1().Ingresses("namespace").List(meta_v1.ListOptions{})
 	ingf := ings.Items[0]
 	if !checkStatus("", ingf) {
 		t.Errorf("expected: %v actual: %v", "", ingf.Status.LoadBalancer.Ingress[0])
@@ -65,7 +70,7 @@ func TestStatusUpdate(t *testing.T) {
 	if err != nil {
 		t.Errorf("error updating ing status: %v", err)
 	}
-	ring, _ := fakeClient.ExtensionsV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
+	ring, _ := fakeClient.NetworkingV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
 	if !checkStatus("1.1.1.1", *ring) {
 		t.Errorf("expected: %v actual: %v", "", ring.Status.LoadBalancer.Ingress)
 	}
@@ -88,7 +93,7 @@ func TestStatusUpdate(t *testing.T) {
 	if err != nil {
 		t.Errorf("error updating ing status: %v", err)
 	}
-	ring, _ = fakeClient.ExtensionsV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
+	ring, _ = fakeClient.NetworkingV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
 	if !checkStatus("1.1.1.1", *ring) {
 		t.Errorf("expected: %v actual: %v", "1.1.1.1", ring.Status.LoadBalancer.Ingress)
 	}
@@ -98,7 +103,7 @@ func TestStatusUpdate(t *testing.T) {
 	if err != nil {
 		t.Errorf("error updating ing status: %v", err)
 	}
-	ring, _ = fakeClient.ExtensionsV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
+	ring, _ = fakeClient.NetworkingV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
 	if !checkStatus("2.2.2.2", *ring) {
 		t.Errorf("expected: %v actual: %v", "2.2.2.2", ring.Status.LoadBalancer.Ingress)
 	}
@@ -108,15 +113,183 @@ func TestStatusUpdate(t *testing.T) {
 	if err != nil {
 		t.Errorf("error updating ing status: %v", err)
 	}
-	ring, _ = fakeClient.ExtensionsV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
+	ring, _ = fakeClient.NetworkingV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
 	if !checkStatus("", *ring) {
 		t.Errorf("expected: %v actual: %v", "", ring.Status.LoadBalancer.Ingress)
 	}
 }
 
-func checkStatus(expected string, actual extensions.Ingress) bool {
+func TestStatusUpdateWithExtensionsAPI(t *testing.T) {
+	extIng := extensions.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ing-1",
+			Namespace: "namespace",
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(
+		&extensions.IngressList{Items: []extensions.Ingress{
+			extIng,
+		}},
+	)
+
+	ing := networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ing-1",
+			Namespace: "namespace",
+		},
+	}
+	ingLister := storeToIngressLister{}
+	ingLister.Store, _ = cache.NewInformer(newExtensionsIngressListWatch(fakeClient, "namespace"), &networking.Ingress{}, 2, nil)
+
+	err := ingLister.Store.Add(&ing)
+	if err != nil {
+		t.Errorf("Error adding Ingress to the ingress lister: %v", err)
+	}
+
+	su := statusUpdater{
+		client:                  fakeClient,
+		namespace:               "namespace",
+		externalStatusAddress:   "123.123.123.123",
+		ingLister:               &ingLister,
+		keyFunc:                 cache.DeletionHandlingMetaNamespaceKeyFunc,
+		useExtensionsIngressAPI: true,
+	}
+
+	su.SaveStatusFromExternalStatus("1.1.1.1")
+	err = su.UpdateIngressStatus(ing)
+	if err != nil {
+		t.Errorf("error updating ing status: %v", err)
+	}
+
+	ring, _ := fakeClient.ExtensionsV1beta1().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
+	if len(ring.Status.LoadBalancer.Ingress) != 1 || ring.Status.LoadBalancer.Ingress[0].IP != "1.1.1.1" {
+		t.Errorf("expected: %v actual: %v", "1.1.1.1", ring.Status.LoadBalancer.Ingress)
+	}
+}
+
+func checkStatus(expected string, actual networking.Ingress) bool {
 	if len(actual.Status.LoadBalancer.Ingress) == 0 {
 		return expected == ""
 	}
 	return expected == actual.Status.LoadBalancer.Ingress[0].IP
 }
+
+func TestVirtualServerStatusUpdate(t *testing.T) {
+	vs := conf_v1alpha1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:       "cafe",
+			Namespace:  "default",
+			Generation: 3,
+		},
+	}
+	fakeConfClient := conf_fake.NewSimpleClientset(&vs)
+
+	virtualServerLister := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	err := virtualServerLister.Add(&vs)
+	if err != nil {
+		t.Errorf("Error adding VirtualServer to the lister: %v", err)
+	}
+
+	su := statusUpdater{
+		confClient:          fakeConfClient,
+		virtualServerLister: virtualServerLister,
+		keyFunc:             cache.DeletionHandlingMetaNamespaceKeyFunc,
+	}
+	su.SaveStatusFromExternalStatus("1.1.1.1")
+
+	err = su.UpdateVirtualServerStatus(&vs, conf_v1alpha1.StateValid, "AddedOrUpdated", "Configuration for default/cafe was added or updated")
+	if err != nil {
+		t.Errorf("error updating VirtualServer status: %v", err)
+	}
+
+	expected := conf_v1alpha1.VirtualServerStatus{
+		State:              conf_v1alpha1.StateValid,
+		Reason:             "AddedOrUpdated",
+		Message:            "Configuration for default/cafe was added or updated",
+		ObservedGeneration: 3,
+		ExternalEndpoints: []conf_v1alpha1.ExternalEndpoint{
+			{
+				IP:    "1.1.1.1",
+				Ports: "[80,443]",
+			},
+		},
+	}
+
+	result, _ := fakeConfClient.K8sV1alpha1().VirtualServers(vs.Namespace).Get(vs.Name, meta_v1.GetOptions{})
+	if !reflect.DeepEqual(result.Status, expected) {
+		t.Errorf("UpdateVirtualServerStatus() set status %+v but expected %+v", result.Status, expected)
+	}
+
+	err = virtualServerLister.Update(result)
+	if err != nil {
+		t.Errorf("Error updating VirtualServer in the lister: %v", err)
+	}
+
+	su.SaveStatusFromExternalStatus("example.com")
+	err = su.UpdateVirtualServersExternalEndpoints([]*conf_v1alpha1.VirtualServer{result})
+	if err != nil {
+		t.Errorf("error updating VirtualServer external endpoints: %v", err)
+	}
+
+	expected.ExternalEndpoints = []conf_v1alpha1.ExternalEndpoint{
+		{
+			Hostname: "example.com",
+			Ports:    "[80,443]",
+		},
+	}
+
+	result, _ = fakeConfClient.K8sV1alpha1().VirtualServers(vs.Namespace).Get(vs.Name, meta_v1.GetOptions{})
+	if !reflect.DeepEqual(result.Status, expected) {
+		t.Errorf("UpdateVirtualServersExternalEndpoints() set status %+v but expected %+v", result.Status, expected)
+	}
+}
+
+func TestVirtualServerRouteStatusUpdate(t *testing.T) {
+	vsr := conf_v1alpha1.VirtualServerRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:       "coffee",
+			Namespace:  "default",
+			Generation: 1,
+		},
+	}
+	fakeConfClient := conf_fake.NewSimpleClientset(&vsr)
+
+	su := statusUpdater{
+		confClient:               fakeConfClient,
+		virtualServerRouteLister: cache.NewStore(cache.MetaNamespaceKeyFunc),
+		keyFunc:                  cache.DeletionHandlingMetaNamespaceKeyFunc,
+	}
+
+	// the VirtualServerRoute is not in the lister, so the status must not be updated
+	err := su.UpdateVirtualServerRouteStatus(&vsr, conf_v1alpha1.StateInvalid, "Rejected", "VirtualServerRoute default/coffee is invalid")
+	if err != nil {
+		t.Errorf("error updating VirtualServerRoute status: %v", err)
+	}
+
+	result, _ := fakeConfClient.K8sV1alpha1().VirtualServerRoutes(vsr.Namespace).Get(vsr.Name, meta_v1.GetOptions{})
+	if !reflect.DeepEqual(result.Status, conf_v1alpha1.VirtualServerRouteStatus{}) {
+		t.Errorf("UpdateVirtualServerRouteStatus() set status %+v for a VirtualServerRoute that is not in the lister", result.Status)
+	}
+
+	err = su.virtualServerRouteLister.Add(&vsr)
+	if err != nil {
+		t.Errorf("Error adding VirtualServerRoute to the lister: %v", err)
+	}
+
+	err = su.UpdateVirtualServerRouteStatus(&vsr, conf_v1alpha1.StateInvalid, "Rejected", "VirtualServerRoute default/coffee is invalid")
+	if err != nil {
+		t.Errorf("error updating VirtualServerRoute status: %v", err)
+	}
+
+	expected := conf_v1alpha1.VirtualServerRouteStatus{
+		State:              conf_v1alpha1.StateInvalid,
+		Reason:             "Rejected",
+		Message:            "VirtualServerRoute default/coffee is invalid",
+		ObservedGeneration: 1,
+	}
+
+	result, _ = fakeConfClient.K8sV1alpha1().VirtualServerRoutes(vsr.Namespace).Get(vsr.Name, meta_v1.GetOptions{})
+	if !reflect.DeepEqual(result.Status, expected) {
+		t.Errorf("UpdateVirtualServerRouteStatus() set status %+v but expected %+v", result.Status, expected)
+	}
+}
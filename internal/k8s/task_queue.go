@@ -6,8 +6,8 @@ import (
 
 	"github.com/golang/glog"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -112,6 +112,10 @@ const (
 	virtualserver
 	// virtualServeRoute resource
 	virtualServerRoute
+	// transportserver resource
+	transportserver
+	// policy resource
+	policy
 )
 
 // task is an element of a taskQueue
@@ -124,8 +128,8 @@ type task struct {
 func newTask(key string, obj interface{}) (task, error) {
 	var k kind
 	switch t := obj.(type) {
-	case *v1beta1.Ingress:
-		ing := obj.(*v1beta1.Ingress)
+	case *networking.Ingress:
+		ing := obj.(*networking.Ingress)
 		if isMinion(ing) {
 			k = ingressMinion
 		} else {
@@ -143,6 +147,10 @@ func newTask(key string, obj interface{}) (task, error) {
 		k = virtualserver
 	case *conf_v1alpha1.VirtualServerRoute:
 		k = virtualServerRoute
+	case *conf_v1alpha1.TransportServer:
+		k = transportserver
+	case *conf_v1alpha1.Policy:
+		k = policy
 	default:
 		return task{}, fmt.Errorf("Unknow type: %v", t)
 	}
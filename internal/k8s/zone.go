@@ -0,0 +1,179 @@
+package k8s
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// deprecatedZoneLabel is the label of nodes that holds the zone in the clusters older than 1.17.
+const deprecatedZoneLabel = "failure-domain.beta.kubernetes.io/zone"
+
+// GetControllerZone returns the zone of the node that runs the pod of the Ingress Controller.
+func GetControllerZone(client kubernetes.Interface, namespace string, podName string) (string, error) {
+	if namespace == "" || podName == "" {
+		return "", fmt.Errorf("the namespace and the name of the pod of the Ingress Controller are unknown")
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(podName, meta_v1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting pod %v/%v: %v", namespace, podName, err)
+	}
+	if pod.Spec.NodeName == "" {
+		return "", fmt.Errorf("pod %v/%v is not scheduled to a node", namespace, podName)
+	}
+
+	node, err := client.CoreV1().Nodes().Get(pod.Spec.NodeName, meta_v1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting node %v: %v", pod.Spec.NodeName, err)
+	}
+
+	zone := getNodeZone(node)
+	if zone == "" {
+		return "", fmt.Errorf("node %v doesn't have the %v label", node.Name, zoneLabel)
+	}
+
+	return zone, nil
+}
+
+func getNodeZone(node *api_v1.Node) string {
+	if zone, exists := node.Labels[zoneLabel]; exists {
+		return zone
+	}
+	return node.Labels[deprecatedZoneLabel]
+}
+
+// addNodeHandler adds the handler for nodes to the controller. The nodes are used to find the zones of the endpoints.
+func (lbc *LoadBalancerController) addNodeHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.nodeLister, lbc.nodeController = cache.NewInformer(
+		cache.NewListWatchFromClient(
+			lbc.client.CoreV1().RESTClient(),
+			"nodes",
+			"",
+			fields.Everything()),
+		&api_v1.Node{},
+		lbc.resync,
+		handlers,
+	)
+}
+
+// getServiceEndpointsTopology returns the topology of the endpoints of a service, keyed by the IP of the endpoint.
+// The zones that the endpoints don't report are taken from the labels of their nodes.
+func (lbc *LoadBalancerController) getServiceEndpointsTopology(svc *api_v1.Service) (map[string]endpointTopology, error) {
+	var topology map[string]endpointTopology
+
+	if lbc.useEndpointSlices {
+		var err error
+		topology, err = lbc.endpointSliceLister.GetServiceEndpointsTopology(svc)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		endps, err := lbc.endpointLister.GetServiceEndpoints(svc)
+		if err != nil {
+			return nil, err
+		}
+		topology = getEndpointsTopology(endps)
+	}
+
+	for ip, t := range topology {
+		if t.zone != "" || t.nodeName == "" {
+			continue
+		}
+
+		obj, exists, err := lbc.nodeLister.GetByKey(t.nodeName)
+		if err != nil {
+			glog.V(3).Infof("Error getting node %v from the cache: %v", t.nodeName, err)
+			continue
+		}
+		if !exists {
+			continue
+		}
+
+		t.zone = getNodeZone(obj.(*api_v1.Node))
+		topology[ip] = t
+	}
+
+	return topology, nil
+}
+
+// getEndpointsTopology returns the topology of the endpoints, keyed by the IP of the endpoint.
+// The Endpoints resources only include the node names of the endpoints.
+func getEndpointsTopology(endps api_v1.Endpoints) map[string]endpointTopology {
+	topology := make(map[string]endpointTopology)
+
+	for _, subset := range endps.Subsets {
+		for _, address := range subset.Addresses {
+			var t endpointTopology
+			if address.NodeName != nil {
+				t.nodeName = *address.NodeName
+			}
+			topology[address.IP] = t
+		}
+	}
+
+	return topology
+}
+
+// isEndpointInZone checks if an endpoint must receive the traffic from the zone. If the EndpointSlice controller
+// provides hints for the endpoint, the hints take precedence over the zone of the endpoint.
+func isEndpointInZone(t endpointTopology, zone string) bool {
+	if len(t.hints) == 0 {
+		return t.zone == zone
+	}
+
+	for _, hint := range t.hints {
+		if hint == zone {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addLocalZoneEndpoints adds the endpoints of a service that are in the zone of the Ingress Controller
+// to the localEndpoints map. The endpoints are in the ip:port format.
+func (lbc *LoadBalancerController) addLocalZoneEndpoints(localEndpoints map[string]bool, svc *api_v1.Service, endps []string) {
+	if localEndpoints == nil || len(endps) == 0 {
+		return
+	}
+
+	topology, err := lbc.getServiceEndpointsTopology(svc)
+	if err != nil {
+		glog.V(3).Infof("Error getting the topology of the endpoints of service %v/%v: %v", svc.Namespace, svc.Name, err)
+		return
+	}
+
+	for _, endp := range endps {
+		ip, _, err := net.SplitHostPort(endp)
+		if err != nil {
+			continue
+		}
+
+		if t, exists := topology[ip]; exists && isEndpointInZone(t, lbc.controllerZone) {
+			localEndpoints[endp] = true
+		}
+	}
+}
+
+// addLocalZoneEndpointsForService is like addLocalZoneEndpoints, but looks up the service by its name.
+func (lbc *LoadBalancerController) addLocalZoneEndpointsForService(localEndpoints map[string]bool, namespace string, name string, endps []string) {
+	if localEndpoints == nil || len(endps) == 0 {
+		return
+	}
+
+	svc, err := lbc.getServiceForIngressBackend(&networking.IngressBackend{ServiceName: name}, namespace)
+	if err != nil {
+		glog.V(3).Infof("Error getting service %v: %v", name, err)
+		return
+	}
+
+	lbc.addLocalZoneEndpoints(localEndpoints, svc, endps)
+}
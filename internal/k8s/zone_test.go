@@ -0,0 +1,214 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	discovery_v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/discovery/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func createNode(name string, labels map[string]string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
+
+func TestGetControllerZone(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "nginx-ingress",
+			Namespace: "nginx-ingress",
+		},
+		Spec: v1.PodSpec{
+			NodeName: "node-1",
+		},
+	}
+
+	tests := []struct {
+		node        *v1.Node
+		expected    string
+		expectedErr bool
+		msg         string
+	}{
+		{
+			node:     createNode("node-1", map[string]string{zoneLabel: "zone-a", deprecatedZoneLabel: "zone-b"}),
+			expected: "zone-a",
+			msg:      "zone label",
+		},
+		{
+			node:     createNode("node-1", map[string]string{deprecatedZoneLabel: "zone-b"}),
+			expected: "zone-b",
+			msg:      "deprecated zone label",
+		},
+		{
+			node:        createNode("node-1", nil),
+			expectedErr: true,
+			msg:         "node without zone labels",
+		},
+		{
+			node:        createNode("node-2", map[string]string{zoneLabel: "zone-a"}),
+			expectedErr: true,
+			msg:         "missing node",
+		},
+	}
+
+	for _, test := range tests {
+		client := fake.NewSimpleClientset(pod, test.node)
+
+		result, err := GetControllerZone(client, "nginx-ingress", "nginx-ingress")
+		if (err != nil) != test.expectedErr {
+			t.Errorf("GetControllerZone() returned the error %v for the case of %s", err, test.msg)
+		}
+		if result != test.expected {
+			t.Errorf("GetControllerZone() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestIsEndpointInZone(t *testing.T) {
+	tests := []struct {
+		topology endpointTopology
+		expected bool
+		msg      string
+	}{
+		{
+			topology: endpointTopology{zone: "zone-a"},
+			expected: true,
+			msg:      "endpoint in the zone",
+		},
+		{
+			topology: endpointTopology{zone: "zone-b"},
+			expected: false,
+			msg:      "endpoint in another zone",
+		},
+		{
+			topology: endpointTopology{},
+			expected: false,
+			msg:      "endpoint without a zone",
+		},
+		{
+			topology: endpointTopology{zone: "zone-b", hints: []string{"zone-a", "zone-c"}},
+			expected: true,
+			msg:      "endpoint in another zone with a hint for the zone",
+		},
+		{
+			topology: endpointTopology{zone: "zone-a", hints: []string{"zone-b"}},
+			expected: false,
+			msg:      "endpoint in the zone with a hint for another zone",
+		},
+	}
+
+	for _, test := range tests {
+		result := isEndpointInZone(test.topology, "zone-a")
+		if result != test.expected {
+			t.Errorf("isEndpointInZone() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestAddLocalZoneEndpoints(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee-svc",
+			Namespace: "default",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+	endps := []string{"10.0.0.1:8080", "10.0.0.2:8080", "10.0.0.3:8080"}
+	expected := map[string]bool{"10.0.0.1:8080": true, "10.0.0.3:8080": true}
+
+	nodeLister := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	nodes := []*v1.Node{
+		createNode("node-1", map[string]string{zoneLabel: "zone-a"}),
+		createNode("node-2", map[string]string{zoneLabel: "zone-b"}),
+	}
+	for _, node := range nodes {
+		err := nodeLister.Add(node)
+		if err != nil {
+			t.Fatalf("Failed to add a node to the store: %v", err)
+		}
+	}
+
+	// Endpoints
+	lbc := LoadBalancerController{
+		controllerZone: "zone-a",
+		endpointLister: storeToEndpointLister{Store: cache.NewStore(cache.MetaNamespaceKeyFunc)},
+		nodeLister:     nodeLister,
+	}
+	err := lbc.endpointLister.Add(&v1.Endpoints{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee-svc",
+			Namespace: "default",
+		},
+		Subsets: []v1.EndpointSubset{
+			{
+				Addresses: []v1.EndpointAddress{
+					{IP: "10.0.0.1", NodeName: stringPointer("node-1")},
+					{IP: "10.0.0.2", NodeName: stringPointer("node-2")},
+					{IP: "10.0.0.3", NodeName: stringPointer("node-1")},
+				},
+				Ports: []v1.EndpointPort{{Port: 8080}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to add the Endpoints to the store: %v", err)
+	}
+
+	result := make(map[string]bool)
+	lbc.addLocalZoneEndpoints(result, svc, endps)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("addLocalZoneEndpoints() returned %v but expected %v for the case of Endpoints", result, expected)
+	}
+
+	// EndpointSlices
+	lbc = LoadBalancerController{
+		controllerZone:    "zone-a",
+		useEndpointSlices: true,
+		endpointSliceLister: storeToEndpointSliceLister{
+			Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{endpointSliceServiceIndex: endpointSliceServiceIndexFunc}),
+		},
+		nodeLister: nodeLister,
+	}
+	err = lbc.endpointSliceLister.Add(createEndpointSlice("coffee-svc-a", "coffee-svc", 8080, []discovery_v1beta1.Endpoint{
+		{
+			Addresses: []string{"10.0.0.1"},
+			Topology:  map[string]string{zoneLabel: "zone-a"},
+		},
+		{
+			Addresses: []string{"10.0.0.2"},
+			NodeName:  stringPointer("node-1"),
+			Hints: &discovery_v1beta1.EndpointHints{
+				ForZones: []discovery_v1beta1.ForZone{{Name: "zone-b"}},
+			},
+		},
+		{
+			Addresses: []string{"10.0.0.3"},
+			NodeName:  stringPointer("node-1"),
+		},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to add an EndpointSlice to the indexer: %v", err)
+	}
+
+	result = make(map[string]bool)
+	lbc.addLocalZoneEndpoints(result, svc, endps)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("addLocalZoneEndpoints() returned %v but expected %v for the case of EndpointSlices", result, expected)
+	}
+}
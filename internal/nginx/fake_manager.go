@@ -42,6 +42,23 @@ func (*FakeManager) DeleteConfig(name string) {
 	glog.V(3).Infof("Deleting config %v", name)
 }
 
+// CreateStreamConfig provides a fake implementation of CreateStreamConfig.
+func (*FakeManager) CreateStreamConfig(name string, content []byte) {
+	glog.V(3).Infof("Writing stream config %v", name)
+	glog.V(3).Info(string(content))
+}
+
+// DeleteStreamConfig provides a fake implementation of DeleteStreamConfig.
+func (*FakeManager) DeleteStreamConfig(name string) {
+	glog.V(3).Infof("Deleting stream config %v", name)
+}
+
+// CreateTLSPassthroughHostsConfig provides a fake implementation of CreateTLSPassthroughHostsConfig.
+func (*FakeManager) CreateTLSPassthroughHostsConfig(content []byte) {
+	glog.V(3).Info("Writing TLS Passthrough hosts config")
+	glog.V(3).Info(string(content))
+}
+
 // CreateSecret provides a fake implementation of CreateSecret.
 func (fm *FakeManager) CreateSecret(name string, content []byte, mode os.FileMode) string {
 	glog.V(3).Infof("Writing secret %v", name)
@@ -95,6 +112,12 @@ func (*FakeManager) UpdateServersInPlus(upstream string, servers []string, confi
 	return nil
 }
 
+// UpdateStreamServersInPlus provides a fake implementation of UpdateStreamServersInPlus.
+func (*FakeManager) UpdateStreamServersInPlus(upstream string, servers []string, config ServerConfig) error {
+	glog.V(3).Infof("Updating stream servers of %v: %v", upstream, servers)
+	return nil
+}
+
 // UpdateWallarmTarantoolConfigFile writes the Wallarm Tarantool Service configuration file to the filesystem
 func (*FakeManager) UpdateWallarmTarantoolConfigFile(name string, content []byte) {
 	glog.V(3).Infof("Writing config to %v", name)
@@ -20,6 +20,9 @@ const TLSSecretFileMode = 0600
 // JWKSecretFileMode defines the default filemode for files with JWK Secrets.
 const JWKSecretFileMode = 0644
 
+// CASecretFileMode defines the default filemode for files with CA Secrets.
+const CASecretFileMode = 0644
+
 const configFileMode = 0644
 
 // ServerConfig holds the config data for an upstream server in NGINX Plus.
@@ -35,6 +38,9 @@ type Manager interface {
 	CreateMainConfig(content []byte)
 	CreateConfig(name string, content []byte)
 	DeleteConfig(name string)
+	CreateStreamConfig(name string, content []byte)
+	DeleteStreamConfig(name string)
+	CreateTLSPassthroughHostsConfig(content []byte)
 	CreateSecret(name string, content []byte, mode os.FileMode) string
 	DeleteSecret(name string)
 	GetFilenameForSecret(name string) string
@@ -45,6 +51,7 @@ type Manager interface {
 	UpdateConfigVersionFile()
 	SetPlusClients(plusClient *client.NginxClient, plusConfigVersionCheckClient *http.Client)
 	UpdateServersInPlus(upstream string, servers []string, config ServerConfig) error
+	UpdateStreamServersInPlus(upstream string, servers []string, config ServerConfig) error
 	UpdateWallarmTarantoolConfigFile(name string, content []byte)
 	DeleteWallarmTarantoolConfigFile(name string)
 }
@@ -53,9 +60,11 @@ type Manager interface {
 // updates NGINX Plus upstream servers. It assumes that NGINX is running in the same container.
 type LocalManager struct {
 	confdPath                    string
+	streamConfdPath              string
 	secretsPath                  string
 	mainConfFilename             string
 	configVersionFilename        string
+	tlsPassthroughHostsFilename  string
 	binaryFilename               string
 	dhparamFilename              string
 	verifyConfigGenerator        *verifyConfigGenerator
@@ -76,18 +85,20 @@ func NewLocalManager(confPath string, binaryFilename string, mc collectors.Manag
 	}
 
 	manager := LocalManager{
-		confdPath:             path.Join(confPath, "conf.d"),
-		secretsPath:           path.Join(confPath, "secrets"),
-		dhparamFilename:       path.Join(confPath, "secrets", "dhparam.pem"),
-		mainConfFilename:      path.Join(confPath, "nginx.conf"),
-		configVersionFilename: path.Join(confPath, "config-version.conf"),
-		binaryFilename:        binaryFilename,
-		verifyConfigGenerator: verifyConfigGenerator,
-		configVersion:         0,
-		verifyClient:          newVerifyClient(),
-		reloadCmd:             fmt.Sprintf("%v -s %v", binaryFilename, "reload"),
-		quitCmd:               fmt.Sprintf("%v -s %v", binaryFilename, "quit"),
-		metricsCollector:      mc,
+		confdPath:                   path.Join(confPath, "conf.d"),
+		streamConfdPath:             path.Join(confPath, "stream-conf.d"),
+		secretsPath:                 path.Join(confPath, "secrets"),
+		dhparamFilename:             path.Join(confPath, "secrets", "dhparam.pem"),
+		mainConfFilename:            path.Join(confPath, "nginx.conf"),
+		configVersionFilename:       path.Join(confPath, "config-version.conf"),
+		tlsPassthroughHostsFilename: path.Join(confPath, "tls-passthrough-hosts.conf"),
+		binaryFilename:              binaryFilename,
+		verifyConfigGenerator:       verifyConfigGenerator,
+		configVersion:               0,
+		verifyClient:                newVerifyClient(),
+		reloadCmd:                   fmt.Sprintf("%v -s %v", binaryFilename, "reload"),
+		quitCmd:                     fmt.Sprintf("%v -s %v", binaryFilename, "quit"),
+		metricsCollector:            mc,
 	}
 
 	return &manager
@@ -132,6 +143,46 @@ func (lm *LocalManager) getFilenameForConfig(name string) string {
 	return path.Join(lm.confdPath, name+".conf")
 }
 
+// CreateStreamConfig creates a configuration file for the stream module. If the file already exists, it will be overridden.
+func (lm *LocalManager) CreateStreamConfig(name string, content []byte) {
+	filename := lm.getFilenameForStreamConfig(name)
+
+	glog.V(3).Infof("Writing stream config to %v", filename)
+	glog.V(3).Info(string(content))
+
+	err := createFileAndWrite(filename, content)
+	if err != nil {
+		glog.Fatalf("Failed to write stream config to %v: %v", filename, err)
+	}
+}
+
+// DeleteStreamConfig deletes the configuration file from the stream-conf.d folder.
+func (lm *LocalManager) DeleteStreamConfig(name string) {
+	filename := lm.getFilenameForStreamConfig(name)
+
+	glog.V(3).Infof("Deleting stream config from %v", filename)
+
+	if err := os.Remove(filename); err != nil {
+		glog.Warningf("Failed to delete stream config from %v: %v", filename, err)
+	}
+}
+
+// CreateTLSPassthroughHostsConfig creates the configuration file that maps the hosts of the TLS Passthrough TransportServers
+// to the unix sockets of their servers. If the file already exists, it will be overridden.
+func (lm *LocalManager) CreateTLSPassthroughHostsConfig(content []byte) {
+	glog.V(3).Infof("Writing TLS Passthrough hosts config to %v", lm.tlsPassthroughHostsFilename)
+	glog.V(3).Info(string(content))
+
+	err := createFileAndWrite(lm.tlsPassthroughHostsFilename, content)
+	if err != nil {
+		glog.Fatalf("Failed to write TLS Passthrough hosts config to %v: %v", lm.tlsPassthroughHostsFilename, err)
+	}
+}
+
+func (lm *LocalManager) getFilenameForStreamConfig(name string) string {
+	return path.Join(lm.streamConfdPath, name+".conf")
+}
+
 func (lm *LocalManager) getWallarmTarantoolConfigFileName(name string) string {
 	return lm.getFilenameForConfig("wallarm-tarantool-" + name)
 }
@@ -154,7 +205,7 @@ func (lm *LocalManager) DeleteSecret(name string) {
 
 	glog.V(3).Infof("Deleting secret from %v", filename)
 
-	if err := os.Remove(filename); err != nil {
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
 		glog.Warningf("Failed to delete secret from %v: %v", filename, err)
 	}
 }
@@ -283,6 +334,36 @@ func (lm *LocalManager) UpdateServersInPlus(upstream string, servers []string, c
 	return nil
 }
 
+// UpdateStreamServersInPlus updates NGINX Plus stream servers of the given upstream.
+func (lm *LocalManager) UpdateStreamServersInPlus(upstream string, servers []string, config ServerConfig) error {
+	err := verifyConfigVersion(lm.plusConfigVersionCheckClient, lm.configVersion)
+	if err != nil {
+		return fmt.Errorf("error verifying config version: %v", err)
+	}
+
+	glog.V(3).Infof("API has the correct config version: %v.", lm.configVersion)
+
+	var upsServers []client.StreamUpstreamServer
+	for _, s := range servers {
+		upsServers = append(upsServers, client.StreamUpstreamServer{
+			Server:      s,
+			MaxFails:    config.MaxFails,
+			FailTimeout: config.FailTimeout,
+			SlowStart:   config.SlowStart,
+		})
+	}
+
+	added, removed, err := lm.plusClient.UpdateStreamServers(upstream, upsServers)
+	if err != nil {
+		glog.V(3).Infof("Couldn't update stream servers of %v upstream: %v", upstream, err)
+		return fmt.Errorf("error updating stream servers of %v upstream: %v", upstream, err)
+	}
+
+	glog.V(3).Infof("Updated stream servers of %v; Added: %v, Removed: %v", upstream, added, removed)
+
+	return nil
+}
+
 // verifyConfigVersion is used to check if the worker process that the API client is connected
 // to is using the latest version of nginx config. This way we avoid making changes on
 // a worker processes that is being shut down.
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// AdmissionReview is the subset of the admission.k8s.io AdmissionReview that the webhook needs.
+// The fields are the same in the v1beta1 and v1 versions of the API, so the webhook supports both.
+type AdmissionReview struct {
+	meta_v1.TypeMeta `json:",inline"`
+	Request          *AdmissionRequest  `json:"request,omitempty"`
+	Response         *AdmissionResponse `json:"response,omitempty"`
+}
+
+// AdmissionRequest describes the object that the API server asks the webhook to admit.
+type AdmissionRequest struct {
+	UID       types.UID                `json:"uid"`
+	Kind      meta_v1.GroupVersionKind `json:"kind"`
+	Name      string                   `json:"name,omitempty"`
+	Namespace string                   `json:"namespace,omitempty"`
+	Operation string                   `json:"operation"`
+	Object    runtime.RawExtension     `json:"object,omitempty"`
+}
+
+// AdmissionResponse describes the decision of the webhook.
+type AdmissionResponse struct {
+	UID     types.UID       `json:"uid"`
+	Allowed bool            `json:"allowed"`
+	Result  *meta_v1.Status `json:"status,omitempty"`
+}
@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidatePath is the path where the admission webhook is exposed.
+const ValidatePath = "/validate"
+
+// maxRequestBodySize limits the size of an AdmissionReview. The API server doesn't send objects larger than 3MB.
+const maxRequestBodySize = 4 * 1024 * 1024
+
+// Validator validates the resources that the Kubernetes API server sends to the admission webhook.
+type Validator struct {
+	isNginxPlus    bool
+	isNginxIngress func(ing *networking.Ingress) bool
+	configMapKey   string
+}
+
+// NewValidator creates a Validator. Only the Ingress resources for which isNginxIngress returns true are validated.
+// configMapKey is the ConfigMap of the Ingress Controller in the <namespace>/<name> format. If it is empty,
+// no ConfigMap is validated.
+func NewValidator(isNginxPlus bool, isNginxIngress func(ing *networking.Ingress) bool, configMapKey string) *Validator {
+	return &Validator{
+		isNginxPlus:    isNginxPlus,
+		isNginxIngress: isNginxIngress,
+		configMapKey:   configMapKey,
+	}
+}
+
+// ServeHTTP handles an AdmissionReview request and responds with an AdmissionReview that includes the decision.
+func (v *Validator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBodySize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read the request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var review AdmissionReview
+	err = json.Unmarshal(body, &review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode the AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "the AdmissionReview doesn't include a request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = v.review(review.Request)
+	review.Request = nil
+
+	resp, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode the AdmissionReview: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(resp)
+	if err != nil {
+		glog.Warningf("Error while sending an admission webhook response: %v", err)
+	}
+}
+
+func (v *Validator) review(req *AdmissionRequest) *AdmissionResponse {
+	resp := &AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+
+	if req.Operation != "CREATE" && req.Operation != "UPDATE" {
+		return resp
+	}
+
+	err := v.validate(req)
+	if err != nil {
+		glog.V(3).Infof("Admission webhook rejected %v %v/%v: %v", req.Kind.Kind, req.Namespace, req.Name, err)
+
+		resp.Allowed = false
+		resp.Result = &meta_v1.Status{
+			Status:  meta_v1.StatusFailure,
+			Code:    http.StatusUnprocessableEntity,
+			Reason:  meta_v1.StatusReasonInvalid,
+			Message: fmt.Sprintf("%v %v/%v is invalid: %v", req.Kind.Kind, req.Namespace, req.Name, err),
+		}
+	}
+
+	return resp
+}
+
+func (v *Validator) validate(req *AdmissionRequest) error {
+	kind := req.Kind
+
+	switch {
+	case kind.Kind == "Ingress" && (kind.Group == "extensions" || kind.Group == "networking.k8s.io"):
+		var ing networking.Ingress
+		if err := json.Unmarshal(req.Object.Raw, &ing); err != nil {
+			return fmt.Errorf("failed to decode the object: %v", err)
+		}
+
+		if !v.isNginxIngress(&ing) {
+			return nil
+		}
+
+		return configs.ValidateIngressAnnotations(&ing, v.isNginxPlus).ToAggregate()
+	case kind.Kind == "VirtualServer" && kind.Group == conf_v1alpha1.SchemeGroupVersion.Group:
+		var vs conf_v1alpha1.VirtualServer
+		if err := json.Unmarshal(req.Object.Raw, &vs); err != nil {
+			return fmt.Errorf("failed to decode the object: %v", err)
+		}
+
+		return validation.ValidateVirtualServer(&vs, v.isNginxPlus)
+	case kind.Kind == "VirtualServerRoute" && kind.Group == conf_v1alpha1.SchemeGroupVersion.Group:
+		var vsr conf_v1alpha1.VirtualServerRoute
+		if err := json.Unmarshal(req.Object.Raw, &vsr); err != nil {
+			return fmt.Errorf("failed to decode the object: %v", err)
+		}
+
+		return validation.ValidateVirtualServerRoute(&vsr, v.isNginxPlus)
+	case kind.Kind == "ConfigMap" && kind.Group == "":
+		if v.configMapKey != fmt.Sprintf("%v/%v", req.Namespace, req.Name) {
+			return nil
+		}
+
+		var cfgm api_v1.ConfigMap
+		if err := json.Unmarshal(req.Object.Raw, &cfgm); err != nil {
+			return fmt.Errorf("failed to decode the object: %v", err)
+		}
+
+		_, allErrs := configs.ParseConfigMap(&cfgm, v.isNginxPlus)
+		return allErrs.ToAggregate()
+	}
+
+	return nil
+}
+
+// RunWebhookServer runs an HTTPS server that exposes the admission webhook on the ValidatePath.
+// The certificate is not reloaded, so a rotated certificate only takes effect after a restart.
+func RunWebhookServer(port int, cert tls.Certificate, validator *Validator) {
+	mux := http.NewServeMux()
+	mux.Handle(ValidatePath, validator)
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%v", port),
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		},
+	}
+
+	glog.Infof("Starting admission webhook listener on: %v%v", server.Addr, ValidatePath)
+	glog.Fatal("Error in admission webhook listener server: ", server.ListenAndServeTLS("", ""))
+}
@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func isNginxIngress(ing *networking.Ingress) bool {
+	return ing.Annotations["kubernetes.io/ingress.class"] != "other"
+}
+
+func createReview(kind meta_v1.GroupVersionKind, operation string, object string) AdmissionReview {
+	return AdmissionReview{
+		TypeMeta: meta_v1.TypeMeta{
+			APIVersion: "admission.k8s.io/v1beta1",
+			Kind:       "AdmissionReview",
+		},
+		Request: &AdmissionRequest{
+			UID:       "3c4f5a60-6bd1-4b51-8d9a-1a2f3e4d5c6b",
+			Kind:      kind,
+			Name:      "cafe",
+			Namespace: "default",
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: []byte(object)},
+		},
+	}
+}
+
+func sendReview(t *testing.T, validator *Validator, review AdmissionReview) *AdmissionReview {
+	t.Helper()
+
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("Failed to encode the AdmissionReview: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	validator.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() returned the status code %d: %s", w.Code, w.Body.String())
+	}
+
+	var result AdmissionReview
+	err = json.Unmarshal(w.Body.Bytes(), &result)
+	if err != nil {
+		t.Fatalf("Failed to decode the response: %v", err)
+	}
+
+	return &result
+}
+
+var (
+	ingressKind            = meta_v1.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}
+	virtualServerKind      = meta_v1.GroupVersionKind{Group: "k8s.nginx.org", Version: "v1alpha1", Kind: "VirtualServer"}
+	virtualServerRouteKind = meta_v1.GroupVersionKind{Group: "k8s.nginx.org", Version: "v1alpha1", Kind: "VirtualServerRoute"}
+	configMapKind          = meta_v1.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}
+)
+
+func TestServeHTTP(t *testing.T) {
+	validator := NewValidator(false, isNginxIngress, "nginx-ingress/nginx-config")
+
+	tests := []struct {
+		review          AdmissionReview
+		expectedAllowed bool
+		expectedErrors  []string
+		msg             string
+	}{
+		{
+			review: createReview(ingressKind, "CREATE",
+				`{"metadata":{"name":"cafe","annotations":{"nginx.org/lb-method":"least_conn"}}}`),
+			expectedAllowed: true,
+			msg:             "valid ingress",
+		},
+		{
+			review: createReview(ingressKind, "CREATE",
+				`{"metadata":{"name":"cafe","annotations":{"nginx.org/redirect-to-https":"yes","nginx.org/max-fails":"-"}}}`),
+			expectedAllowed: false,
+			expectedErrors: []string{
+				`metadata.annotations[nginx.org/redirect-to-https]: Invalid value: "yes": must be a boolean`,
+				`metadata.annotations[nginx.org/max-fails]: Invalid value: "-": must be an integer`,
+			},
+			msg: "ingress with invalid annotations",
+		},
+		{
+			review: createReview(ingressKind, "UPDATE",
+				`{"metadata":{"name":"cafe","annotations":{"kubernetes.io/ingress.class":"other","nginx.org/max-fails":"-"}}}`),
+			expectedAllowed: true,
+			msg:             "ingress of another ingress class",
+		},
+		{
+			review: createReview(virtualServerKind, "CREATE",
+				`{"metadata":{"name":"cafe"},"spec":{"host":"cafe.example.com"}}`),
+			expectedAllowed: true,
+			msg:             "valid virtualserver",
+		},
+		{
+			review: createReview(virtualServerKind, "UPDATE",
+				`{"metadata":{"name":"cafe"},"spec":{"host":"cafe.example.com","upstreams":[{"name":"tea","service":"tea-svc","port":0}]}}`),
+			expectedAllowed: false,
+			expectedErrors:  []string{"spec.upstreams[0].port"},
+			msg:             "invalid virtualserver",
+		},
+		{
+			review: createReview(virtualServerRouteKind, "CREATE",
+				`{"metadata":{"name":"tea"},"spec":{"host":"cafe.example.com","subroutes":[{"path":"tea","upstream":"tea"}]}}`),
+			expectedAllowed: false,
+			expectedErrors:  []string{"spec.subroutes[0].path", "spec.subroutes[0].upstream"},
+			msg:             "invalid virtualserverroute",
+		},
+		{
+			review:          createReview(virtualServerKind, "DELETE", `null`),
+			expectedAllowed: true,
+			msg:             "delete operation",
+		},
+		{
+			review: createReview(configMapKind, "UPDATE",
+				`{"metadata":{"name":"nginx-config","namespace":"nginx-ingress"},"data":{"proxy-buffering":"maybe"}}`),
+			expectedAllowed: true,
+			msg:             "configmap in another namespace",
+		},
+	}
+
+	for _, test := range tests {
+		result := sendReview(t, validator, test.review)
+
+		if result.Response == nil {
+			t.Errorf("ServeHTTP() returned no response for the case of %s", test.msg)
+			continue
+		}
+		if result.Response.UID != test.review.Request.UID {
+			t.Errorf("ServeHTTP() returned the UID %q but expected %q for the case of %s", result.Response.UID, test.review.Request.UID, test.msg)
+		}
+		if result.APIVersion != test.review.APIVersion || result.Kind != test.review.Kind {
+			t.Errorf("ServeHTTP() returned %v but expected %v for the case of %s", result.TypeMeta, test.review.TypeMeta, test.msg)
+		}
+		if result.Response.Allowed != test.expectedAllowed {
+			t.Errorf("ServeHTTP() returned allowed %v but expected %v for the case of %s", result.Response.Allowed, test.expectedAllowed, test.msg)
+		}
+		if test.expectedAllowed {
+			continue
+		}
+		if result.Response.Result == nil || result.Response.Result.Reason != meta_v1.StatusReasonInvalid {
+			t.Errorf("ServeHTTP() returned the status %+v for the case of %s", result.Response.Result, test.msg)
+			continue
+		}
+		for _, expected := range test.expectedErrors {
+			if !strings.Contains(result.Response.Result.Message, expected) {
+				t.Errorf("ServeHTTP() returned the message %q that doesn't include %q for the case of %s", result.Response.Result.Message, expected, test.msg)
+			}
+		}
+	}
+}
+
+func TestServeHTTPForConfigMap(t *testing.T) {
+	validator := NewValidator(false, isNginxIngress, "default/cafe")
+
+	review := createReview(configMapKind, "UPDATE", `{"metadata":{"name":"cafe","namespace":"default"},"data":{"proxy-buffering":"maybe"}}`)
+	result := sendReview(t, validator, review)
+
+	if result.Response.Allowed {
+		t.Fatalf("ServeHTTP() allowed the ConfigMap of the Ingress Controller with an invalid key")
+	}
+
+	expected := `ConfigMap default/cafe is invalid: data[proxy-buffering]: Invalid value: "maybe": must be a boolean`
+	if result.Response.Result.Message != expected {
+		t.Errorf("ServeHTTP() returned the message %q but expected %q", result.Response.Result.Message, expected)
+	}
+}
+
+func TestServeHTTPFails(t *testing.T) {
+	validator := NewValidator(false, isNginxIngress, "")
+
+	tests := []struct {
+		method       string
+		body         string
+		expectedCode int
+		msg          string
+	}{
+		{
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+			msg:          "GET request",
+		},
+		{
+			method:       http.MethodPost,
+			body:         `{"kind":`,
+			expectedCode: http.StatusBadRequest,
+			msg:          "malformed body",
+		},
+		{
+			method:       http.MethodPost,
+			body:         `{"kind":"AdmissionReview","apiVersion":"admission.k8s.io/v1"}`,
+			expectedCode: http.StatusBadRequest,
+			msg:          "review without a request",
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, ValidatePath, strings.NewReader(test.body))
+		w := httptest.NewRecorder()
+
+		validator.ServeHTTP(w, req)
+
+		if w.Code != test.expectedCode {
+			t.Errorf("ServeHTTP() returned the status code %d but expected %d for the case of %s", w.Code, test.expectedCode, test.msg)
+		}
+	}
+}
@@ -32,6 +32,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VirtualServerList{},
 		&VirtualServerRoute{},
 		&VirtualServerRouteList{},
+		&TransportServer{},
+		&TransportServerList{},
+		&Policy{},
+		&PolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
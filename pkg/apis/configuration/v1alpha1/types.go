@@ -4,6 +4,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// StateValid is used when the resource has been validated and accepted and its configuration was applied.
+	StateValid = "Valid"
+	// StateWarning is used when the resource has been validated and accepted but it might work in a degraded state.
+	StateWarning = "Warning"
+	// StateInvalid is used when the resource failed validation or NGINX failed to apply its configuration.
+	StateInvalid = "Invalid"
+)
+
+// TLSPassthroughProtocol is the protocol of a TransportServer listener that passes TLS connections to the upstreams
+// based on the SNI extension, without decrypting them.
+const TLSPassthroughProtocol = "TLS_PASSTHROUGH"
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -12,37 +25,169 @@ type VirtualServer struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec VirtualServerSpec `json:"spec"`
+	Spec   VirtualServerSpec   `json:"spec"`
+	Status VirtualServerStatus `json:"status"`
 }
 
 // VirtualServerSpec is the spec of the VirtualServer resource.
 type VirtualServerSpec struct {
-	Host      string     `json:"host"`
-	TLS       *TLS       `json:"tls"`
-	Upstreams []Upstream `json:"upstreams"`
-	Routes    []Route    `json:"routes"`
+	Host      string            `json:"host"`
+	TLS       *TLS              `json:"tls"`
+	Upstreams []Upstream        `json:"upstreams"`
+	Routes    []Route           `json:"routes"`
+	Policies  []PolicyReference `json:"policies"`
+	Wallarm   *Wallarm          `json:"wallarm"`
 }
 
 // Upstream defines an upstream.
 type Upstream struct {
-	Name    string `json:"name"`
-	Service string `json:"service"`
-	Port    uint16 `json:"port"`
+	Name                     string            `json:"name"`
+	Service                  string            `json:"service"`
+	Subselector              map[string]string `json:"subselector"`
+	Port                     uint16            `json:"port"`
+	Type                     string            `json:"type"`
+	LBMethod                 string            `json:"lb-method"`
+	FailTimeout              string            `json:"fail-timeout"`
+	MaxFails                 *int              `json:"max-fails"`
+	MaxConns                 *int              `json:"max-conns"`
+	Keepalive                *int              `json:"keepalive"`
+	ZoneAwareRouting         bool              `json:"zone-aware-routing"`
+	ProxyConnectTimeout      string            `json:"connect-timeout"`
+	ProxyReadTimeout         string            `json:"read-timeout"`
+	ProxySendTimeout         string            `json:"send-timeout"`
+	ProxyBuffering           *bool             `json:"buffering"`
+	ProxyBuffers             *UpstreamBuffers  `json:"buffers"`
+	ProxyBufferSize          string            `json:"buffer-size"`
+	ClientMaxBodySize        string            `json:"client-max-body-size"`
+	ProxyNextUpstream        string            `json:"next-upstream"`
+	ProxyNextUpstreamTimeout string            `json:"next-upstream-timeout"`
+	ProxyNextUpstreamTries   *int              `json:"next-upstream-tries"`
+	HealthCheck              *HealthCheck      `json:"healthCheck"`
+	SessionCookie            *SessionCookie    `json:"sessionCookie"`
+	TLS                      *UpstreamTLS      `json:"tls"`
+}
+
+// UpstreamBuffers defines Buffer Configuration for an Upstream.
+type UpstreamBuffers struct {
+	Number int    `json:"number"`
+	Size   string `json:"size"`
+}
+
+// SessionCookie defines the parameters for session persistence of an Upstream.
+type SessionCookie struct {
+	Enable   bool   `json:"enable"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Expires  string `json:"expires"`
+	Domain   string `json:"domain"`
+	HTTPOnly bool   `json:"httpOnly"`
+	Secure   bool   `json:"secure"`
+}
+
+// HealthCheck defines the parameters for active health checks of an Upstream.
+type HealthCheck struct {
+	Enable         bool         `json:"enable"`
+	Path           string       `json:"path"`
+	Interval       string       `json:"interval"`
+	Jitter         string       `json:"jitter"`
+	Fails          *int         `json:"fails"`
+	Passes         *int         `json:"passes"`
+	Port           int          `json:"port"`
+	TLS            *UpstreamTLS `json:"tls"`
+	ConnectTimeout string       `json:"connect-timeout"`
+	ReadTimeout    string       `json:"read-timeout"`
+	SendTimeout    string       `json:"send-timeout"`
+	Headers        []Header     `json:"headers"`
+	StatusMatch    string       `json:"statusMatch"`
+	BodyMatch      string       `json:"bodyMatch"`
+}
+
+// UpstreamTLS defines the TLS configuration for connections to an Upstream.
+type UpstreamTLS struct {
+	Enable            bool   `json:"enable"`
+	TrustedCertSecret string `json:"trustedCertSecret"`
+	VerifyDepth       *int   `json:"verifyDepth"`
+	ServerName        string `json:"serverName"`
+	Secret            string `json:"secret"`
+}
+
+// Header defines an HTTP Header.
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // Route defines a route.
 type Route struct {
-	Path     string  `json:"path"`
-	Upstream string  `json:"upstream"`
-	Splits   []Split `json:"splits"`
-	Rules    *Rules  `json:"rules"`
-	Route    string  `json:"route"`
+	Path            string                `json:"path"`
+	Upstream        string                `json:"upstream"`
+	Action          *Action               `json:"action"`
+	Splits          []Split               `json:"splits"`
+	SplitKey        string                `json:"splitKey"`
+	SplitCookie     *SplitCookie          `json:"splitCookie"`
+	Rules           *Rules                `json:"rules"`
+	Route           string                `json:"route"`
+	RewritePath     string                `json:"rewritePath"`
+	RequestHeaders  *ProxyRequestHeaders  `json:"requestHeaders"`
+	ResponseHeaders *ProxyResponseHeaders `json:"responseHeaders"`
+	Policies        []PolicyReference     `json:"policies"`
+	Wallarm         *Wallarm              `json:"wallarm"`
+}
+
+// SplitCookie defines a cookie that pins a client to the split chosen for its first request.
+type SplitCookie struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	MaxAge *int   `json:"maxAge"`
+}
+
+// ProxyRequestHeaders defines the request headers manipulation in a route.
+type ProxyRequestHeaders struct {
+	Pass   *bool    `json:"pass"`
+	Set    []Header `json:"set"`
+	Ignore []string `json:"ignore"`
+}
+
+// ProxyResponseHeaders defines the response headers manipulation in a route.
+type ProxyResponseHeaders struct {
+	Add    []AddHeader `json:"add"`
+	Hide   []string    `json:"hide"`
+	Pass   []string    `json:"pass"`
+	Ignore []string    `json:"ignore"`
+}
+
+// AddHeader defines an HTTP Header with an optional Always field to use with the add_header NGINX directive.
+type AddHeader struct {
+	Header `json:",inline"`
+	Always bool `json:"always"`
+}
+
+// Action defines an action.
+type Action struct {
+	Pass     string          `json:"pass"`
+	Redirect *ActionRedirect `json:"redirect"`
+	Return   *ActionReturn   `json:"return"`
+}
+
+// ActionRedirect defines a redirect in an Action.
+type ActionRedirect struct {
+	URL  string `json:"url"`
+	Code int    `json:"code"`
+}
+
+// ActionReturn defines a return in an Action.
+type ActionReturn struct {
+	Code int    `json:"code"`
+	Type string `json:"type"`
+	Body string `json:"body"`
 }
 
 // Split defines a split.
 type Split struct {
-	Weight   int    `json:"weight"`
-	Upstream string `json:"upstream"`
+	Weight      int     `json:"weight"`
+	Upstream    string  `json:"upstream"`
+	Action      *Action `json:"action"`
+	RewritePath string  `json:"rewritePath"`
 }
 
 // Rules defines rules.
@@ -62,13 +207,59 @@ type Condition struct {
 
 // Match defines a match in a MatchRule.
 type Match struct {
-	Values   []string `json:"values"`
-	Upstream string   `json:"upstream"`
+	Values      []string `json:"values"`
+	Upstream    string   `json:"upstream"`
+	Action      *Action  `json:"action"`
+	RewritePath string   `json:"rewritePath"`
+}
+
+// PolicyReference references a Policy resource by its name and, optionally, its namespace.
+type PolicyReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
 }
 
 // TLS defines TLS configuration for a VirtualServer.
 type TLS struct {
-	Secret string `json:"secret"`
+	Secret            string             `json:"secret"`
+	ClientCertificate *ClientCertificate `json:"clientCertificate"`
+}
+
+// ClientCertificate defines the verification of client certificates for a VirtualServer.
+type ClientCertificate struct {
+	Secret            string `json:"secret"`
+	Verify            string `json:"verify"`
+	VerifyDepth       *int   `json:"verifyDepth"`
+	ForwardToUpstream *bool  `json:"forwardToUpstream"`
+}
+
+// Wallarm defines Wallarm protection settings for a VirtualServer, a route or a subroute.
+type Wallarm struct {
+	Mode              string   `json:"mode"`
+	ModeAllowOverride string   `json:"modeAllowOverride"`
+	Fallback          string   `json:"fallback"`
+	Instance          string   `json:"instance"`
+	BlockPage         string   `json:"blockPage"`
+	ParseResponse     string   `json:"parseResponse"`
+	ParseWebsocket    string   `json:"parseWebsocket"`
+	UnpackResponse    string   `json:"unpackResponse"`
+	ParserDisable     []string `json:"parserDisable"`
+}
+
+// VirtualServerStatus defines the status of the VirtualServer resource.
+type VirtualServerStatus struct {
+	State              string             `json:"state"`
+	Reason             string             `json:"reason"`
+	Message            string             `json:"message"`
+	ObservedGeneration int64              `json:"observedGeneration"`
+	ExternalEndpoints  []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+}
+
+// ExternalEndpoint defines the IP or hostname and the ports used to connect to a resource.
+type ExternalEndpoint struct {
+	IP       string `json:"ip,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	Ports    string `json:"ports"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -88,7 +279,8 @@ type VirtualServerRoute struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec VirtualServerRouteSpec `json:"spec"`
+	Spec   VirtualServerRouteSpec   `json:"spec"`
+	Status VirtualServerRouteStatus `json:"status"`
 }
 
 type VirtualServerRouteSpec struct {
@@ -97,6 +289,15 @@ type VirtualServerRouteSpec struct {
 	Subroutes []Route    `json:"subroutes"`
 }
 
+// VirtualServerRouteStatus defines the status of the VirtualServerRoute resource.
+type VirtualServerRouteStatus struct {
+	State              string             `json:"state"`
+	Reason             string             `json:"reason"`
+	Message            string             `json:"message"`
+	ObservedGeneration int64              `json:"observedGeneration"`
+	ExternalEndpoints  []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type VirtualServerRouteList struct {
@@ -105,3 +306,113 @@ type VirtualServerRouteList struct {
 
 	Items []VirtualServerRoute `json:"items"`
 }
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TransportServer defines the TransportServer resource.
+type TransportServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TransportServerSpec `json:"spec"`
+}
+
+// TransportServerSpec is the spec of the TransportServer resource.
+type TransportServerSpec struct {
+	Listener  TransportServerListener   `json:"listener"`
+	Host      string                    `json:"host"`
+	Upstreams []TransportServerUpstream `json:"upstreams"`
+	Action    *TransportServerAction    `json:"action"`
+}
+
+// TransportServerListener defines a listener for a TransportServer.
+type TransportServerListener struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// TransportServerUpstream defines an upstream of a TransportServer.
+type TransportServerUpstream struct {
+	Name                string `json:"name"`
+	Service             string `json:"service"`
+	Port                uint16 `json:"port"`
+	LBMethod            string `json:"lb-method"`
+	MaxFails            *int   `json:"max-fails"`
+	FailTimeout         string `json:"fail-timeout"`
+	ProxyConnectTimeout string `json:"connect-timeout"`
+	ProxyTimeout        string `json:"timeout"`
+}
+
+// TransportServerAction defines an action for a TransportServer.
+type TransportServerAction struct {
+	Pass string `json:"pass"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TransportServerList is a list of the TransportServer resources.
+type TransportServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TransportServer `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Policy defines a Policy resource.
+type Policy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PolicySpec `json:"spec"`
+}
+
+// PolicySpec is the spec of the Policy resource.
+// The spec includes multiple fields, where each field represents a different policy.
+// Only one policy (field) is allowed.
+type PolicySpec struct {
+	AccessControl *AccessControl `json:"accessControl"`
+	RateLimit     *RateLimit     `json:"rateLimit"`
+	JWTAuth       *JWTAuth       `json:"jwt"`
+}
+
+// AccessControl defines an access policy based on the source IP of a request.
+type AccessControl struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// RateLimit defines a rate limit policy.
+type RateLimit struct {
+	Rate       string `json:"rate"`
+	Key        string `json:"key"`
+	Burst      *int   `json:"burst"`
+	NoDelay    *bool  `json:"noDelay"`
+	ZoneSize   string `json:"zoneSize"`
+	DryRun     *bool  `json:"dryRun"`
+	LogLevel   string `json:"logLevel"`
+	RejectCode *int   `json:"rejectCode"`
+}
+
+// JWTAuth defines a JWT authentication policy. NGINX Plus only.
+type JWTAuth struct {
+	Realm    string `json:"realm"`
+	Secret   string `json:"secret"`
+	Token    string `json:"token"`
+	LoginURL string `json:"loginURL"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyList is a list of the Policy resources.
+type PolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Policy `json:"items"`
+}
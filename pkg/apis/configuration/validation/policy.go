@@ -0,0 +1,212 @@
+package validation
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidatePolicy validates a Policy.
+func ValidatePolicy(policy *v1alpha1.Policy, isPlus bool) error {
+	allErrs := validatePolicySpec(&policy.Spec, field.NewPath("spec"), isPlus)
+	return allErrs.ToAggregate()
+}
+
+func validatePolicySpec(spec *v1alpha1.PolicySpec, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fieldCount := 0
+
+	if spec.AccessControl != nil {
+		allErrs = append(allErrs, validateAccessControl(spec.AccessControl, fieldPath.Child("accessControl"))...)
+		fieldCount++
+	}
+
+	if spec.RateLimit != nil {
+		allErrs = append(allErrs, validateRateLimit(spec.RateLimit, fieldPath.Child("rateLimit"))...)
+		fieldCount++
+	}
+
+	if spec.JWTAuth != nil {
+		if !isPlus {
+			return append(allErrs, field.Forbidden(fieldPath.Child("jwt"), "jwt is only supported in NGINX Plus"))
+		}
+
+		allErrs = append(allErrs, validateJWT(spec.JWTAuth, fieldPath.Child("jwt"))...)
+		fieldCount++
+	}
+
+	if fieldCount != 1 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `accessControl`, `rateLimit` or `jwt`"))
+	}
+
+	return allErrs
+}
+
+func validateAccessControl(accessControl *v1alpha1.AccessControl, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fieldCount := 0
+
+	if accessControl.Allow != nil {
+		allErrs = append(allErrs, validateIPorCIDRs(accessControl.Allow, fieldPath.Child("allow"))...)
+		fieldCount++
+	}
+
+	if accessControl.Deny != nil {
+		allErrs = append(allErrs, validateIPorCIDRs(accessControl.Deny, fieldPath.Child("deny"))...)
+		fieldCount++
+	}
+
+	if fieldCount != 1 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `allow` or `deny`"))
+	}
+
+	return allErrs
+}
+
+func validateIPorCIDRs(values []string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(values) == 0 {
+		return append(allErrs, field.Required(fieldPath, "must include at least one IP address or CIDR range"))
+	}
+
+	for i, value := range values {
+		allErrs = append(allErrs, validateIPorCIDR(value, fieldPath.Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validateIPorCIDR(value string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, _, err := net.ParseCIDR(value); err == nil {
+		return allErrs
+	}
+
+	if net.ParseIP(value) == nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath, value, "must be a valid IP address or CIDR range, e.g. 10.0.0.1 or 10.0.0.0/8"))
+	}
+
+	return allErrs
+}
+
+func validateRateLimit(rateLimit *v1alpha1.RateLimit, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rateLimit.Rate == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("rate"), ""))
+	} else if _, err := configs.ParseRequestRate(rateLimit.Rate); err != nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("rate"), rateLimit.Rate, "must be a valid request rate, e.g. 10r/s or 100r/m"))
+	}
+
+	if rateLimit.Key == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("key"), ""))
+	} else {
+		allErrs = append(allErrs, validateStringWithVariables(rateLimit.Key, fieldPath.Child("key"))...)
+	}
+
+	allErrs = append(allErrs, validatePositiveInt(rateLimit.Burst, fieldPath.Child("burst"))...)
+	allErrs = append(allErrs, validateSize(rateLimit.ZoneSize, fieldPath.Child("zoneSize"))...)
+
+	if rateLimit.LogLevel != "" {
+		if _, err := configs.ParseLimitReqLogLevel(rateLimit.LogLevel); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("logLevel"), rateLimit.LogLevel, err.Error()))
+		}
+	}
+
+	if rateLimit.RejectCode != nil && (*rateLimit.RejectCode < 400 || *rateLimit.RejectCode > 599) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("rejectCode"), *rateLimit.RejectCode, "must be in the range 400-599"))
+	}
+
+	return allErrs
+}
+
+func validateJWT(jwt *v1alpha1.JWTAuth, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if jwt.Realm == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("realm"), ""))
+	} else {
+		for _, msg := range isValidMatchValue(jwt.Realm) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("realm"), jwt.Realm, msg))
+		}
+	}
+
+	if jwt.Secret == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("secret"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(jwt.Secret) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("secret"), jwt.Secret, msg))
+		}
+	}
+
+	if jwt.Token != "" {
+		for _, msg := range isValidJWTToken(jwt.Token) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("token"), jwt.Token, msg))
+		}
+	}
+
+	if jwt.LoginURL != "" {
+		for _, msg := range isValidRedirectURL(jwt.LoginURL) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("loginURL"), jwt.LoginURL, msg))
+		}
+	}
+
+	return allErrs
+}
+
+const jwtTokenFmt = `\$(http|cookie|arg)_[a-zA-Z0-9_]+`
+const jwtTokenErrMsg = "must be a variable that starts with $http_, $cookie_ or $arg_"
+
+var jwtTokenRegexp = regexp.MustCompile("^" + jwtTokenFmt + "$")
+
+func isValidJWTToken(token string) []string {
+	if !jwtTokenRegexp.MatchString(token) {
+		return []string{validation.RegexError(jwtTokenErrMsg, jwtTokenFmt, "$http_token", "$cookie_auth_token", "$arg_token")}
+	}
+	return nil
+}
+
+func validatePolicies(policies []v1alpha1.PolicyReference, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	policyKeys := make(map[string]bool)
+
+	for i, p := range policies {
+		idxPath := fieldPath.Index(i)
+
+		key := p.Name
+		if p.Namespace != "" {
+			key = fmt.Sprintf("%s/%s", p.Namespace, p.Name)
+		}
+
+		if _, exists := policyKeys[key]; exists {
+			allErrs = append(allErrs, field.Duplicate(idxPath, key))
+		} else {
+			policyKeys[key] = true
+		}
+
+		if p.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(p.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), p.Name, msg))
+			}
+		}
+
+		if p.Namespace != "" {
+			for _, msg := range validation.IsDNS1123Label(p.Namespace) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("namespace"), p.Namespace, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,450 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	policy := &v1alpha1.Policy{
+		Spec: v1alpha1.PolicySpec{
+			AccessControl: &v1alpha1.AccessControl{
+				Allow: []string{"127.0.0.1"},
+			},
+		},
+	}
+
+	isPlus := false
+
+	err := ValidatePolicy(policy, isPlus)
+	if err != nil {
+		t.Errorf("ValidatePolicy() returned error %v for valid input", err)
+	}
+}
+
+func TestValidatePolicyForJWT(t *testing.T) {
+	policy := &v1alpha1.Policy{
+		Spec: v1alpha1.PolicySpec{
+			JWTAuth: &v1alpha1.JWTAuth{
+				Realm:  "My Product API",
+				Secret: "my-jwk",
+			},
+		},
+	}
+	isPlus := true
+
+	err := ValidatePolicy(policy, isPlus)
+	if err != nil {
+		t.Errorf("ValidatePolicy() returned error %v for valid input", err)
+	}
+}
+
+func TestValidatePolicyFails(t *testing.T) {
+	tests := []struct {
+		policy *v1alpha1.Policy
+		msg    string
+	}{
+		{
+			policy: &v1alpha1.Policy{
+				Spec: v1alpha1.PolicySpec{},
+			},
+			msg: "empty policy spec",
+		},
+		{
+			policy: &v1alpha1.Policy{
+				Spec: v1alpha1.PolicySpec{
+					AccessControl: &v1alpha1.AccessControl{
+						Allow: []string{"127.0.0.1"},
+					},
+					RateLimit: &v1alpha1.RateLimit{
+						Rate: "10r/s",
+						Key:  "${binary_remote_addr}",
+					},
+				},
+			},
+			msg: "multiple policies in spec",
+		},
+		{
+			policy: &v1alpha1.Policy{
+				Spec: v1alpha1.PolicySpec{
+					JWTAuth: &v1alpha1.JWTAuth{
+						Realm:  "My Product API",
+						Secret: "my-jwk",
+					},
+				},
+			},
+			msg: "jwt policy for NGINX",
+		},
+	}
+
+	isPlus := false
+
+	for _, test := range tests {
+		err := ValidatePolicy(test.policy, isPlus)
+		if err == nil {
+			t.Errorf("ValidatePolicy() returned no error for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateAccessControl(t *testing.T) {
+	validInput := []*v1alpha1.AccessControl{
+		{
+			Allow: []string{"127.0.0.1"},
+		},
+		{
+			Deny: []string{"127.0.0.1", "10.0.0.0/8"},
+		},
+	}
+
+	for _, input := range validInput {
+		allErrs := validateAccessControl(input, field.NewPath("accessControl"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateAccessControl(%+v) returned errors %v for valid input", input, allErrs)
+		}
+	}
+}
+
+func TestValidateAccessControlFails(t *testing.T) {
+	tests := []struct {
+		accessControl *v1alpha1.AccessControl
+		msg           string
+	}{
+		{
+			accessControl: &v1alpha1.AccessControl{
+				Allow: []string{"127.0.0.1"},
+				Deny:  []string{"127.0.0.2"},
+			},
+			msg: "both allow and deny are defined",
+		},
+		{
+			accessControl: &v1alpha1.AccessControl{},
+			msg:           "neither allow nor deny is defined",
+		},
+		{
+			accessControl: &v1alpha1.AccessControl{
+				Allow: []string{},
+			},
+			msg: "empty allow",
+		},
+		{
+			accessControl: &v1alpha1.AccessControl{
+				Allow: []string{"invalid"},
+			},
+			msg: "invalid allow",
+		},
+		{
+			accessControl: &v1alpha1.AccessControl{
+				Deny: []string{"10.0.0.0/33"},
+			},
+			msg: "invalid deny",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAccessControl(test.accessControl, field.NewPath("accessControl"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateAccessControl() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateIPorCIDR(t *testing.T) {
+	validInput := []string{
+		"192.168.1.1",
+		"192.168.1.0/24",
+		"2001:0db8::1",
+		"2001:0db8::/32",
+	}
+
+	for _, input := range validInput {
+		allErrs := validateIPorCIDR(input, field.NewPath("ipOrCIDR"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateIPorCIDR(%q) returned errors %v for valid input", input, allErrs)
+		}
+	}
+
+	invalidInput := []string{
+		"",
+		"localhost",
+		"192.168.1.0/",
+		"192.168.1.256",
+		"192.168.1.0/33",
+		"2001:0db8::/129",
+		"all",
+	}
+
+	for _, input := range invalidInput {
+		allErrs := validateIPorCIDR(input, field.NewPath("ipOrCIDR"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateIPorCIDR(%q) returned no errors for invalid input", input)
+		}
+	}
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	burst := 10
+	noDelay := true
+	dryRun := false
+	rejectCode := 429
+
+	validInput := []*v1alpha1.RateLimit{
+		{
+			Rate: "10r/s",
+			Key:  "${binary_remote_addr}",
+		},
+		{
+			Rate:       "100r/m",
+			Key:        "${binary_remote_addr}${request_uri}",
+			Burst:      &burst,
+			NoDelay:    &noDelay,
+			ZoneSize:   "20m",
+			DryRun:     &dryRun,
+			LogLevel:   "warn",
+			RejectCode: &rejectCode,
+		},
+	}
+
+	for _, input := range validInput {
+		allErrs := validateRateLimit(input, field.NewPath("rateLimit"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateRateLimit(%+v) returned errors %v for valid input", input, allErrs)
+		}
+	}
+}
+
+func TestValidateRateLimitFails(t *testing.T) {
+	invalidBurst := 0
+	invalidRejectCode := 302
+
+	tests := []struct {
+		rateLimit *v1alpha1.RateLimit
+		msg       string
+	}{
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Key: "${binary_remote_addr}",
+			},
+			msg: "missing rate",
+		},
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Rate: "10r/h",
+				Key:  "${binary_remote_addr}",
+			},
+			msg: "invalid rate",
+		},
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Rate: "10r/s",
+			},
+			msg: "missing key",
+		},
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Rate: "10r/s",
+				Key:  "${unknown_variable}",
+			},
+			msg: "invalid key",
+		},
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Rate:  "10r/s",
+				Key:   "${binary_remote_addr}",
+				Burst: &invalidBurst,
+			},
+			msg: "invalid burst",
+		},
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Rate:     "10r/s",
+				Key:      "${binary_remote_addr}",
+				ZoneSize: "10g",
+			},
+			msg: "invalid zone size",
+		},
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Rate:     "10r/s",
+				Key:      "${binary_remote_addr}",
+				LogLevel: "debug",
+			},
+			msg: "invalid log level",
+		},
+		{
+			rateLimit: &v1alpha1.RateLimit{
+				Rate:       "10r/s",
+				Key:        "${binary_remote_addr}",
+				RejectCode: &invalidRejectCode,
+			},
+			msg: "invalid reject code",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRateLimit(test.rateLimit, field.NewPath("rateLimit"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRateLimit() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateJWT(t *testing.T) {
+	validInput := []*v1alpha1.JWTAuth{
+		{
+			Realm:  "My Product API",
+			Secret: "my-jwk",
+		},
+		{
+			Realm:    "My Product \\\"API\\\"",
+			Secret:   "my-jwk",
+			Token:    "$cookie_auth_token",
+			LoginURL: "https://login.example.com",
+		},
+		{
+			Realm:  "My Product API",
+			Secret: "my-jwk",
+			Token:  "$http_token",
+		},
+	}
+
+	for _, input := range validInput {
+		allErrs := validateJWT(input, field.NewPath("jwt"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateJWT(%+v) returned errors %v for valid input", input, allErrs)
+		}
+	}
+}
+
+func TestValidateJWTFails(t *testing.T) {
+	tests := []struct {
+		jwt *v1alpha1.JWTAuth
+		msg string
+	}{
+		{
+			jwt: &v1alpha1.JWTAuth{
+				Secret: "my-jwk",
+			},
+			msg: "missing realm",
+		},
+		{
+			jwt: &v1alpha1.JWTAuth{
+				Realm:  "My Product \"API",
+				Secret: "my-jwk",
+			},
+			msg: "invalid realm",
+		},
+		{
+			jwt: &v1alpha1.JWTAuth{
+				Realm: "My Product API",
+			},
+			msg: "missing secret",
+		},
+		{
+			jwt: &v1alpha1.JWTAuth{
+				Realm:  "My Product API",
+				Secret: "-invalid",
+			},
+			msg: "invalid secret",
+		},
+		{
+			jwt: &v1alpha1.JWTAuth{
+				Realm:  "My Product API",
+				Secret: "my-jwk",
+				Token:  "$request_uri",
+			},
+			msg: "invalid token",
+		},
+		{
+			jwt: &v1alpha1.JWTAuth{
+				Realm:    "My Product API",
+				Secret:   "my-jwk",
+				LoginURL: "login.example.com",
+			},
+			msg: "invalid login url",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateJWT(test.jwt, field.NewPath("jwt"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateJWT() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidatePolicies(t *testing.T) {
+	policies := []v1alpha1.PolicyReference{
+		{
+			Name: "my-policy",
+		},
+		{
+			Name:      "my-policy",
+			Namespace: "nginx-ingress",
+		},
+		{
+			Name:      "another-policy",
+			Namespace: "default",
+		},
+	}
+
+	allErrs := validatePolicies(policies, field.NewPath("policies"))
+	if len(allErrs) > 0 {
+		t.Errorf("validatePolicies() returned errors %v for valid input", allErrs)
+	}
+}
+
+func TestValidatePoliciesFails(t *testing.T) {
+	tests := []struct {
+		policies []v1alpha1.PolicyReference
+		msg      string
+	}{
+		{
+			policies: []v1alpha1.PolicyReference{
+				{
+					Name: "",
+				},
+			},
+			msg: "missing name",
+		},
+		{
+			policies: []v1alpha1.PolicyReference{
+				{
+					Name: "-invalid",
+				},
+			},
+			msg: "invalid name",
+		},
+		{
+			policies: []v1alpha1.PolicyReference{
+				{
+					Name:      "valid",
+					Namespace: "-invalid",
+				},
+			},
+			msg: "invalid namespace",
+		},
+		{
+			policies: []v1alpha1.PolicyReference{
+				{
+					Name:      "my-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "my-policy",
+					Namespace: "default",
+				},
+			},
+			msg: "duplicated policies",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validatePolicies(test.policies, field.NewPath("policies"))
+		if len(allErrs) == 0 {
+			t.Errorf("validatePolicies() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// reservedTCPPorts are the ports on which NGINX accepts HTTP and HTTPS traffic.
+var reservedTCPPorts = map[int]bool{
+	80:  true,
+	443: true,
+}
+
+var validProtocols = map[string]bool{
+	"TCP":                           true,
+	"UDP":                           true,
+	v1alpha1.TLSPassthroughProtocol: true,
+}
+
+// tlsPassthroughPort is the port on which NGINX accepts TLS connections for both the TLS Passthrough TransportServers
+// and the HTTPS servers.
+const tlsPassthroughPort = 443
+
+// ValidateTransportServer validates a TransportServer.
+func ValidateTransportServer(transportServer *v1alpha1.TransportServer, isPlus bool, isTLSPassthroughEnabled bool) error {
+	allErrs := validateTransportServerSpec(&transportServer.Spec, field.NewPath("spec"), isPlus, isTLSPassthroughEnabled)
+	return allErrs.ToAggregate()
+}
+
+func validateTransportServerSpec(spec *v1alpha1.TransportServerSpec, fieldPath *field.Path, isPlus bool, isTLSPassthroughEnabled bool) field.ErrorList {
+	allErrs := validateTransportServerListener(spec.Listener, fieldPath.Child("listener"), isTLSPassthroughEnabled)
+
+	isTLSPassthroughListener := spec.Listener.Protocol == v1alpha1.TLSPassthroughProtocol
+	allErrs = append(allErrs, validateTransportServerHost(spec.Host, fieldPath.Child("host"), isTLSPassthroughListener)...)
+
+	upstreamErrs, upstreamNames := validateTransportServerUpstreams(spec.Upstreams, fieldPath.Child("upstreams"), isPlus)
+	allErrs = append(allErrs, upstreamErrs...)
+
+	allErrs = append(allErrs, validateTransportServerAction(spec.Action, fieldPath.Child("action"), upstreamNames)...)
+
+	return allErrs
+}
+
+func validateTransportServerListener(listener v1alpha1.TransportServerListener, fieldPath *field.Path, isTLSPassthroughEnabled bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if listener.Protocol == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("protocol"), ""))
+	} else if !validProtocols[listener.Protocol] {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("protocol"), listener.Protocol, sets.StringKeySet(validProtocols).List()))
+	}
+
+	if listener.Protocol == v1alpha1.TLSPassthroughProtocol {
+		if !isTLSPassthroughEnabled {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("protocol"), "TLS Passthrough is not enabled"))
+		}
+
+		if listener.Port != tlsPassthroughPort {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("port"), listener.Port, fmt.Sprintf("must be %d for the %s protocol", tlsPassthroughPort, listener.Protocol)))
+		}
+
+		return allErrs
+	}
+
+	for _, msg := range validation.IsValidPortNum(listener.Port) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("port"), listener.Port, msg))
+	}
+
+	if listener.Protocol == "TCP" && reservedTCPPorts[listener.Port] {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("port"), fmt.Sprintf("port %d is used for HTTP and HTTPS traffic", listener.Port)))
+	}
+
+	return allErrs
+}
+
+func validateTransportServerHost(host string, fieldPath *field.Path, isTLSPassthroughListener bool) field.ErrorList {
+	if !isTLSPassthroughListener {
+		if host != "" {
+			return field.ErrorList{field.Forbidden(fieldPath, fmt.Sprintf("host is only supported for the %s protocol", v1alpha1.TLSPassthroughProtocol))}
+		}
+		return field.ErrorList{}
+	}
+
+	return validateHost(host, fieldPath)
+}
+
+func validateTransportServerUpstreams(upstreams []v1alpha1.TransportServerUpstream, fieldPath *field.Path, isPlus bool) (allErrs field.ErrorList, upstreamNames sets.String) {
+	allErrs = field.ErrorList{}
+	upstreamNames = sets.String{}
+
+	for i, u := range upstreams {
+		idxPath := fieldPath.Index(i)
+
+		upstreamErrors := validateUpstreamName(u.Name, idxPath.Child("name"))
+		if len(upstreamErrors) > 0 {
+			allErrs = append(allErrs, upstreamErrors...)
+		} else if upstreamNames.Has(u.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), u.Name))
+		} else {
+			upstreamNames.Insert(u.Name)
+		}
+
+		allErrs = append(allErrs, validateServiceName(u.Service, idxPath.Child("service"))...)
+
+		for _, msg := range validation.IsValidPortNum(int(u.Port)) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
+		}
+
+		allErrs = append(allErrs, validateStreamLBMethod(u.LBMethod, idxPath.Child("lb-method"), isPlus)...)
+		allErrs = append(allErrs, validatePositiveIntOrZero(u.MaxFails, idxPath.Child("max-fails"))...)
+		allErrs = append(allErrs, validateTime(u.FailTimeout, idxPath.Child("fail-timeout"))...)
+		allErrs = append(allErrs, validateTime(u.ProxyConnectTimeout, idxPath.Child("connect-timeout"))...)
+		allErrs = append(allErrs, validateTime(u.ProxyTimeout, idxPath.Child("timeout"))...)
+	}
+
+	return allErrs, upstreamNames
+}
+
+func validateStreamLBMethod(method string, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if method == "" {
+		return allErrs
+	}
+
+	var err error
+	if isPlus {
+		_, err = configs.ParseStreamLBMethodForPlus(method)
+	} else {
+		_, err = configs.ParseStreamLBMethod(method)
+	}
+
+	if err != nil {
+		return append(allErrs, field.Invalid(fieldPath, method, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateTransportServerAction(action *v1alpha1.TransportServerAction, fieldPath *field.Path, upstreamNames sets.String) field.ErrorList {
+	if action == nil {
+		return field.ErrorList{field.Required(fieldPath, "")}
+	}
+
+	if action.Pass == "" {
+		return field.ErrorList{field.Required(fieldPath.Child("pass"), "")}
+	}
+
+	return validateReferencedUpstream(action.Pass, fieldPath.Child("pass"), upstreamNames)
+}
@@ -0,0 +1,309 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func createTransportServer() v1alpha1.TransportServer {
+	maxFails := 3
+
+	return v1alpha1.TransportServer{
+		Spec: v1alpha1.TransportServerSpec{
+			Listener: v1alpha1.TransportServerListener{
+				Port:     5353,
+				Protocol: "UDP",
+			},
+			Upstreams: []v1alpha1.TransportServerUpstream{
+				{
+					Name:                "dns",
+					Service:             "dns-svc",
+					Port:                53,
+					LBMethod:            "least_conn",
+					MaxFails:            &maxFails,
+					FailTimeout:         "30s",
+					ProxyConnectTimeout: "10s",
+					ProxyTimeout:        "1m",
+				},
+			},
+			Action: &v1alpha1.TransportServerAction{
+				Pass: "dns",
+			},
+		},
+	}
+}
+
+func TestValidateTransportServer(t *testing.T) {
+	ts := createTransportServer()
+
+	err := ValidateTransportServer(&ts, false, false)
+	if err != nil {
+		t.Errorf("ValidateTransportServer() returned error %v for valid input", err)
+	}
+}
+
+func TestValidateTransportServerForTLSPassthrough(t *testing.T) {
+	ts := createTransportServer()
+	ts.Spec.Listener = v1alpha1.TransportServerListener{
+		Port:     443,
+		Protocol: "TLS_PASSTHROUGH",
+	}
+	ts.Spec.Host = "app.example.com"
+
+	err := ValidateTransportServer(&ts, false, true)
+	if err != nil {
+		t.Errorf("ValidateTransportServer() returned error %v for valid input", err)
+	}
+
+	err = ValidateTransportServer(&ts, false, false)
+	if err == nil {
+		t.Errorf("ValidateTransportServer() returned no error for the TLS Passthrough TransportServer when TLS Passthrough is not enabled")
+	}
+}
+
+func TestValidateTransportServerListener(t *testing.T) {
+	listeners := []v1alpha1.TransportServerListener{
+		{
+			Port:     5353,
+			Protocol: "TCP",
+		},
+		{
+			Port:     443,
+			Protocol: "UDP",
+		},
+	}
+
+	for _, listener := range listeners {
+		allErrs := validateTransportServerListener(listener, field.NewPath("listener"), false)
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerListener(%+v) returned errors %v for valid input", listener, allErrs)
+		}
+	}
+}
+
+func TestValidateTransportServerListenerFails(t *testing.T) {
+	tests := []struct {
+		listener v1alpha1.TransportServerListener
+		msg      string
+	}{
+		{
+			listener: v1alpha1.TransportServerListener{
+				Port:     5353,
+				Protocol: "",
+			},
+			msg: "missing protocol",
+		},
+		{
+			listener: v1alpha1.TransportServerListener{
+				Port:     5353,
+				Protocol: "HTTP",
+			},
+			msg: "unsupported protocol",
+		},
+		{
+			listener: v1alpha1.TransportServerListener{
+				Port:     0,
+				Protocol: "TCP",
+			},
+			msg: "invalid port",
+		},
+		{
+			listener: v1alpha1.TransportServerListener{
+				Port:     443,
+				Protocol: "TCP",
+			},
+			msg: "reserved port",
+		},
+		{
+			listener: v1alpha1.TransportServerListener{
+				Port:     8443,
+				Protocol: "TLS_PASSTHROUGH",
+			},
+			msg: "TLS Passthrough listener not on port 443",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerListener(test.listener, field.NewPath("listener"), true)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerListener() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerHost(t *testing.T) {
+	tests := []struct {
+		host                     string
+		isTLSPassthroughListener bool
+	}{
+		{
+			host:                     "",
+			isTLSPassthroughListener: false,
+		},
+		{
+			host:                     "app.example.com",
+			isTLSPassthroughListener: true,
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerHost(test.host, field.NewPath("host"), test.isTLSPassthroughListener)
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerHost(%q, %v) returned errors %v for valid input", test.host, test.isTLSPassthroughListener, allErrs)
+		}
+	}
+}
+
+func TestValidateTransportServerHostFails(t *testing.T) {
+	tests := []struct {
+		host                     string
+		isTLSPassthroughListener bool
+		msg                      string
+	}{
+		{
+			host:                     "app.example.com",
+			isTLSPassthroughListener: false,
+			msg:                      "host for a TCP listener",
+		},
+		{
+			host:                     "",
+			isTLSPassthroughListener: true,
+			msg:                      "missing host for a TLS Passthrough listener",
+		},
+		{
+			host:                     "app.example.com:443",
+			isTLSPassthroughListener: true,
+			msg:                      "invalid host",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerHost(test.host, field.NewPath("host"), test.isTLSPassthroughListener)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerHost() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerUpstreamsFails(t *testing.T) {
+	maxFails := -1
+
+	tests := []struct {
+		upstreams []v1alpha1.TransportServerUpstream
+		isPlus    bool
+		msg       string
+	}{
+		{
+			upstreams: []v1alpha1.TransportServerUpstream{
+				{
+					Name:    "@dns",
+					Service: "dns-svc",
+					Port:    53,
+				},
+			},
+			msg: "invalid upstream name",
+		},
+		{
+			upstreams: []v1alpha1.TransportServerUpstream{
+				{
+					Name:    "dns",
+					Service: "dns-svc",
+					Port:    53,
+				},
+				{
+					Name:    "dns",
+					Service: "dns-svc-2",
+					Port:    53,
+				},
+			},
+			msg: "duplicated upstreams",
+		},
+		{
+			upstreams: []v1alpha1.TransportServerUpstream{
+				{
+					Name:    "dns",
+					Service: "dns-svc",
+					Port:    0,
+				},
+			},
+			msg: "invalid port",
+		},
+		{
+			upstreams: []v1alpha1.TransportServerUpstream{
+				{
+					Name:     "dns",
+					Service:  "dns-svc",
+					Port:     53,
+					LBMethod: "least_time connect",
+				},
+			},
+			isPlus: false,
+			msg:    "NGINX Plus lb method for NGINX",
+		},
+		{
+			upstreams: []v1alpha1.TransportServerUpstream{
+				{
+					Name:     "dns",
+					Service:  "dns-svc",
+					Port:     53,
+					MaxFails: &maxFails,
+				},
+			},
+			msg: "negative max-fails",
+		},
+		{
+			upstreams: []v1alpha1.TransportServerUpstream{
+				{
+					Name:         "dns",
+					Service:      "dns-svc",
+					Port:         53,
+					ProxyTimeout: "1 minute",
+				},
+			},
+			msg: "invalid timeout",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs, _ := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), test.isPlus)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerUpstreams() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerActionFails(t *testing.T) {
+	upstreamNames := sets.NewString("dns")
+
+	tests := []struct {
+		action *v1alpha1.TransportServerAction
+		msg    string
+	}{
+		{
+			action: nil,
+			msg:    "missing action",
+		},
+		{
+			action: &v1alpha1.TransportServerAction{
+				Pass: "",
+			},
+			msg: "missing pass",
+		},
+		{
+			action: &v1alpha1.TransportServerAction{
+				Pass: "test",
+			},
+			msg: "non-existing upstream",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerAction(test.action, field.NewPath("action"), upstreamNames)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerAction() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
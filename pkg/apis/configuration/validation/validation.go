@@ -3,8 +3,11 @@ package validation
 import (
 	"fmt"
 	"regexp"
+	"regexp/syntax"
+	"strconv"
 	"strings"
 
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -12,22 +15,24 @@ import (
 )
 
 // ValidateVirtualServer validates a VirtualServer.
-func ValidateVirtualServer(virtualServer *v1alpha1.VirtualServer) error {
-	allErrs := validateVirtualServerSpec(&virtualServer.Spec, field.NewPath("spec"))
+func ValidateVirtualServer(virtualServer *v1alpha1.VirtualServer, isPlus bool) error {
+	allErrs := validateVirtualServerSpec(&virtualServer.Spec, field.NewPath("spec"), isPlus)
 	return allErrs.ToAggregate()
 }
 
 // validateVirtualServerSpec validates a VirtualServerSpec.
-func validateVirtualServerSpec(spec *v1alpha1.VirtualServerSpec, fieldPath *field.Path) field.ErrorList {
+func validateVirtualServerSpec(spec *v1alpha1.VirtualServerSpec, fieldPath *field.Path, isPlus bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateHost(spec.Host, fieldPath.Child("host"))...)
 	allErrs = append(allErrs, validateTLS(spec.TLS, fieldPath.Child("tls"))...)
 
-	upstreamErrs, upstreamNames := validateUpstreams(spec.Upstreams, fieldPath.Child("upstreams"))
+	upstreamErrs, upstreamNames := validateUpstreams(spec.Upstreams, fieldPath.Child("upstreams"), isPlus)
 	allErrs = append(allErrs, upstreamErrs...)
 
 	allErrs = append(allErrs, validateVirtualServerRoutes(spec.Routes, fieldPath.Child("routes"), upstreamNames)...)
+	allErrs = append(allErrs, validatePolicies(spec.Policies, fieldPath.Child("policies"))...)
+	allErrs = append(allErrs, validateWallarm(spec.Wallarm, fieldPath.Child("wallarm"))...)
 
 	return allErrs
 }
@@ -52,7 +57,31 @@ func validateTLS(tls *v1alpha1.TLS, fieldPath *field.Path) field.ErrorList {
 		return field.ErrorList{}
 	}
 
-	return validateSecretName(tls.Secret, fieldPath.Child("secret"))
+	allErrs := validateSecretName(tls.Secret, fieldPath.Child("secret"))
+	allErrs = append(allErrs, validateClientCertificate(tls.ClientCertificate, fieldPath.Child("clientCertificate"))...)
+
+	return allErrs
+}
+
+func validateClientCertificate(clientCert *v1alpha1.ClientCertificate, fieldPath *field.Path) field.ErrorList {
+	if clientCert == nil {
+		// valid case - clientCertificate is not defined
+		return field.ErrorList{}
+	}
+
+	allErrs := validateSecretName(clientCert.Secret, fieldPath.Child("secret"))
+
+	if clientCert.Verify != "" {
+		if _, err := configs.ParseSSLVerifyClient(clientCert.Verify); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("verify"), clientCert.Verify, err.Error()))
+		}
+	}
+
+	if clientCert.VerifyDepth != nil && *clientCert.VerifyDepth < 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("verifyDepth"), *clientCert.VerifyDepth, "must not be negative"))
+	}
+
+	return allErrs
 }
 
 // validateSecretName checks if a secret name is valid.
@@ -71,7 +100,7 @@ func validateSecretName(name string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
-func validateUpstreams(upstreams []v1alpha1.Upstream, fieldPath *field.Path) (allErrs field.ErrorList, upstreamNames sets.String) {
+func validateUpstreams(upstreams []v1alpha1.Upstream, fieldPath *field.Path, isPlus bool) (allErrs field.ErrorList, upstreamNames sets.String) {
 	allErrs = field.ErrorList{}
 	upstreamNames = sets.String{}
 
@@ -88,15 +117,404 @@ func validateUpstreams(upstreams []v1alpha1.Upstream, fieldPath *field.Path) (al
 		}
 
 		allErrs = append(allErrs, validateServiceName(u.Service, idxPath.Child("service"))...)
+		allErrs = append(allErrs, validateLabels(u.Subselector, idxPath.Child("subselector"))...)
 
 		for _, msg := range validation.IsValidPortNum(int(u.Port)) {
 			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
 		}
+
+		allErrs = append(allErrs, validateUpstreamType(u.Type, idxPath.Child("type"))...)
+		allErrs = append(allErrs, validateLBMethod(u.LBMethod, idxPath.Child("lb-method"), isPlus)...)
+		allErrs = append(allErrs, validateTime(u.FailTimeout, idxPath.Child("fail-timeout"))...)
+		allErrs = append(allErrs, validatePositiveIntOrZero(u.MaxFails, idxPath.Child("max-fails"))...)
+		allErrs = append(allErrs, validatePositiveIntOrZero(u.MaxConns, idxPath.Child("max-conns"))...)
+		allErrs = append(allErrs, validatePositiveIntOrZero(u.Keepalive, idxPath.Child("keepalive"))...)
+		allErrs = append(allErrs, validateTime(u.ProxyConnectTimeout, idxPath.Child("connect-timeout"))...)
+		allErrs = append(allErrs, validateTime(u.ProxyReadTimeout, idxPath.Child("read-timeout"))...)
+		allErrs = append(allErrs, validateTime(u.ProxySendTimeout, idxPath.Child("send-timeout"))...)
+		allErrs = append(allErrs, validateBuffers(u.ProxyBuffers, idxPath.Child("buffers"))...)
+		allErrs = append(allErrs, validateSize(u.ProxyBufferSize, idxPath.Child("buffer-size"))...)
+		allErrs = append(allErrs, validateSize(u.ClientMaxBodySize, idxPath.Child("client-max-body-size"))...)
+		allErrs = append(allErrs, validateNextUpstream(u.ProxyNextUpstream, idxPath.Child("next-upstream"))...)
+		allErrs = append(allErrs, validateTime(u.ProxyNextUpstreamTimeout, idxPath.Child("next-upstream-timeout"))...)
+		allErrs = append(allErrs, validatePositiveIntOrZero(u.ProxyNextUpstreamTries, idxPath.Child("next-upstream-tries"))...)
+		if u.Type == "grpc" && u.HealthCheck != nil && u.HealthCheck.Enable {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("healthCheck"), "is not supported for upstreams of type grpc"))
+		} else {
+			allErrs = append(allErrs, validateUpstreamHealthCheck(u.HealthCheck, idxPath.Child("healthCheck"), isPlus)...)
+		}
+		allErrs = append(allErrs, validateSessionCookie(u.SessionCookie, idxPath.Child("sessionCookie"))...)
+		if !isPlus && u.LBMethod != "" && u.SessionCookie != nil && u.SessionCookie.Enable {
+			// NGINX uses the consistent hash on the session ID for the session cookie
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("lb-method"), "is not supported together with `sessionCookie` in NGINX"))
+		}
+		allErrs = append(allErrs, validateUpstreamTLS(u.TLS, idxPath.Child("tls"))...)
 	}
 
 	return allErrs, upstreamNames
 }
 
+func validateLabels(labels map[string]string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, key := range sets.StringKeySet(labels).List() {
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(fieldPath, key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(labels[key]) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Key(key), labels[key], msg))
+		}
+	}
+
+	return allErrs
+}
+
+var validUpstreamTypes = map[string]bool{
+	"http": true,
+	"grpc": true,
+}
+
+func validateUpstreamType(upstreamType string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if upstreamType == "" {
+		return allErrs
+	}
+
+	if !validUpstreamTypes[upstreamType] {
+		allErrs = append(allErrs, field.NotSupported(fieldPath, upstreamType, sets.StringKeySet(validUpstreamTypes).List()))
+	}
+
+	return allErrs
+}
+
+func validateNextUpstream(nextUpstream string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if nextUpstream == "" {
+		return allErrs
+	}
+
+	if _, err := configs.ParseProxyNextUpstream(nextUpstream); err != nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath, nextUpstream, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateLBMethod(method string, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if method == "" {
+		return allErrs
+	}
+
+	var err error
+	if isPlus {
+		_, err = configs.ParseLBMethodForPlus(method)
+	} else {
+		_, err = configs.ParseLBMethod(method)
+	}
+
+	if err != nil {
+		return append(allErrs, field.Invalid(fieldPath, method, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateTime(time string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if time == "" {
+		return allErrs
+	}
+
+	if _, err := configs.ParseTime(time); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, time, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateSize(size string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if size == "" {
+		return allErrs
+	}
+
+	if _, err := configs.ParseSize(size); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, size, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateBuffers(buffers *v1alpha1.UpstreamBuffers, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if buffers == nil {
+		return allErrs
+	}
+
+	if buffers.Number <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("number"), buffers.Number, "must be positive"))
+	}
+
+	if buffers.Size == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("size"), ""))
+	} else {
+		allErrs = append(allErrs, validateSize(buffers.Size, fieldPath.Child("size"))...)
+	}
+
+	return allErrs
+}
+
+func validatePositiveIntOrZero(n *int, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if n == nil {
+		return allErrs
+	}
+
+	if *n < 0 {
+		return append(allErrs, field.Invalid(fieldPath, *n, "must be positive or zero"))
+	}
+
+	return allErrs
+}
+
+func validatePositiveInt(n *int, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if n == nil {
+		return allErrs
+	}
+
+	if *n <= 0 {
+		return append(allErrs, field.Invalid(fieldPath, *n, "must be positive"))
+	}
+
+	return allErrs
+}
+
+func validateUpstreamHealthCheck(hc *v1alpha1.HealthCheck, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if hc == nil {
+		return allErrs
+	}
+
+	if !isPlus {
+		return append(allErrs, field.Forbidden(fieldPath, "active health checks are only supported in NGINX Plus"))
+	}
+
+	if hc.Path != "" {
+		allErrs = append(allErrs, validatePath(hc.Path, fieldPath.Child("path"))...)
+	}
+
+	allErrs = append(allErrs, validateTime(hc.Interval, fieldPath.Child("interval"))...)
+	allErrs = append(allErrs, validateTime(hc.Jitter, fieldPath.Child("jitter"))...)
+	allErrs = append(allErrs, validatePositiveInt(hc.Fails, fieldPath.Child("fails"))...)
+	allErrs = append(allErrs, validatePositiveInt(hc.Passes, fieldPath.Child("passes"))...)
+	allErrs = append(allErrs, validateTime(hc.ConnectTimeout, fieldPath.Child("connect-timeout"))...)
+	allErrs = append(allErrs, validateTime(hc.ReadTimeout, fieldPath.Child("read-timeout"))...)
+	allErrs = append(allErrs, validateTime(hc.SendTimeout, fieldPath.Child("send-timeout"))...)
+
+	if hc.Port != 0 {
+		for _, msg := range validation.IsValidPortNum(hc.Port) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("port"), hc.Port, msg))
+		}
+	}
+
+	for i, h := range hc.Headers {
+		idxPath := fieldPath.Child("headers").Index(i)
+
+		for _, msg := range validation.IsHTTPHeaderName(h.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), h.Name, msg))
+		}
+		for _, msg := range isValidMatchValue(h.Value) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), h.Value, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateHealthCheckTLS(hc.TLS, fieldPath.Child("tls"))...)
+	allErrs = append(allErrs, validateStatusMatch(hc.StatusMatch, fieldPath.Child("statusMatch"))...)
+
+	if hc.BodyMatch != "" {
+		for _, msg := range isValidMatchValue(hc.BodyMatch) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("bodyMatch"), hc.BodyMatch, msg))
+		}
+		// the body match is used as the regex of the body ~ parameter of the match directive
+		if _, err := regexp.Compile(hc.BodyMatch); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("bodyMatch"), hc.BodyMatch, fmt.Sprintf("must be a valid regular expression: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateUpstreamTLS(tls *v1alpha1.UpstreamTLS, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tls == nil {
+		return allErrs
+	}
+
+	if tls.TrustedCertSecret != "" {
+		allErrs = append(allErrs, validateSecretName(tls.TrustedCertSecret, fieldPath.Child("trustedCertSecret"))...)
+	}
+
+	if tls.VerifyDepth != nil {
+		if tls.TrustedCertSecret == "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("verifyDepth"), "requires `trustedCertSecret`"))
+		} else {
+			allErrs = append(allErrs, validatePositiveIntOrZero(tls.VerifyDepth, fieldPath.Child("verifyDepth"))...)
+		}
+	}
+
+	if tls.ServerName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(tls.ServerName) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("serverName"), tls.ServerName, msg))
+		}
+	} else if tls.TrustedCertSecret != "" {
+		// without the server name, NGINX verifies the certificates against the generated name of the upstream
+		allErrs = append(allErrs, field.Required(fieldPath.Child("serverName"), "must be set when `trustedCertSecret` is set"))
+	}
+
+	if tls.Secret != "" {
+		allErrs = append(allErrs, validateSecretName(tls.Secret, fieldPath.Child("secret"))...)
+	}
+
+	return allErrs
+}
+
+// validateHealthCheckTLS validates the TLS configuration of a health check, which only supports enabling or disabling TLS.
+// The other TLS parameters of a health check are inherited from the upstream.
+func validateHealthCheckTLS(tls *v1alpha1.UpstreamTLS, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tls == nil {
+		return allErrs
+	}
+
+	if tls.TrustedCertSecret != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("trustedCertSecret"), "is not supported for health checks"))
+	}
+	if tls.VerifyDepth != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("verifyDepth"), "is not supported for health checks"))
+	}
+	if tls.ServerName != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("serverName"), "is not supported for health checks"))
+	}
+	if tls.Secret != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("secret"), "is not supported for health checks"))
+	}
+
+	return allErrs
+}
+
+func validateSplitCookie(sc *v1alpha1.SplitCookie, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sc.Name == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("name"), ""))
+	} else {
+		for _, msg := range isCookieName(sc.Name) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("name"), sc.Name, msg))
+		}
+	}
+
+	if sc.Path != "" {
+		allErrs = append(allErrs, validatePath(sc.Path, fieldPath.Child("path"))...)
+	}
+
+	allErrs = append(allErrs, validatePositiveInt(sc.MaxAge, fieldPath.Child("maxAge"))...)
+
+	return allErrs
+}
+
+func validateSessionCookie(sc *v1alpha1.SessionCookie, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sc == nil || !sc.Enable {
+		return allErrs
+	}
+
+	if sc.Name == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("name"), ""))
+	} else {
+		for _, msg := range isCookieName(sc.Name) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("name"), sc.Name, msg))
+		}
+	}
+
+	if sc.Path != "" {
+		allErrs = append(allErrs, validatePath(sc.Path, fieldPath.Child("path"))...)
+	}
+
+	if sc.Expires != "max" {
+		allErrs = append(allErrs, validateTime(sc.Expires, fieldPath.Child("expires"))...)
+	}
+
+	if sc.Domain != "" {
+		// a domain can start with a dot, for example, .example.com
+		domain := strings.TrimPrefix(sc.Domain, ".")
+		for _, msg := range validation.IsDNS1123Subdomain(domain) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("domain"), sc.Domain, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateStatusMatch validates the status codes for the status parameter of the match block,
+// for example, "200", "! 500", "200 204" or "200-399".
+func validateStatusMatch(s string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s == "" {
+		return allErrs
+	}
+
+	codes := strings.Fields(s)
+	if len(codes) > 0 && codes[0] == "!" {
+		codes = codes[1:]
+	}
+
+	if len(codes) == 0 {
+		return append(allErrs, field.Invalid(fieldPath, s, "must include at least one status code or range"))
+	}
+
+	for _, code := range codes {
+		if !isValidStatusCodeOrRange(code) {
+			msg := fmt.Sprintf("%q is not a valid status code or range. Status codes must fall into the range 100..599, for example, 200 or 200-399", code)
+			return append(allErrs, field.Invalid(fieldPath, s, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func isValidStatusCodeOrRange(value string) bool {
+	parts := strings.Split(value, "-")
+	if len(parts) > 2 {
+		return false
+	}
+
+	var codes []int
+	for _, p := range parts {
+		code, err := strconv.Atoi(p)
+		if err != nil || code < 100 || code > 599 {
+			return false
+		}
+		codes = append(codes, code)
+	}
+
+	return len(codes) == 1 || codes[0] < codes[1]
+}
+
 // validateUpstreamName checks is an upstream name is valid.
 // The rules for NGINX upstream names are less strict than IsDNS1035Label.
 // However, it is convenient to enforce IsDNS1035Label in the yaml for
@@ -150,7 +568,11 @@ func validateVirtualServerRoutes(routes []v1alpha1.Route, fieldPath *field.Path,
 func validateRoute(route v1alpha1.Route, fieldPath *field.Path, upstreamNames sets.String, isRouteFieldForbidden bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	allErrs = append(allErrs, validatePath(route.Path, fieldPath.Child("path"))...)
+	allErrs = append(allErrs, validateRoutePath(route.Path, fieldPath.Child("path"))...)
+
+	if isRegexPath(route.Path) {
+		allErrs = append(allErrs, validateNoRewritePathForRegexPath(route, fieldPath)...)
+	}
 
 	fieldCount := 0
 
@@ -159,11 +581,32 @@ func validateRoute(route v1alpha1.Route, fieldPath *field.Path, upstreamNames se
 		fieldCount++
 	}
 
+	if route.Action != nil {
+		allErrs = append(allErrs, validateAction(route.Action, fieldPath.Child("action"), upstreamNames)...)
+		fieldCount++
+	}
+
 	if len(route.Splits) > 0 {
 		allErrs = append(allErrs, validateSplits(route.Splits, fieldPath.Child("splits"), upstreamNames)...)
 		fieldCount++
 	}
 
+	if route.SplitKey != "" {
+		if len(route.Splits) == 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("splitKey"), "is only allowed for a route with splits"))
+		} else {
+			allErrs = append(allErrs, validateStringWithVariables(route.SplitKey, fieldPath.Child("splitKey"))...)
+		}
+	}
+
+	if route.SplitCookie != nil {
+		if len(route.Splits) == 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("splitCookie"), "is only allowed for a route with splits"))
+		} else {
+			allErrs = append(allErrs, validateSplitCookie(route.SplitCookie, fieldPath.Child("splitCookie"))...)
+		}
+	}
+
 	if route.Rules != nil {
 		allErrs = append(allErrs, validateRules(route.Rules, fieldPath.Child("rules"), upstreamNames)...)
 		fieldCount++
@@ -178,10 +621,33 @@ func validateRoute(route v1alpha1.Route, fieldPath *field.Path, upstreamNames se
 		}
 	}
 
+	if route.RewritePath != "" {
+		if route.Route != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("rewritePath"), "is not allowed for a route that references a VirtualServerRoute"))
+		} else {
+			allErrs = append(allErrs, validateRewritePath(route.RewritePath, fieldPath.Child("rewritePath"))...)
+		}
+	}
+
+	if route.Route != "" && route.RequestHeaders != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("requestHeaders"), "is not allowed for a route that references a VirtualServerRoute"))
+	} else {
+		allErrs = append(allErrs, validateProxyRequestHeaders(route.RequestHeaders, fieldPath.Child("requestHeaders"))...)
+	}
+
+	if route.Route != "" && route.ResponseHeaders != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("responseHeaders"), "is not allowed for a route that references a VirtualServerRoute"))
+	} else {
+		allErrs = append(allErrs, validateProxyResponseHeaders(route.ResponseHeaders, fieldPath.Child("responseHeaders"))...)
+	}
+
+	allErrs = append(allErrs, validatePolicies(route.Policies, fieldPath.Child("policies"))...)
+	allErrs = append(allErrs, validateWallarm(route.Wallarm, fieldPath.Child("wallarm"))...)
+
 	if fieldCount != 1 {
-		msg := "must specify exactly one of: `upstream`, `splits`, `rules` or `route`"
+		msg := "must specify exactly one of: `upstream`, `action`, `splits`, `rules` or `route`"
 		if isRouteFieldForbidden {
-			msg = "must specify exactly one of: `upstream`, `splits` or `rules`"
+			msg = "must specify exactly one of: `upstream`, `action`, `splits` or `rules`"
 		}
 
 		allErrs = append(allErrs, field.Invalid(fieldPath, "", msg))
@@ -190,6 +656,224 @@ func validateRoute(route v1alpha1.Route, fieldPath *field.Path, upstreamNames se
 	return allErrs
 }
 
+// reservedProxySetHeaders are the request headers that the Ingress Controller always sets for the upstreams.
+var reservedProxySetHeaders = map[string]bool{
+	"connection":        true,
+	"x-real-ip":         true,
+	"x-forwarded-for":   true,
+	"x-forwarded-host":  true,
+	"x-forwarded-port":  true,
+	"x-forwarded-proto": true,
+}
+
+func validateProxyRequestHeaders(requestHeaders *v1alpha1.ProxyRequestHeaders, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if requestHeaders == nil {
+		return allErrs
+	}
+
+	for i, h := range requestHeaders.Set {
+		idxPath := fieldPath.Child("set").Index(i)
+
+		allErrs = append(allErrs, validateProxySetHeaderName(h.Name, idxPath.Child("name"))...)
+		allErrs = append(allErrs, validateStringWithVariables(h.Value, idxPath.Child("value"))...)
+	}
+
+	for i, name := range requestHeaders.Ignore {
+		allErrs = append(allErrs, validateProxySetHeaderName(name, fieldPath.Child("ignore").Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validateProxySetHeaderName(name string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if reservedProxySetHeaders[strings.ToLower(name)] {
+		return append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("the header %s is set by the Ingress Controller", name)))
+	}
+
+	return validateHeaderName(name, fieldPath)
+}
+
+func validateHeaderName(name string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, msg := range validation.IsHTTPHeaderName(name) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, name, msg))
+	}
+
+	return allErrs
+}
+
+// validIgnoreHeaders are the response headers whose processing can be disabled by the proxy_ignore_headers directive.
+var validIgnoreHeaders = sets.NewString(
+	"X-Accel-Redirect",
+	"X-Accel-Expires",
+	"X-Accel-Limit-Rate",
+	"X-Accel-Buffering",
+	"X-Accel-Charset",
+	"Expires",
+	"Cache-Control",
+	"Set-Cookie",
+	"Vary",
+)
+
+func validateProxyResponseHeaders(responseHeaders *v1alpha1.ProxyResponseHeaders, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if responseHeaders == nil {
+		return allErrs
+	}
+
+	for i, h := range responseHeaders.Add {
+		idxPath := fieldPath.Child("add").Index(i)
+
+		allErrs = append(allErrs, validateHeaderName(h.Name, idxPath.Child("name"))...)
+		allErrs = append(allErrs, validateStringWithVariables(h.Value, idxPath.Child("value"))...)
+	}
+
+	for i, name := range responseHeaders.Hide {
+		allErrs = append(allErrs, validateHeaderName(name, fieldPath.Child("hide").Index(i))...)
+	}
+
+	for i, name := range responseHeaders.Pass {
+		allErrs = append(allErrs, validateHeaderName(name, fieldPath.Child("pass").Index(i))...)
+	}
+
+	for i, name := range responseHeaders.Ignore {
+		if !validIgnoreHeaders.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fieldPath.Child("ignore").Index(i), name, validIgnoreHeaders.List()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateStringWithVariables validates a string that is used as a quoted parameter of an NGINX directive.
+// The string can include only the variables from the allowlist.
+func validateStringWithVariables(value string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, msg := range isValidMatchValue(value) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, value, msg))
+	}
+
+	for _, name := range configs.GetVariableNames(value) {
+		if name == "" {
+			allErrs = append(allErrs, field.Invalid(fieldPath, value, "'$' must be followed by a variable name"))
+		} else if !configs.IsValidVariable(name) {
+			allErrs = append(allErrs, field.Invalid(fieldPath, value, fmt.Sprintf("the variable $%s is not supported", name)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateRoutePath validates the path of a route, which can be a prefix (/path), an exact path (=/path)
+// or a regular expression, either case-sensitive (~ regex) or case-insensitive (~* regex).
+func validateRoutePath(path string, fieldPath *field.Path) field.ErrorList {
+	if strings.HasPrefix(path, "=") {
+		return validatePath(path[1:], fieldPath)
+	}
+
+	if isRegexPath(path) {
+		return validateRegexPath(path, fieldPath)
+	}
+
+	return validatePath(path, fieldPath)
+}
+
+func isRegexPath(path string) bool {
+	return strings.HasPrefix(path, "~")
+}
+
+func isExactPath(path string) bool {
+	return strings.HasPrefix(path, "=")
+}
+
+func validateRegexPath(path string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var regex string
+	if strings.HasPrefix(path, "~* ") {
+		regex = path[3:]
+	} else if strings.HasPrefix(path, "~ ") {
+		regex = path[2:]
+	} else {
+		return append(allErrs, field.Invalid(fieldPath, path, "a regex path must start with `~ ` or `~* ` followed by a regular expression"))
+	}
+
+	if strings.TrimSpace(regex) == "" {
+		return append(allErrs, field.Invalid(fieldPath, path, "must include a regular expression"))
+	}
+
+	// the regex is used in a quoted parameter of the location directive
+	for _, msg := range isValidMatchValue(regex) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, path, msg))
+	}
+
+	// NGINX uses PCRE, but only the subset of PCRE supported by the Go regular expressions (RE2) is accepted,
+	// so that the regex is checked before it reaches NGINX. The PCRE-only features, such as lookarounds
+	// and backreferences, are rejected.
+	re, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		return append(allErrs, field.Invalid(fieldPath, path, fmt.Sprintf("must be a valid regular expression: %v", err)))
+	}
+
+	if hasNestedQuantifiers(re, false) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, path,
+			"must not include a quantifier nested in another quantifier, such as `(a+)+`, which can cause catastrophic backtracking in PCRE"))
+	}
+
+	return allErrs
+}
+
+// hasNestedQuantifiers checks if a regex includes an unbounded quantifier (*, + or {n,}) inside another quantifier
+// that repeats its expression more than once. repeated is true if the regex is already inside such a quantifier.
+func hasNestedQuantifiers(re *syntax.Regexp, repeated bool) bool {
+	unbounded := re.Op == syntax.OpStar || re.Op == syntax.OpPlus || (re.Op == syntax.OpRepeat && re.Max == -1)
+	if unbounded && repeated {
+		return true
+	}
+
+	repeats := unbounded || (re.Op == syntax.OpRepeat && re.Max > 1)
+	for _, sub := range re.Sub {
+		if hasNestedQuantifiers(sub, repeated || repeats) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateNoRewritePathForRegexPath(route v1alpha1.Route, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	msg := "is not supported for a regex path"
+
+	if route.RewritePath != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("rewritePath"), msg))
+	}
+
+	for i, s := range route.Splits {
+		if s.RewritePath != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("splits").Index(i).Child("rewritePath"), msg))
+		}
+	}
+
+	if route.Rules != nil {
+		for i, m := range route.Rules.Matches {
+			if m.RewritePath != "" {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("rules").Child("matches").Index(i).Child("rewritePath"), msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func validateRouteField(value string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -229,7 +913,11 @@ func validateSplits(splits []v1alpha1.Split, fieldPath *field.Path, upstreamName
 			allErrs = append(allErrs, field.Invalid(idxPath.Child("weight"), s.Weight, msg))
 		}
 
-		allErrs = append(allErrs, validateReferencedUpstream(s.Upstream, idxPath.Child("upstream"), upstreamNames)...)
+		allErrs = append(allErrs, validateUpstreamOrAction(s.Upstream, s.Action, idxPath, upstreamNames)...)
+
+		if s.RewritePath != "" {
+			allErrs = append(allErrs, validateRewritePath(s.RewritePath, idxPath.Child("rewritePath"))...)
+		}
 
 		totalWeight += s.Weight
 	}
@@ -241,7 +929,8 @@ func validateSplits(splits []v1alpha1.Split, fieldPath *field.Path, upstreamName
 	return allErrs
 }
 
-// For now, we only support prefix-based NGINX locations. For example, location /abc { ... }.
+// pathFmt validates a prefix path, for example, /abc, and the body of an exact path after the = modifier, for example, =/abc.
+// Regex paths are validated by validateRegexPath.
 const pathFmt = `/[^\s{};]*`
 const pathErrMsg = "must start with / and must not include any whitespace character, `{`, `}` or `;`"
 
@@ -262,6 +951,22 @@ func validatePath(path string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+const rewritePathFmt = `/[^\s{};$"\\]*`
+const rewritePathErrMsg = "must start with / and must not include any whitespace character, `{`, `}`, `;`, `$`, `\"` or `\\`"
+
+var rewritePathRegexp = regexp.MustCompile("^" + rewritePathFmt + "$")
+
+func validateRewritePath(path string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !rewritePathRegexp.MatchString(path) {
+		msg := validation.RegexError(rewritePathErrMsg, rewritePathFmt, "/", "/path", "/path/subpath-123")
+		return append(allErrs, field.Invalid(fieldPath, path, msg))
+	}
+
+	return allErrs
+}
+
 func validateRules(rules *v1alpha1.Rules, fieldPath *field.Path, upstreamNames sets.String) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -392,11 +1097,165 @@ func validateMatch(match v1alpha1.Match, fieldPath *field.Path, conditionsCount
 		}
 	}
 
-	allErrs = append(allErrs, validateReferencedUpstream(match.Upstream, fieldPath.Child("upstream"), upstreamNames)...)
+	allErrs = append(allErrs, validateUpstreamOrAction(match.Upstream, match.Action, fieldPath, upstreamNames)...)
+
+	if match.RewritePath != "" {
+		allErrs = append(allErrs, validateRewritePath(match.RewritePath, fieldPath.Child("rewritePath"))...)
+	}
+
+	return allErrs
+}
+
+// validateUpstreamOrAction validates the upstream and the action of a split or a match, exactly one of which must be set.
+func validateUpstreamOrAction(upstream string, action *v1alpha1.Action, fieldPath *field.Path, upstreamNames sets.String) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if upstream != "" && action != nil {
+		return append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `upstream` or `action`"))
+	}
+
+	if action != nil {
+		return validateAction(action, fieldPath.Child("action"), upstreamNames)
+	}
+
+	return validateReferencedUpstream(upstream, fieldPath.Child("upstream"), upstreamNames)
+}
+
+func validateAction(action *v1alpha1.Action, fieldPath *field.Path, upstreamNames sets.String) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fieldCount := 0
+
+	if action.Pass != "" {
+		allErrs = append(allErrs, validateReferencedUpstream(action.Pass, fieldPath.Child("pass"), upstreamNames)...)
+		fieldCount++
+	}
+
+	if action.Redirect != nil {
+		allErrs = append(allErrs, validateActionRedirect(action.Redirect, fieldPath.Child("redirect"))...)
+		fieldCount++
+	}
+
+	if action.Return != nil {
+		allErrs = append(allErrs, validateActionReturn(action.Return, fieldPath.Child("return"))...)
+		fieldCount++
+	}
+
+	if fieldCount != 1 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `pass`, `redirect` or `return`"))
+	}
+
+	return allErrs
+}
+
+var validRedirectCodes = map[int]bool{
+	301: true,
+	302: true,
+	307: true,
+	308: true,
+}
+
+func validateActionRedirect(redirect *v1alpha1.ActionRedirect, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if redirect.URL == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("url"), ""))
+	} else {
+		for _, msg := range isValidRedirectURL(redirect.URL) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("url"), redirect.URL, msg))
+		}
+	}
+
+	if redirect.Code != 0 && !validRedirectCodes[redirect.Code] {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("code"), redirect.Code, []string{"301", "302", "307", "308"}))
+	}
+
+	return allErrs
+}
+
+func validateActionReturn(ret *v1alpha1.ActionReturn, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ret.Code != 0 && !isValidReturnCode(ret.Code) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("code"), ret.Code, "must be in the range 200..299 or 400..599"))
+	}
+
+	if ret.Type != "" {
+		for _, msg := range isValidMimeType(ret.Type) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("type"), ret.Type, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateStringWithVariables(ret.Body, fieldPath.Child("body"))...)
 
 	return allErrs
 }
 
+// isValidReturnCode checks if the code can be used with a text in the return directive.
+// Redirect codes are not allowed, because for them NGINX expects a URL instead of a text.
+func isValidReturnCode(code int) bool {
+	return (code >= 200 && code <= 299) || (code >= 400 && code <= 599)
+}
+
+const redirectURLFmt = `(http://|https://|\$scheme://)[^\s"\\]+`
+const redirectURLErrMsg = "must start with http://, https:// or $scheme:// and must not include any whitespace character, '\"' (double quote) or '\\' (backslash)"
+
+var redirectURLRegexp = regexp.MustCompile("^" + redirectURLFmt + "$")
+
+// validRedirectHostVariables are the variables that can be used in the host part of a redirect URL.
+// The other variables are excluded, because the client could set them to redirect to an arbitrary site.
+// $request_uri and $uri always start with '/', so they end the host part.
+var validRedirectHostVariables = map[string]bool{
+	"host":        true,
+	"request_uri": true,
+	"server_addr": true,
+	"server_name": true,
+	"server_port": true,
+	"uri":         true,
+}
+
+func isValidRedirectURL(url string) []string {
+	if !redirectURLRegexp.MatchString(url) {
+		return []string{validation.RegexError(redirectURLErrMsg, redirectURLFmt, "http://example.com", "https://${host}/coffee", "$scheme://$host$request_uri")}
+	}
+
+	var msgs []string
+
+	// the scheme is validated by the regexp
+	host := url[strings.Index(url, "://")+len("://"):]
+	if i := strings.IndexAny(host, "/?#"); i != -1 {
+		host = host[:i]
+	}
+
+	for _, name := range configs.GetVariableNames(host) {
+		if name != "" && configs.IsValidVariable(name) && !validRedirectHostVariables[name] {
+			msgs = append(msgs, fmt.Sprintf("the variable $%s is not allowed in the host part of the URL", name))
+		}
+	}
+
+	for _, name := range configs.GetVariableNames(url) {
+		if name == "" {
+			msgs = append(msgs, "'$' must be followed by a variable name")
+		} else if !configs.IsValidVariable(name) {
+			msgs = append(msgs, fmt.Sprintf("the variable $%s is not supported", name))
+		}
+	}
+
+	return msgs
+}
+
+const mimeTypeFmt = `[-\w.+]+/[-\w.+]+`
+const mimeTypeErrMsg = "must be a valid MIME type"
+
+var mimeTypeRegexp = regexp.MustCompile("^" + mimeTypeFmt + "$")
+
+func isValidMimeType(mimeType string) []string {
+	if !mimeTypeRegexp.MatchString(mimeType) {
+		return []string{validation.RegexError(mimeTypeErrMsg, mimeTypeFmt, "text/plain", "application/json")}
+	}
+	return nil
+}
+
 const matchValueFmt string = `([^"\\]|\\.)*`
 const matchValueErrMsg string = `a valid value must have all '"' (double quotes) escaped and must not end with an unescaped '\' (backslash)`
 
@@ -409,27 +1268,150 @@ func isValidMatchValue(value string) []string {
 	return nil
 }
 
+var validWallarmModes = map[string]bool{
+	"off":           true,
+	"monitoring":    true,
+	"safe_blocking": true,
+	"block":         true,
+}
+
+var validWallarmModeAllowOverrideValues = map[string]bool{
+	"on":     true,
+	"off":    true,
+	"strict": true,
+}
+
+var validWallarmSwitchValues = map[string]bool{
+	"on":  true,
+	"off": true,
+}
+
+// validWallarmParsers includes the parsers that can be disabled with the wallarm_parser_disable directive.
+var validWallarmParsers = map[string]bool{
+	"cookie":    true,
+	"zlib":      true,
+	"htmljs":    true,
+	"json":      true,
+	"multipart": true,
+	"base64":    true,
+	"percent":   true,
+	"urlenc":    true,
+	"xml":       true,
+	"jwt":       true,
+}
+
+func validateWallarm(wallarm *v1alpha1.Wallarm, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if wallarm == nil {
+		// valid case - wallarm is not defined
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateWallarmValue(wallarm.Mode, fieldPath.Child("mode"), validWallarmModes)...)
+	allErrs = append(allErrs, validateWallarmValue(wallarm.ModeAllowOverride, fieldPath.Child("modeAllowOverride"), validWallarmModeAllowOverrideValues)...)
+	allErrs = append(allErrs, validateWallarmValue(wallarm.Fallback, fieldPath.Child("fallback"), validWallarmSwitchValues)...)
+	allErrs = append(allErrs, validateWallarmValue(wallarm.ParseResponse, fieldPath.Child("parseResponse"), validWallarmSwitchValues)...)
+	allErrs = append(allErrs, validateWallarmValue(wallarm.ParseWebsocket, fieldPath.Child("parseWebsocket"), validWallarmSwitchValues)...)
+	allErrs = append(allErrs, validateWallarmValue(wallarm.UnpackResponse, fieldPath.Child("unpackResponse"), validWallarmSwitchValues)...)
+
+	if wallarm.Instance != "" {
+		for _, msg := range isWallarmInstance(wallarm.Instance) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("instance"), wallarm.Instance, msg))
+		}
+	}
+
+	if wallarm.BlockPage != "" {
+		for _, msg := range isValidMatchValue(wallarm.BlockPage) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("blockPage"), wallarm.BlockPage, msg))
+		}
+	}
+
+	for i, p := range wallarm.ParserDisable {
+		if _, exists := validWallarmParsers[p]; !exists {
+			allErrs = append(allErrs, field.NotSupported(fieldPath.Child("parserDisable").Index(i), p, sets.StringKeySet(validWallarmParsers).List()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateWallarmValue(value string, fieldPath *field.Path, validValues map[string]bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value == "" {
+		// valid case - the default value will be used
+		return allErrs
+	}
+
+	if _, exists := validValues[value]; !exists {
+		allErrs = append(allErrs, field.NotSupported(fieldPath, value, sets.StringKeySet(validValues).List()))
+	}
+
+	return allErrs
+}
+
+const wallarmInstanceFmt = "[0-9]+"
+const wallarmInstanceErrMsg = "a valid instance must consist of digits"
+
+var wallarmInstanceRegexp = regexp.MustCompile("^" + wallarmInstanceFmt + "$")
+
+func isWallarmInstance(value string) []string {
+	if !wallarmInstanceRegexp.MatchString(value) {
+		return []string{validation.RegexError(wallarmInstanceErrMsg, wallarmInstanceFmt, "1", "42")}
+	}
+	return nil
+}
+
 // ValidateVirtualServerRoute validates a VirtualServerRoute.
-func ValidateVirtualServerRoute(virtualServerRoute *v1alpha1.VirtualServerRoute) error {
-	allErrs := validateVirtualServerRouteSpec(&virtualServerRoute.Spec, field.NewPath("spec"), "", "/")
+func ValidateVirtualServerRoute(virtualServerRoute *v1alpha1.VirtualServerRoute, isPlus bool) error {
+	allErrs := validateVirtualServerRouteSpec(&virtualServerRoute.Spec, field.NewPath("spec"), "", "", isPlus)
 	return allErrs.ToAggregate()
 }
 
-// ValidateVirtualServerRouteForVirtualServer validates a VirtualServerRoute for a VirtualServer represented by its host and path prefix.
-func ValidateVirtualServerRouteForVirtualServer(virtualServerRoute *v1alpha1.VirtualServerRoute, virtualServerHost string, pathPrefix string) error {
-	allErrs := validateVirtualServerRouteSpec(&virtualServerRoute.Spec, field.NewPath("spec"), virtualServerHost, pathPrefix)
+// ValidateVirtualServerRouteForVirtualServer validates a VirtualServerRoute for a VirtualServer represented by its host
+// and the path of the route that references the VirtualServerRoute.
+func ValidateVirtualServerRouteForVirtualServer(virtualServerRoute *v1alpha1.VirtualServerRoute, virtualServerHost string, routePath string, isPlus bool) error {
+	allErrs := validateVirtualServerRouteSpec(&virtualServerRoute.Spec, field.NewPath("spec"), virtualServerHost, routePath, isPlus)
 	return allErrs.ToAggregate()
 }
 
-func validateVirtualServerRouteSpec(spec *v1alpha1.VirtualServerRouteSpec, fieldPath *field.Path, virtualServerHost string, pathPrefix string) field.ErrorList {
+func validateVirtualServerRouteSpec(spec *v1alpha1.VirtualServerRouteSpec, fieldPath *field.Path, virtualServerHost string, routePath string, isPlus bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateVirtualServerRouteHost(spec.Host, virtualServerHost, fieldPath.Child("host"))...)
 
-	upstreamErrs, upstreamNames := validateUpstreams(spec.Upstreams, fieldPath.Child("upstreams"))
+	upstreamErrs, upstreamNames := validateUpstreams(spec.Upstreams, fieldPath.Child("upstreams"), isPlus)
 	allErrs = append(allErrs, upstreamErrs...)
 
-	allErrs = append(allErrs, validateVirtualServerRouteSubroutes(spec.Subroutes, fieldPath.Child("subroutes"), upstreamNames, pathPrefix)...)
+	allErrs = append(allErrs, validateVirtualServerRouteSubroutes(spec.Subroutes, fieldPath.Child("subroutes"), upstreamNames, routePath)...)
+
+	return allErrs
+}
+
+// validateSubroutePathForRoute checks that the path of a subroute belongs to the path of the route of the VirtualServer.
+// For an exact or a regex route path, the subroute path must be the same. For a prefix route path, the subroute path
+// must be a prefix or an exact path that starts with the route path.
+func validateSubroutePathForRoute(subroutePath string, routePath string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if isRegexPath(routePath) || isExactPath(routePath) {
+		if subroutePath != routePath {
+			msg := fmt.Sprintf("must be equal to '%s'", routePath)
+			allErrs = append(allErrs, field.Invalid(fieldPath, subroutePath, msg))
+		}
+		return allErrs
+	}
+
+	if isRegexPath(subroutePath) {
+		msg := "a regex path is allowed only if the route of the VirtualServer has the same path"
+		return append(allErrs, field.Invalid(fieldPath, subroutePath, msg))
+	}
+
+	if !strings.HasPrefix(strings.TrimPrefix(subroutePath, "="), routePath) {
+		msg := fmt.Sprintf("must start with '%s'", routePath)
+		allErrs = append(allErrs, field.Invalid(fieldPath, subroutePath, msg))
+	}
 
 	return allErrs
 }
@@ -447,7 +1429,7 @@ func validateVirtualServerRouteHost(host string, virtualServerHost string, field
 	return allErrs
 }
 
-func validateVirtualServerRouteSubroutes(routes []v1alpha1.Route, fieldPath *field.Path, upstreamNames sets.String, pathPrefix string) field.ErrorList {
+func validateVirtualServerRouteSubroutes(routes []v1alpha1.Route, fieldPath *field.Path, upstreamNames sets.String, routePath string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allPaths := sets.String{}
@@ -458,9 +1440,8 @@ func validateVirtualServerRouteSubroutes(routes []v1alpha1.Route, fieldPath *fie
 		isRouteFieldForbidden := true
 		routeErrs := validateRoute(r, idxPath, upstreamNames, isRouteFieldForbidden)
 
-		if pathPrefix != "" && !strings.HasPrefix(r.Path, pathPrefix) {
-			msg := fmt.Sprintf("must start with '%s'", pathPrefix)
-			routeErrs = append(routeErrs, field.Invalid(idxPath, r.Path, msg))
+		if routePath != "" {
+			routeErrs = append(routeErrs, validateSubroutePathForRoute(r.Path, routePath, idxPath)...)
 		}
 
 		if len(routeErrs) > 0 {
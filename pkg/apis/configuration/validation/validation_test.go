@@ -46,7 +46,7 @@ func TestValidateVirtualServer(t *testing.T) {
 		},
 	}
 
-	err := ValidateVirtualServer(&virtualServer)
+	err := ValidateVirtualServer(&virtualServer, false)
 	if err != nil {
 		t.Errorf("ValidateVirtualServer() returned error %v for valid input %v", err, virtualServer)
 	}
@@ -83,11 +83,29 @@ func TestValidateHost(t *testing.T) {
 }
 
 func TestValidateTLS(t *testing.T) {
+	verifyDepth := 2
+	forwardToUpstream := true
+
 	validTLSes := []*v1alpha1.TLS{
 		nil,
 		{
 			Secret: "my-secret",
 		},
+		{
+			Secret: "my-secret",
+			ClientCertificate: &v1alpha1.ClientCertificate{
+				Secret: "my-ca-secret",
+			},
+		},
+		{
+			Secret: "my-secret",
+			ClientCertificate: &v1alpha1.ClientCertificate{
+				Secret:            "my-ca-secret",
+				Verify:            "optional",
+				VerifyDepth:       &verifyDepth,
+				ForwardToUpstream: &forwardToUpstream,
+			},
+		},
 	}
 
 	for _, tls := range validTLSes {
@@ -97,6 +115,8 @@ func TestValidateTLS(t *testing.T) {
 		}
 	}
 
+	invalidVerifyDepth := -1
+
 	invalidTLSes := []*v1alpha1.TLS{
 		{
 			Secret: "",
@@ -107,6 +127,24 @@ func TestValidateTLS(t *testing.T) {
 		{
 			Secret: "a/b",
 		},
+		{
+			Secret:            "my-secret",
+			ClientCertificate: &v1alpha1.ClientCertificate{},
+		},
+		{
+			Secret: "my-secret",
+			ClientCertificate: &v1alpha1.ClientCertificate{
+				Secret: "my-ca-secret",
+				Verify: "off",
+			},
+		},
+		{
+			Secret: "my-secret",
+			ClientCertificate: &v1alpha1.ClientCertificate{
+				Secret:      "my-ca-secret",
+				VerifyDepth: &invalidVerifyDepth,
+			},
+		},
 	}
 
 	for _, tls := range invalidTLSes {
@@ -147,10 +185,52 @@ func TestValidateUpstreams(t *testing.T) {
 			},
 			msg: "2 valid upstreams",
 		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:    "upstream1",
+					Service: "test-1",
+					Port:    80,
+					Type:    "http",
+				},
+				{
+					Name:    "upstream2",
+					Service: "test-2",
+					Port:    50051,
+					Type:    "grpc",
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+				"upstream2": sets.Empty{},
+			},
+			msg: "upstreams with types",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:        "upstream1",
+					Service:     "test-1",
+					Subselector: map[string]string{"version": "v1"},
+					Port:        80,
+				},
+				{
+					Name:        "upstream2",
+					Service:     "test-1",
+					Subselector: map[string]string{"version": "v2", "app.example.com/track": "canary"},
+					Port:        80,
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+				"upstream2": sets.Empty{},
+			},
+			msg: "upstreams with subselectors",
+		},
 	}
 
 	for _, test := range tests {
-		allErrs, resultUpstreamNames := validateUpstreams(test.upstreams, field.NewPath("upstreams"))
+		allErrs, resultUpstreamNames := validateUpstreams(test.upstreams, field.NewPath("upstreams"), false)
 		if len(allErrs) > 0 {
 			t.Errorf("validateUpstreams() returned errors %v for valid input for the case of %s", allErrs, test.msg)
 		}
@@ -161,6 +241,8 @@ func TestValidateUpstreams(t *testing.T) {
 }
 
 func TestValidateUpstreamsFails(t *testing.T) {
+	invalidNextUpstreamTries := -1
+
 	tests := []struct {
 		upstreams             []v1alpha1.Upstream
 		expectedUpstreamNames sets.String
@@ -221,10 +303,138 @@ func TestValidateUpstreamsFails(t *testing.T) {
 			},
 			msg: "duplicated upstreams",
 		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:    "upstream1",
+					Service: "test-1",
+					Port:    80,
+					Type:    "websocket",
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "invalid type",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:        "upstream1",
+					Service:     "test-1",
+					Port:        50051,
+					Type:        "grpc",
+					HealthCheck: &v1alpha1.HealthCheck{Enable: true},
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "health check for grpc upstream",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:              "upstream1",
+					Service:           "test-1",
+					Port:              80,
+					ProxyNextUpstream: "error http_600",
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "invalid next-upstream",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:        "upstream1",
+					Service:     "test-1",
+					Subselector: map[string]string{"-version": "v1"},
+					Port:        80,
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "invalid subselector key",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:        "upstream1",
+					Service:     "test-1",
+					Subselector: map[string]string{"version": "v1/beta"},
+					Port:        80,
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "invalid subselector value",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:              "upstream1",
+					Service:           "test-1",
+					Port:              80,
+					ProxyNextUpstream: "error timeout non_idempotent",
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "next-upstream with non_idempotent",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:                     "upstream1",
+					Service:                  "test-1",
+					Port:                     80,
+					ProxyNextUpstreamTimeout: "-1s",
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "invalid next-upstream-timeout",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:                   "upstream1",
+					Service:                "test-1",
+					Port:                   80,
+					ProxyNextUpstreamTries: &invalidNextUpstreamTries,
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "invalid next-upstream-tries",
+		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:          "upstream1",
+					Service:       "test-1",
+					Port:          80,
+					LBMethod:      "least_conn",
+					SessionCookie: &v1alpha1.SessionCookie{Enable: true, Name: "srv_id"},
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": sets.Empty{},
+			},
+			msg: "lb-method with sessionCookie",
+		},
 	}
 
 	for _, test := range tests {
-		allErrs, resultUpstreamNames := validateUpstreams(test.upstreams, field.NewPath("upstreams"))
+		allErrs, resultUpstreamNames := validateUpstreams(test.upstreams, field.NewPath("upstreams"), false)
 		if len(allErrs) == 0 {
 			t.Errorf("validateUpstreams() returned no errors for the case of %s", test.msg)
 		}
@@ -234,337 +444,1556 @@ func TestValidateUpstreamsFails(t *testing.T) {
 	}
 }
 
-func TestValidateDNS1035Label(t *testing.T) {
-	validNames := []string{
-		"test",
-		"test-123",
+func TestValidateUpstreamsWithSettings(t *testing.T) {
+	maxFails := 3
+	maxConns := 0
+	keepalive := 16
+	buffering := false
+	nextUpstreamTries := 3
+	upstreams := []v1alpha1.Upstream{
+		{
+			Name:                "upstream1",
+			Service:             "test-1",
+			Port:                80,
+			LBMethod:            "least_conn",
+			FailTimeout:         "10s",
+			MaxFails:            &maxFails,
+			MaxConns:            &maxConns,
+			Keepalive:           &keepalive,
+			ProxyConnectTimeout: "30s",
+			ProxyReadTimeout:    "1m 30s",
+			ProxySendTimeout:    "30s",
+			ProxyBuffering:      &buffering,
+			ProxyBuffers: &v1alpha1.UpstreamBuffers{
+				Number: 8,
+				Size:   "4k",
+			},
+			ProxyBufferSize:          "4k",
+			ClientMaxBodySize:        "1m",
+			ProxyNextUpstream:        "error timeout http_503",
+			ProxyNextUpstreamTimeout: "10s",
+			ProxyNextUpstreamTries:   &nextUpstreamTries,
+		},
+	}
+
+	allErrs, _ := validateUpstreams(upstreams, field.NewPath("upstreams"), false)
+	if len(allErrs) > 0 {
+		t.Errorf("validateUpstreams() returned errors %v for valid input", allErrs)
 	}
+}
 
-	for _, name := range validNames {
-		allErrs := validateDNS1035Label(name, field.NewPath("name"))
+func TestValidateLBMethod(t *testing.T) {
+	tests := []struct {
+		method string
+		isPlus bool
+	}{
+		{
+			method: "",
+			isPlus: false,
+		},
+		{
+			method: "round_robin",
+			isPlus: false,
+		},
+		{
+			method: "random two least_conn",
+			isPlus: false,
+		},
+		{
+			method: "least_time header",
+			isPlus: true,
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateLBMethod(test.method, field.NewPath("lb-method"), test.isPlus)
 		if len(allErrs) > 0 {
-			t.Errorf("validateDNS1035Label(%q) returned errors %v for valid input", name, allErrs)
+			t.Errorf("validateLBMethod(%q, %v) returned errors %v for valid input", test.method, test.isPlus, allErrs)
 		}
 	}
+}
 
-	invalidNames := []string{
-		"",
-		"123",
-		"test.123",
+func TestValidateLBMethodFails(t *testing.T) {
+	tests := []struct {
+		method string
+		isPlus bool
+	}{
+		{
+			method: "least_time header",
+			isPlus: false,
+		},
+		{
+			method: "invalid",
+			isPlus: false,
+		},
+		{
+			method: "invalid",
+			isPlus: true,
+		},
 	}
 
-	for _, name := range invalidNames {
-		allErrs := validateDNS1035Label(name, field.NewPath("name"))
+	for _, test := range tests {
+		allErrs := validateLBMethod(test.method, field.NewPath("lb-method"), test.isPlus)
 		if len(allErrs) == 0 {
-			t.Errorf("validateDNS1035Label(%q) returned no errors for invalid input", name)
+			t.Errorf("validateLBMethod(%q, %v) returned no errors for invalid input", test.method, test.isPlus)
 		}
 	}
 }
 
-func TestValidateVirtualServerRoutes(t *testing.T) {
+func TestValidateTimeFails(t *testing.T) {
+	times := []string{"-1s", "1x", "s", "1 minute"}
+
+	for _, time := range times {
+		allErrs := validateTime(time, field.NewPath("time"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateTime(%q) returned no errors for invalid input", time)
+		}
+	}
+}
+
+func TestValidateSizeFails(t *testing.T) {
+	sizes := []string{"-1", "1g", "k", "1 m"}
+
+	for _, size := range sizes {
+		allErrs := validateSize(size, field.NewPath("size"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateSize(%q) returned no errors for invalid input", size)
+		}
+	}
+}
+
+func TestValidateBuffersFails(t *testing.T) {
 	tests := []struct {
-		routes        []v1alpha1.Route
-		upstreamNames sets.String
-		msg           string
+		buffers *v1alpha1.UpstreamBuffers
+		msg     string
 	}{
 		{
-			routes:        []v1alpha1.Route{},
-			upstreamNames: sets.String{},
-			msg:           "no routes",
+			buffers: &v1alpha1.UpstreamBuffers{
+				Number: 0,
+				Size:   "4k",
+			},
+			msg: "zero number",
 		},
 		{
-			routes: []v1alpha1.Route{
-				{
-					Path:     "/",
-					Upstream: "test",
-				},
+			buffers: &v1alpha1.UpstreamBuffers{
+				Number: 8,
+				Size:   "",
 			},
-			upstreamNames: map[string]sets.Empty{
-				"test": sets.Empty{},
+			msg: "missing size",
+		},
+		{
+			buffers: &v1alpha1.UpstreamBuffers{
+				Number: 8,
+				Size:   "4g",
 			},
-			msg: "valid route",
+			msg: "invalid size",
 		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateVirtualServerRoutes(test.routes, field.NewPath("routes"), test.upstreamNames)
-		if len(allErrs) > 0 {
-			t.Errorf("validateVirtualServerRoutes() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		allErrs := validateBuffers(test.buffers, field.NewPath("buffers"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateBuffers() returned no errors for the case of %s", test.msg)
 		}
 	}
 }
 
-func TestValidateVirtualServerRoutesFails(t *testing.T) {
+func TestValidatePositiveIntOrZeroFails(t *testing.T) {
+	n := -1
+
+	allErrs := validatePositiveIntOrZero(&n, field.NewPath("max-fails"))
+	if len(allErrs) == 0 {
+		t.Errorf("validatePositiveIntOrZero(%v) returned no errors for invalid input", n)
+	}
+}
+
+func TestValidatePositiveIntFails(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		allErrs := validatePositiveInt(&n, field.NewPath("fails"))
+		if len(allErrs) == 0 {
+			t.Errorf("validatePositiveInt(%v) returned no errors for invalid input", n)
+		}
+	}
+}
+
+func TestValidateUpstreamTLS(t *testing.T) {
+	verifyDepth := 2
+	zeroVerifyDepth := 0
+
 	tests := []struct {
-		routes        []v1alpha1.Route
-		upstreamNames sets.String
-		msg           string
+		tls *v1alpha1.UpstreamTLS
+		msg string
 	}{
 		{
-			routes: []v1alpha1.Route{
-				{
-					Path:     "/test",
-					Upstream: "test-1",
-				},
-				{
-					Path:     "/test",
-					Upstream: "test-2",
-				},
-			},
-			upstreamNames: map[string]sets.Empty{
-				"test-1": sets.Empty{},
-				"test-2": sets.Empty{},
+			tls: nil,
+			msg: "no tls",
+		},
+		{
+			tls: &v1alpha1.UpstreamTLS{Enable: true},
+			msg: "only enable",
+		},
+		{
+			tls: &v1alpha1.UpstreamTLS{
+				Enable:            true,
+				TrustedCertSecret: "backend-ca",
+				VerifyDepth:       &verifyDepth,
+				ServerName:        "backend.example.com",
+				Secret:            "backend-client-secret",
 			},
-			msg: "duplicated paths",
+			msg: "all fields",
 		},
-
 		{
-			routes: []v1alpha1.Route{
-				{
-					Path:     "",
-					Upstream: "",
-				},
+			tls: &v1alpha1.UpstreamTLS{
+				Enable:            true,
+				TrustedCertSecret: "backend-ca",
+				VerifyDepth:       &zeroVerifyDepth,
+				ServerName:        "backend.example.com",
 			},
-			upstreamNames: map[string]sets.Empty{},
-			msg:           "invalid route",
+			msg: "zero verify depth",
 		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateVirtualServerRoutes(test.routes, field.NewPath("routes"), test.upstreamNames)
-		if len(allErrs) == 0 {
-			t.Errorf("validateVirtualServerRoutes() returned no errors for the case of %s", test.msg)
+		allErrs := validateUpstreamTLS(test.tls, field.NewPath("tls"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateUpstreamTLS() returned errors %v for valid input for the case of %s", allErrs, test.msg)
 		}
 	}
 }
 
-func TestValidateRoute(t *testing.T) {
+func TestValidateUpstreamTLSFails(t *testing.T) {
+	verifyDepth := 2
+	invalidVerifyDepth := -1
+
 	tests := []struct {
-		route                 v1alpha1.Route
-		upstreamNames         sets.String
-		isRouteFieldForbidden bool
-		msg                   string
+		tls *v1alpha1.UpstreamTLS
+		msg string
 	}{
 		{
-			route: v1alpha1.Route{
+			tls: &v1alpha1.UpstreamTLS{Enable: true, TrustedCertSecret: "-invalid"},
+			msg: "invalid trusted cert secret",
+		},
+		{
+			tls: &v1alpha1.UpstreamTLS{Enable: true, TrustedCertSecret: "backend-ca", ServerName: "backend.example.com", VerifyDepth: &invalidVerifyDepth},
+			msg: "invalid verify depth",
+		},
+		{
+			tls: &v1alpha1.UpstreamTLS{Enable: true, TrustedCertSecret: "backend-ca"},
+			msg: "trusted cert secret without server name",
+		},
+		{
+			tls: &v1alpha1.UpstreamTLS{Enable: true, VerifyDepth: &verifyDepth},
+			msg: "verify depth without trusted cert secret",
+		},
+		{
+			tls: &v1alpha1.UpstreamTLS{Enable: true, ServerName: "backend_example.com"},
+			msg: "invalid server name",
+		},
+		{
+			tls: &v1alpha1.UpstreamTLS{Enable: true, Secret: "invalid/secret"},
+			msg: "invalid secret",
+		},
+	}
 
-				Path:     "/",
-				Upstream: "test",
+	for _, test := range tests {
+		allErrs := validateUpstreamTLS(test.tls, field.NewPath("tls"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateUpstreamTLS() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateSessionCookie(t *testing.T) {
+	tests := []struct {
+		sc  *v1alpha1.SessionCookie
+		msg string
+	}{
+		{
+			sc:  nil,
+			msg: "no session cookie",
+		},
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: false},
+			msg: "disabled session cookie",
+		},
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: true, Name: "srv_id"},
+			msg: "only name",
+		},
+		{
+			sc: &v1alpha1.SessionCookie{
+				Enable:   true,
+				Name:     "srv_id",
+				Path:     "/coffee",
+				Expires:  "max",
+				Domain:   ".example.com",
+				HTTPOnly: true,
+				Secure:   true,
+			},
+			msg: "all fields with max expires",
+		},
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: true, Name: "srv_id", Expires: "1h", Domain: "example.com"},
+			msg: "time expires",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateSessionCookie(test.sc, field.NewPath("sessionCookie"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateSessionCookie() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateSessionCookieFails(t *testing.T) {
+	tests := []struct {
+		sc  *v1alpha1.SessionCookie
+		msg string
+	}{
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: true},
+			msg: "missing name",
+		},
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: true, Name: "srv-id"},
+			msg: "invalid name",
+		},
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: true, Name: "srv_id", Path: "coffee"},
+			msg: "invalid path",
+		},
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: true, Name: "srv_id", Expires: "forever"},
+			msg: "invalid expires",
+		},
+		{
+			sc:  &v1alpha1.SessionCookie{Enable: true, Name: "srv_id", Domain: "example.com;"},
+			msg: "invalid domain",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateSessionCookie(test.sc, field.NewPath("sessionCookie"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateSessionCookie() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateSplitCookie(t *testing.T) {
+	maxAge := 3600
+
+	tests := []struct {
+		sc  *v1alpha1.SplitCookie
+		msg string
+	}{
+		{
+			sc:  &v1alpha1.SplitCookie{Name: "canary"},
+			msg: "only name",
+		},
+		{
+			sc:  &v1alpha1.SplitCookie{Name: "canary", Path: "/coffee", MaxAge: &maxAge},
+			msg: "all fields",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateSplitCookie(test.sc, field.NewPath("splitCookie"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateSplitCookie() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateSplitCookieFails(t *testing.T) {
+	invalidMaxAge := 0
+
+	tests := []struct {
+		sc  *v1alpha1.SplitCookie
+		msg string
+	}{
+		{
+			sc:  &v1alpha1.SplitCookie{},
+			msg: "missing name",
+		},
+		{
+			sc:  &v1alpha1.SplitCookie{Name: "canary-cookie"},
+			msg: "invalid name",
+		},
+		{
+			sc:  &v1alpha1.SplitCookie{Name: "canary", Path: "coffee"},
+			msg: "invalid path",
+		},
+		{
+			sc:  &v1alpha1.SplitCookie{Name: "canary", MaxAge: &invalidMaxAge},
+			msg: "invalid max age",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateSplitCookie(test.sc, field.NewPath("splitCookie"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateSplitCookie() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateUpstreamHealthCheck(t *testing.T) {
+	fails := 3
+	passes := 2
+	hc := &v1alpha1.HealthCheck{
+		Enable:         true,
+		Path:           "/healthz",
+		Interval:       "5s",
+		Jitter:         "2s",
+		Fails:          &fails,
+		Passes:         &passes,
+		Port:           8080,
+		TLS:            &v1alpha1.UpstreamTLS{Enable: true},
+		ConnectTimeout: "1s",
+		ReadTimeout:    "1s",
+		SendTimeout:    "1s",
+		Headers: []v1alpha1.Header{
+			{
+				Name:  "Host",
+				Value: "my.service",
+			},
+		},
+		StatusMatch: "! 500",
+		BodyMatch:   "ok",
+	}
+
+	allErrs := validateUpstreamHealthCheck(hc, field.NewPath("healthCheck"), true)
+	if len(allErrs) > 0 {
+		t.Errorf("validateUpstreamHealthCheck() returned errors %v for valid input", allErrs)
+	}
+
+	allErrs = validateUpstreamHealthCheck(&v1alpha1.HealthCheck{Enable: true}, field.NewPath("healthCheck"), true)
+	if len(allErrs) > 0 {
+		t.Errorf("validateUpstreamHealthCheck() returned errors %v for an empty health check", allErrs)
+	}
+}
+
+func TestValidateUpstreamHealthCheckFails(t *testing.T) {
+	tests := []struct {
+		hc     *v1alpha1.HealthCheck
+		isPlus bool
+		msg    string
+	}{
+		{
+			hc:     &v1alpha1.HealthCheck{Enable: true},
+			isPlus: false,
+			msg:    "health check for NGINX",
+		},
+		{
+			hc:     &v1alpha1.HealthCheck{Enable: true, Path: "healthz"},
+			isPlus: true,
+			msg:    "invalid path",
+		},
+		{
+			hc:     &v1alpha1.HealthCheck{Enable: true, Interval: "5 sec"},
+			isPlus: true,
+			msg:    "invalid interval",
+		},
+		{
+			hc:     &v1alpha1.HealthCheck{Enable: true, Port: 70000},
+			isPlus: true,
+			msg:    "invalid port",
+		},
+		{
+			hc: &v1alpha1.HealthCheck{
+				Enable:  true,
+				Headers: []v1alpha1.Header{{Name: "Host:", Value: "my.service"}},
+			},
+			isPlus: true,
+			msg:    "invalid header name",
+		},
+		{
+			hc: &v1alpha1.HealthCheck{
+				Enable:  true,
+				Headers: []v1alpha1.Header{{Name: "Host", Value: `my"service`}},
+			},
+			isPlus: true,
+			msg:    "invalid header value",
+		},
+		{
+			hc:     &v1alpha1.HealthCheck{Enable: true, BodyMatch: `"ok"`},
+			isPlus: true,
+			msg:    "invalid body match",
+		},
+		{
+			hc:     &v1alpha1.HealthCheck{Enable: true, BodyMatch: "ok("},
+			isPlus: true,
+			msg:    "invalid regex in body match",
+		},
+		{
+			hc:     &v1alpha1.HealthCheck{Enable: true, BodyMatch: "[a-z"},
+			isPlus: true,
+			msg:    "unbalanced brackets in body match",
+		},
+		{
+			hc: &v1alpha1.HealthCheck{
+				Enable: true,
+				TLS:    &v1alpha1.UpstreamTLS{Enable: true, TrustedCertSecret: "backend-ca"},
+			},
+			isPlus: true,
+			msg:    "trusted cert secret in health check tls",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateUpstreamHealthCheck(test.hc, field.NewPath("healthCheck"), test.isPlus)
+		if len(allErrs) == 0 {
+			t.Errorf("validateUpstreamHealthCheck() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateStatusMatch(t *testing.T) {
+	validMatches := []string{
+		"200",
+		"! 500",
+		"200 204",
+		"200-399",
+		"! 400-599",
+	}
+
+	for _, m := range validMatches {
+		allErrs := validateStatusMatch(m, field.NewPath("statusMatch"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateStatusMatch(%q) returned errors %v for valid input", m, allErrs)
+		}
+	}
+}
+
+func TestValidateStatusMatchFails(t *testing.T) {
+	invalidMatches := []string{
+		"!",
+		"!500",
+		"99",
+		"600",
+		"399-200",
+		"200-300-400",
+		"ok",
+	}
+
+	for _, m := range invalidMatches {
+		allErrs := validateStatusMatch(m, field.NewPath("statusMatch"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateStatusMatch(%q) returned no errors for invalid input", m)
+		}
+	}
+}
+
+func TestValidateDNS1035Label(t *testing.T) {
+	validNames := []string{
+		"test",
+		"test-123",
+	}
+
+	for _, name := range validNames {
+		allErrs := validateDNS1035Label(name, field.NewPath("name"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateDNS1035Label(%q) returned errors %v for valid input", name, allErrs)
+		}
+	}
+
+	invalidNames := []string{
+		"",
+		"123",
+		"test.123",
+	}
+
+	for _, name := range invalidNames {
+		allErrs := validateDNS1035Label(name, field.NewPath("name"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateDNS1035Label(%q) returned no errors for invalid input", name)
+		}
+	}
+}
+
+func TestValidateVirtualServerRoutes(t *testing.T) {
+	tests := []struct {
+		routes        []v1alpha1.Route
+		upstreamNames sets.String
+		msg           string
+	}{
+		{
+			routes:        []v1alpha1.Route{},
+			upstreamNames: sets.String{},
+			msg:           "no routes",
+		},
+		{
+			routes: []v1alpha1.Route{
+				{
+					Path:     "/",
+					Upstream: "test",
+				},
 			},
 			upstreamNames: map[string]sets.Empty{
 				"test": sets.Empty{},
 			},
-			isRouteFieldForbidden: false,
-			msg:                   "valid route with upstream",
+			msg: "valid route",
 		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateVirtualServerRoutes(test.routes, field.NewPath("routes"), test.upstreamNames)
+		if len(allErrs) > 0 {
+			t.Errorf("validateVirtualServerRoutes() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateVirtualServerRoutesFails(t *testing.T) {
+	tests := []struct {
+		routes        []v1alpha1.Route
+		upstreamNames sets.String
+		msg           string
+	}{
 		{
-			route: v1alpha1.Route{
-				Path: "/",
-				Splits: []v1alpha1.Split{
-					{
-						Weight:   90,
-						Upstream: "test-1",
-					},
-					{
-						Weight:   10,
-						Upstream: "test-2",
-					},
+			routes: []v1alpha1.Route{
+				{
+					Path:     "/test",
+					Upstream: "test-1",
+				},
+				{
+					Path:     "/test",
+					Upstream: "test-2",
 				},
 			},
 			upstreamNames: map[string]sets.Empty{
 				"test-1": sets.Empty{},
 				"test-2": sets.Empty{},
 			},
-			isRouteFieldForbidden: false,
-			msg:                   "valid upstream with splits",
+			msg: "duplicated paths",
+		},
+
+		{
+			routes: []v1alpha1.Route{
+				{
+					Path:     "",
+					Upstream: "",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{},
+			msg:           "invalid route",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateVirtualServerRoutes(test.routes, field.NewPath("routes"), test.upstreamNames)
+		if len(allErrs) == 0 {
+			t.Errorf("validateVirtualServerRoutes() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateRoute(t *testing.T) {
+	tests := []struct {
+		route                 v1alpha1.Route
+		upstreamNames         sets.String
+		isRouteFieldForbidden bool
+		msg                   string
+	}{
+		{
+			route: v1alpha1.Route{
+
+				Path:     "/",
+				Upstream: "test",
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with upstream",
+		},
+		{
+			route: v1alpha1.Route{
+				Path: "/",
+				Splits: []v1alpha1.Split{
+					{
+						Weight:   90,
+						Upstream: "test-1",
+					},
+					{
+						Weight:   10,
+						Upstream: "test-2",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": sets.Empty{},
+				"test-2": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid upstream with splits",
+		},
+		{
+			route: v1alpha1.Route{
+				Path: "/",
+				Splits: []v1alpha1.Split{
+					{
+						Weight:   90,
+						Upstream: "test-1",
+					},
+					{
+						Weight:   10,
+						Upstream: "test-2",
+					},
+				},
+				SplitKey: "${remote_addr}${http_user_agent}",
+				SplitCookie: &v1alpha1.SplitCookie{
+					Name: "canary",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": sets.Empty{},
+				"test-2": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid upstream with splits, split key and split cookie",
+		},
+		{
+			route: v1alpha1.Route{
+				Path: "/",
+				Rules: &v1alpha1.Rules{
+					Conditions: []v1alpha1.Condition{
+						{
+							Header: "x-version",
+						},
+					},
+					Matches: []v1alpha1.Match{
+						{
+							Values: []string{
+								"test-1",
+							},
+							Upstream: "test-1",
+						},
+					},
+					DefaultUpstream: "test-2",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": sets.Empty{},
+				"test-2": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid upstream with rules",
+		},
+		{
+			route: v1alpha1.Route{
+
+				Path:  "/",
+				Route: "default/test",
+			},
+			upstreamNames:         map[string]sets.Empty{},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with route",
+		},
+		{
+			route: v1alpha1.Route{
+				Path: "/",
+				Action: &v1alpha1.Action{
+					Redirect: &v1alpha1.ActionRedirect{
+						URL: "https://example.com",
+					},
+				},
+			},
+			upstreamNames:         map[string]sets.Empty{},
+			isRouteFieldForbidden: true,
+			msg:                   "valid route with action",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:        "=/coffee",
+				Upstream:    "test",
+				RewritePath: "/beans",
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with exact path",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:     `~* \.(jpg|png)$`,
+				Upstream: "test",
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with regex path",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRoute(test.route, field.NewPath("route"), test.upstreamNames, test.isRouteFieldForbidden)
+		if len(allErrs) > 0 {
+			t.Errorf("validateRoute() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateRouteFails(t *testing.T) {
+	tests := []struct {
+		route                 v1alpha1.Route
+		upstreamNames         sets.String
+		isRouteFieldForbidden bool
+		msg                   string
+	}{
+		{
+			route: v1alpha1.Route{
+				Path:     "",
+				Upstream: "test",
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "empty path",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:     "/test",
+				Upstream: "-test",
+			},
+			upstreamNames:         sets.String{},
+			isRouteFieldForbidden: false,
+			msg:                   "invalid upstream",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:     "/",
+				Upstream: "test",
+			},
+			upstreamNames:         sets.String{},
+			isRouteFieldForbidden: false,
+			msg:                   "non-existing upstream",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:  "/",
+				Route: "default/test",
+				ResponseHeaders: &v1alpha1.ProxyResponseHeaders{
+					Hide: []string{"X-Powered-By"},
+				},
+			},
+			upstreamNames:         sets.String{},
+			isRouteFieldForbidden: false,
+			msg:                   "response headers for a route with route",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:        "/",
+				Route:       "default/test",
+				RewritePath: "/beans",
+			},
+			upstreamNames:         sets.String{},
+			isRouteFieldForbidden: false,
+			msg:                   "rewrite path for a route with route",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:        "~ ^/coffee/v[0-9]+",
+				Upstream:    "test",
+				RewritePath: "/beans",
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "rewrite path for a regex path",
+		},
+		{
+			route: v1alpha1.Route{
+				Path: "~ ^/coffee/v[0-9]+",
+				Splits: []v1alpha1.Split{
+					{
+						Weight:      90,
+						Upstream:    "test",
+						RewritePath: "/beans",
+					},
+					{
+						Weight:   10,
+						Upstream: "test",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "rewrite path in a split for a regex path",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:     "/",
+				Upstream: "test",
+				Splits: []v1alpha1.Split{
+					{
+						Weight:   90,
+						Upstream: "test-1",
+					},
+					{
+						Weight:   10,
+						Upstream: "test-2",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test":   sets.Empty{},
+				"test-1": sets.Empty{},
+				"test-2": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "both upstream and splits exist",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:     "/",
+				Upstream: "test",
+				SplitKey: "$remote_addr",
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "split key for a route without splits",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:     "/",
+				Upstream: "test",
+				SplitCookie: &v1alpha1.SplitCookie{
+					Name: "canary",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "split cookie for a route without splits",
+		},
+		{
+			route: v1alpha1.Route{
+				Path: "/",
+				Splits: []v1alpha1.Split{
+					{
+						Weight:   90,
+						Upstream: "test-1",
+					},
+					{
+						Weight:   10,
+						Upstream: "test-2",
+					},
+				},
+				SplitKey: "${unknown_variable}",
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": sets.Empty{},
+				"test-2": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "invalid split key",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:     "/",
+				Upstream: "test",
+				Rules: &v1alpha1.Rules{
+					Conditions: []v1alpha1.Condition{
+						{
+							Header: "x-version",
+						},
+					},
+					Matches: []v1alpha1.Match{
+						{
+							Values: []string{
+								"test-1",
+							},
+							Upstream: "test-1",
+						},
+					},
+					DefaultUpstream: "test-2",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test":   sets.Empty{},
+				"test-1": sets.Empty{},
+				"test-2": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "both upstream and rules exist",
+		},
+		{
+			route: v1alpha1.Route{
+				Path: "/",
+				Splits: []v1alpha1.Split{
+					{
+						Weight:   90,
+						Upstream: "test-1",
+					},
+					{
+						Weight:   10,
+						Upstream: "test-2",
+					},
+				},
+				Rules: &v1alpha1.Rules{
+					Conditions: []v1alpha1.Condition{
+						{
+							Header: "x-version",
+						},
+					},
+					Matches: []v1alpha1.Match{
+						{
+							Values: []string{
+								"test-1",
+							},
+							Upstream: "test-1",
+						},
+					},
+					DefaultUpstream: "test-2",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": sets.Empty{},
+				"test-2": sets.Empty{},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "both splits and rules exist",
+		},
+		{
+			route: v1alpha1.Route{
+				Path:  "/",
+				Route: "default/test",
+			},
+			upstreamNames:         map[string]sets.Empty{},
+			isRouteFieldForbidden: true,
+			msg:                   "route field exists but is forbidden",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRoute(test.route, field.NewPath("route"), test.upstreamNames, test.isRouteFieldForbidden)
+		if len(allErrs) == 0 {
+			t.Errorf("validateRoute() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateAction(t *testing.T) {
+	upstreamNames := map[string]sets.Empty{
+		"test": sets.Empty{},
+	}
+	tests := []struct {
+		action *v1alpha1.Action
+		msg    string
+	}{
+		{
+			action: &v1alpha1.Action{
+				Pass: "test",
+			},
+			msg: "pass",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "http://example.com",
+				},
+			},
+			msg: "redirect without code",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL:  "$scheme://example.com$request_uri",
+					Code: 302,
+				},
+			},
+			msg: "redirect with variables and code",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "https://${host}:$server_port/coffee?from=$arg_from",
+				},
+			},
+			msg: "redirect with variables in the host and the query",
+		},
+		{
+			action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Body: "hello from $server_name to $http_user_agent",
+				},
+			},
+			msg: "return with variables",
+		},
+		{
+			action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Body: "hello",
+				},
+			},
+			msg: "return without code and type",
+		},
+		{
+			action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Code: 503,
+					Type: "application/json",
+					Body: `{\"status\": \"maintenance\"}`,
+				},
+			},
+			msg: "return with all fields",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAction(test.action, field.NewPath("action"), upstreamNames)
+		if len(allErrs) > 0 {
+			t.Errorf("validateAction() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateActionFails(t *testing.T) {
+	upstreamNames := map[string]sets.Empty{
+		"test": sets.Empty{},
+	}
+	tests := []struct {
+		action *v1alpha1.Action
+		msg    string
+	}{
+		{
+			action: &v1alpha1.Action{},
+			msg:    "empty action",
+		},
+		{
+			action: &v1alpha1.Action{
+				Pass: "test",
+				Return: &v1alpha1.ActionReturn{
+					Body: "hello",
+				},
+			},
+			msg: "pass and return",
+		},
+		{
+			action: &v1alpha1.Action{
+				Pass: "not-exists",
+			},
+			msg: "pass to non-existing upstream",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{},
+			},
+			msg: "redirect without url",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "/coffee",
+				},
+			},
+			msg: "redirect with relative url",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "http://example.com/\"",
+				},
+			},
+			msg: "redirect with invalid url",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "http://example.com/$not_exists",
+				},
+			},
+			msg: "redirect with unsupported variable",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "http://example.com/$",
+				},
+			},
+			msg: "redirect with '$' without variable name",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "https://$arg_next/coffee",
+				},
+			},
+			msg: "redirect with request argument in the host",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL: "https://${http_x_target}.example.com",
+				},
+			},
+			msg: "redirect with request header in the host",
+		},
+		{
+			action: &v1alpha1.Action{
+				Redirect: &v1alpha1.ActionRedirect{
+					URL:  "http://example.com",
+					Code: 200,
+				},
+			},
+			msg: "redirect with invalid code",
+		},
+		{
+			action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Code: 301,
+				},
+			},
+			msg: "return with redirect code",
+		},
+		{
+			action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Type: "text",
+				},
+			},
+			msg: "return with invalid type",
+		},
+		{
+			action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Body: `"hello"`,
+				},
+			},
+			msg: "return with invalid body",
+		},
+		{
+			action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Body: "hello $not_exists",
+				},
+			},
+			msg: "return with unsupported variable",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAction(test.action, field.NewPath("action"), upstreamNames)
+		if len(allErrs) == 0 {
+			t.Errorf("validateAction() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateProxyRequestHeaders(t *testing.T) {
+	pass := false
+	requestHeaders := &v1alpha1.ProxyRequestHeaders{
+		Pass: &pass,
+		Set: []v1alpha1.Header{
+			{
+				Name:  "Host",
+				Value: "example.com",
+			},
+			{
+				Name:  "X-Request-Info",
+				Value: "${request_method} $request_uri from ${http_user_agent}",
+			},
+		},
+		Ignore: []string{"Cookie"},
+	}
+
+	allErrs := validateProxyRequestHeaders(requestHeaders, field.NewPath("requestHeaders"))
+	if len(allErrs) > 0 {
+		t.Errorf("validateProxyRequestHeaders() returned errors %v for valid input", allErrs)
+	}
+}
+
+func TestValidateProxyRequestHeadersFails(t *testing.T) {
+	tests := []struct {
+		requestHeaders *v1alpha1.ProxyRequestHeaders
+		msg            string
+	}{
+		{
+			requestHeaders: &v1alpha1.ProxyRequestHeaders{
+				Set: []v1alpha1.Header{{Name: "Host:", Value: "example.com"}},
+			},
+			msg: "invalid header name",
+		},
+		{
+			requestHeaders: &v1alpha1.ProxyRequestHeaders{
+				Set: []v1alpha1.Header{{Name: "X-Forwarded-For", Value: "1.1.1.1"}},
+			},
+			msg: "reserved header name",
+		},
+		{
+			requestHeaders: &v1alpha1.ProxyRequestHeaders{
+				Set: []v1alpha1.Header{{Name: "X-Secret", Value: "$request_body"}},
+			},
+			msg: "unsupported variable",
+		},
+		{
+			requestHeaders: &v1alpha1.ProxyRequestHeaders{
+				Ignore: []string{"X-Real-IP"},
+			},
+			msg: "reserved ignored header",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateProxyRequestHeaders(test.requestHeaders, field.NewPath("requestHeaders"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateProxyRequestHeaders() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateProxyResponseHeaders(t *testing.T) {
+	responseHeaders := &v1alpha1.ProxyResponseHeaders{
+		Add: []v1alpha1.AddHeader{
+			{
+				Header: v1alpha1.Header{
+					Name:  "X-Upstream",
+					Value: "$upstream_addr",
+				},
+				Always: true,
+			},
+		},
+		Hide:   []string{"X-Powered-By"},
+		Pass:   []string{"Server"},
+		Ignore: []string{"Expires", "Set-Cookie"},
+	}
+
+	allErrs := validateProxyResponseHeaders(responseHeaders, field.NewPath("responseHeaders"))
+	if len(allErrs) > 0 {
+		t.Errorf("validateProxyResponseHeaders() returned errors %v for valid input", allErrs)
+	}
+}
+
+func TestValidateProxyResponseHeadersFails(t *testing.T) {
+	tests := []struct {
+		responseHeaders *v1alpha1.ProxyResponseHeaders
+		msg             string
+	}{
+		{
+			responseHeaders: &v1alpha1.ProxyResponseHeaders{
+				Add: []v1alpha1.AddHeader{{Header: v1alpha1.Header{Name: "X-Upstream", Value: `"abc`}}},
+			},
+			msg: "invalid header value",
+		},
+		{
+			responseHeaders: &v1alpha1.ProxyResponseHeaders{
+				Hide: []string{"X Powered By"},
+			},
+			msg: "invalid hidden header",
+		},
+		{
+			responseHeaders: &v1alpha1.ProxyResponseHeaders{
+				Pass: []string{""},
+			},
+			msg: "invalid passed header",
+		},
+		{
+			responseHeaders: &v1alpha1.ProxyResponseHeaders{
+				Ignore: []string{"X-Powered-By"},
+			},
+			msg: "unsupported ignored header",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateProxyResponseHeaders(test.responseHeaders, field.NewPath("responseHeaders"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateProxyResponseHeaders() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateStringWithVariables(t *testing.T) {
+	validValues := []string{
+		"",
+		"value",
+		`escaped \"quotes\"`,
+		"$host",
+		"${host}",
+		"$scheme://${host}$request_uri",
+		"$http_x_user ${cookie_session} $arg_id",
+	}
+
+	for _, v := range validValues {
+		allErrs := validateStringWithVariables(v, field.NewPath("value"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateStringWithVariables(%q) returned errors %v for valid input", v, allErrs)
+		}
+	}
+
+	invalidValues := []string{
+		`"`,
+		"$",
+		"cost: 5$",
+		"${host",
+		"$request_body",
+		"${http_}",
+		"$unknown",
+	}
+
+	for _, v := range invalidValues {
+		allErrs := validateStringWithVariables(v, field.NewPath("value"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateStringWithVariables(%q) returned no errors for invalid input", v)
+		}
+	}
+}
+
+func TestValidateRewritePath(t *testing.T) {
+	validPaths := []string{
+		"/",
+		"/beans",
+		"/v2/beans/",
+	}
+
+	for _, p := range validPaths {
+		allErrs := validateRewritePath(p, field.NewPath("rewritePath"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateRewritePath(%q) returned errors %v for valid input", p, allErrs)
+		}
+	}
+
+	invalidPaths := []string{
+		"beans",
+		"/be ans",
+		"/beans;",
+		"/$uri",
+		`/beans"`,
+		`/beans\`,
+	}
+
+	for _, p := range invalidPaths {
+		allErrs := validateRewritePath(p, field.NewPath("rewritePath"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRewritePath(%q) returned no errors for invalid input", p)
+		}
+	}
+}
+
+func TestValidateRoutePath(t *testing.T) {
+	validPaths := []string{
+		"/",
+		"/path",
+		"=/exact/path",
+		`~ ^/api/v[0-9]+/`,
+		`~* \.(jpg|png)$`,
+		`~ ^/coffee/\d{3}$`,
+		`~ ^/tea/\"quoted\"$`,
+		`~ ^/(tea|coffee)+/\d+$`,
+	}
+
+	for _, path := range validPaths {
+		allErrs := validateRoutePath(path, field.NewPath("path"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateRoutePath(%q) returned errors %v for valid input", path, allErrs)
+		}
+	}
+
+	invalidPaths := []string{
+		"",
+		"=",
+		"=path",
+		"= /path",
+		"~",
+		"~ ",
+		"~^/api",
+		"~*\\.jpg",
+		`~ ^/api/(v1`,
+		`~ ^/api/[v1`,
+		`~ ^/api/"`,
+		`~ ^/api\`,
+		`~ ^/api/(?=v1)`,
+		`~ ^/(a+)+$`,
+		`~* ^/(?:\w+\s?)*$`,
+		`~ ^/(a*){2,5}`,
+	}
+
+	for _, path := range invalidPaths {
+		allErrs := validateRoutePath(path, field.NewPath("path"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRoutePath(%q) returned no errors for invalid input", path)
+		}
+	}
+}
+
+func TestValidateSubroutePathForRoute(t *testing.T) {
+	tests := []struct {
+		subroutePath string
+		routePath    string
+	}{
+		{
+			subroutePath: "/coffee/latte",
+			routePath:    "/coffee",
 		},
 		{
-			route: v1alpha1.Route{
-				Path: "/",
-				Rules: &v1alpha1.Rules{
-					Conditions: []v1alpha1.Condition{
-						{
-							Header: "x-version",
-						},
-					},
-					Matches: []v1alpha1.Match{
-						{
-							Values: []string{
-								"test-1",
-							},
-							Upstream: "test-1",
-						},
-					},
-					DefaultUpstream: "test-2",
-				},
-			},
-			upstreamNames: map[string]sets.Empty{
-				"test-1": sets.Empty{},
-				"test-2": sets.Empty{},
-			},
-			isRouteFieldForbidden: false,
-			msg:                   "valid upstream with rules",
+			subroutePath: "=/coffee/latte",
+			routePath:    "/coffee",
 		},
 		{
-			route: v1alpha1.Route{
-
-				Path:  "/",
-				Route: "default/test",
-			},
-			upstreamNames:         map[string]sets.Empty{},
-			isRouteFieldForbidden: false,
-			msg:                   "valid route with route",
+			subroutePath: "=/coffee",
+			routePath:    "=/coffee",
+		},
+		{
+			subroutePath: "~ ^/coffee/v[0-9]+",
+			routePath:    "~ ^/coffee/v[0-9]+",
 		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateRoute(test.route, field.NewPath("route"), test.upstreamNames, test.isRouteFieldForbidden)
+		allErrs := validateSubroutePathForRoute(test.subroutePath, test.routePath, field.NewPath("subroutes"))
 		if len(allErrs) > 0 {
-			t.Errorf("validateRoute() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+			t.Errorf("validateSubroutePathForRoute(%q, %q) returned errors %v for valid input", test.subroutePath, test.routePath, allErrs)
 		}
 	}
 }
 
-func TestValidateRouteFails(t *testing.T) {
+func TestValidateSubroutePathForRouteFails(t *testing.T) {
 	tests := []struct {
-		route                 v1alpha1.Route
-		upstreamNames         sets.String
-		isRouteFieldForbidden bool
-		msg                   string
+		subroutePath string
+		routePath    string
 	}{
 		{
-			route: v1alpha1.Route{
-				Path:     "",
-				Upstream: "test",
-			},
-			upstreamNames: map[string]sets.Empty{
-				"test": sets.Empty{},
-			},
-			isRouteFieldForbidden: false,
-			msg:                   "empty path",
-		},
-		{
-			route: v1alpha1.Route{
-				Path:     "/test",
-				Upstream: "-test",
-			},
-			upstreamNames:         sets.String{},
-			isRouteFieldForbidden: false,
-			msg:                   "invalid upstream",
+			subroutePath: "/tea",
+			routePath:    "/coffee",
 		},
 		{
-			route: v1alpha1.Route{
-				Path:     "/",
-				Upstream: "test",
-			},
-			upstreamNames:         sets.String{},
-			isRouteFieldForbidden: false,
-			msg:                   "non-existing upstream",
+			subroutePath: "=/tea",
+			routePath:    "/coffee",
 		},
 		{
-			route: v1alpha1.Route{
-				Path:     "/",
-				Upstream: "test",
-				Splits: []v1alpha1.Split{
-					{
-						Weight:   90,
-						Upstream: "test-1",
-					},
-					{
-						Weight:   10,
-						Upstream: "test-2",
-					},
-				},
-			},
-			upstreamNames: map[string]sets.Empty{
-				"test":   sets.Empty{},
-				"test-1": sets.Empty{},
-				"test-2": sets.Empty{},
-			},
-			isRouteFieldForbidden: false,
-			msg:                   "both upstream and splits exist",
+			subroutePath: "~ ^/coffee/v[0-9]+",
+			routePath:    "/coffee",
 		},
 		{
-			route: v1alpha1.Route{
-				Path:     "/",
-				Upstream: "test",
-				Rules: &v1alpha1.Rules{
-					Conditions: []v1alpha1.Condition{
-						{
-							Header: "x-version",
-						},
-					},
-					Matches: []v1alpha1.Match{
-						{
-							Values: []string{
-								"test-1",
-							},
-							Upstream: "test-1",
-						},
-					},
-					DefaultUpstream: "test-2",
-				},
-			},
-			upstreamNames: map[string]sets.Empty{
-				"test":   sets.Empty{},
-				"test-1": sets.Empty{},
-				"test-2": sets.Empty{},
-			},
-			isRouteFieldForbidden: false,
-			msg:                   "both upstream and rules exist",
+			subroutePath: "/coffee/latte",
+			routePath:    "=/coffee",
 		},
 		{
-			route: v1alpha1.Route{
-				Path: "/",
-				Splits: []v1alpha1.Split{
-					{
-						Weight:   90,
-						Upstream: "test-1",
-					},
-					{
-						Weight:   10,
-						Upstream: "test-2",
-					},
-				},
-				Rules: &v1alpha1.Rules{
-					Conditions: []v1alpha1.Condition{
-						{
-							Header: "x-version",
-						},
-					},
-					Matches: []v1alpha1.Match{
-						{
-							Values: []string{
-								"test-1",
-							},
-							Upstream: "test-1",
-						},
-					},
-					DefaultUpstream: "test-2",
-				},
-			},
-			upstreamNames: map[string]sets.Empty{
-				"test-1": sets.Empty{},
-				"test-2": sets.Empty{},
-			},
-			isRouteFieldForbidden: false,
-			msg:                   "both splits and rules exist",
+			subroutePath: "~ ^/coffee/v[0-9]",
+			routePath:    "~ ^/coffee/v[0-9]+",
 		},
 		{
-			route: v1alpha1.Route{
-				Path:  "/",
-				Route: "default/test",
-			},
-			upstreamNames:         map[string]sets.Empty{},
-			isRouteFieldForbidden: true,
-			msg:                   "route field exists but is forbidden",
+			subroutePath: "~* ^/coffee/v[0-9]+",
+			routePath:    "~ ^/coffee/v[0-9]+",
 		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateRoute(test.route, field.NewPath("route"), test.upstreamNames, test.isRouteFieldForbidden)
+		allErrs := validateSubroutePathForRoute(test.subroutePath, test.routePath, field.NewPath("subroutes"))
 		if len(allErrs) == 0 {
-			t.Errorf("validateRoute() returned no errors for invalid input for the case of %s", test.msg)
+			t.Errorf("validateSubroutePathForRoute(%q, %q) returned no errors for invalid input", test.subroutePath, test.routePath)
 		}
 	}
 }
@@ -692,6 +2121,32 @@ func TestValidateSplits(t *testing.T) {
 	}
 }
 
+func TestValidateSplitsWithAction(t *testing.T) {
+	splits := []v1alpha1.Split{
+		{
+			Weight:   90,
+			Upstream: "test-1",
+		},
+		{
+			Weight: 10,
+			Action: &v1alpha1.Action{
+				Return: &v1alpha1.ActionReturn{
+					Code: 503,
+					Body: "maintenance",
+				},
+			},
+		},
+	}
+	upstreamNames := map[string]sets.Empty{
+		"test-1": sets.Empty{},
+	}
+
+	allErrs := validateSplits(splits, field.NewPath("splits"), upstreamNames)
+	if len(allErrs) > 0 {
+		t.Errorf("validateSplits() returned errors %v for valid input", allErrs)
+	}
+}
+
 func TestValidateSplitsFails(t *testing.T) {
 	tests := []struct {
 		splits        []v1alpha1.Split
@@ -1144,6 +2599,35 @@ func TestValidateMatchFails(t *testing.T) {
 			upstreamNames:   map[string]sets.Empty{},
 			msg:             "invalid upstream",
 		},
+		{
+			match: v1alpha1.Match{
+				Values: []string{
+					"value",
+				},
+				Upstream: "test",
+				Action: &v1alpha1.Action{
+					Pass: "test",
+				},
+			},
+			conditionsCount: 1,
+			upstreamNames: map[string]sets.Empty{
+				"test": sets.Empty{},
+			},
+			msg: "both upstream and action",
+		},
+		{
+			match: v1alpha1.Match{
+				Values: []string{
+					"value",
+				},
+				Action: &v1alpha1.Action{
+					Pass: "test",
+				},
+			},
+			conditionsCount: 1,
+			upstreamNames:   map[string]sets.Empty{},
+			msg:             "action with non-existing upstream",
+		},
 	}
 
 	for _, test := range tests {
@@ -1186,6 +2670,100 @@ func TestIsValidMatchValue(t *testing.T) {
 	}
 }
 
+func TestValidateWallarm(t *testing.T) {
+	tests := []struct {
+		wallarm *v1alpha1.Wallarm
+		msg     string
+	}{
+		{
+			wallarm: nil,
+			msg:     "no wallarm",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{},
+			msg:     "empty wallarm",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{
+				Mode:              "block",
+				ModeAllowOverride: "strict",
+				Fallback:          "off",
+				Instance:          "42",
+				BlockPage:         "/usr/share/nginx/html/wallarm_blocked.html",
+				ParseResponse:     "on",
+				ParseWebsocket:    "on",
+				UnpackResponse:    "off",
+				ParserDisable:     []string{"json", "base64"},
+			},
+			msg: "all fields",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateWallarm(test.wallarm, field.NewPath("wallarm"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateWallarm() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateWallarmFails(t *testing.T) {
+	tests := []struct {
+		wallarm *v1alpha1.Wallarm
+		msg     string
+	}{
+		{
+			wallarm: &v1alpha1.Wallarm{
+				Mode: "aggressive",
+			},
+			msg: "invalid mode",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{
+				ModeAllowOverride: "yes",
+			},
+			msg: "invalid modeAllowOverride",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{
+				Fallback: "true",
+			},
+			msg: "invalid fallback",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{
+				Instance: "abc",
+			},
+			msg: "invalid instance",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{
+				BlockPage: `/page"; return 200`,
+			},
+			msg: "invalid blockPage",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{
+				ParseWebsocket: "enabled",
+			},
+			msg: "invalid parseWebsocket",
+		},
+		{
+			wallarm: &v1alpha1.Wallarm{
+				ParserDisable: []string{"json", "unknown"},
+			},
+			msg: "invalid parserDisable",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateWallarm(test.wallarm, field.NewPath("wallarm"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateWallarm() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateVirtualServerRoute(t *testing.T) {
 	virtualServerRoute := v1alpha1.VirtualServerRoute{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -1219,7 +2797,7 @@ func TestValidateVirtualServerRoute(t *testing.T) {
 		},
 	}
 
-	err := ValidateVirtualServerRoute(&virtualServerRoute)
+	err := ValidateVirtualServerRoute(&virtualServerRoute, false)
 	if err != nil {
 		t.Errorf("ValidateVirtualServerRoute() returned error %v for valid input %v", err, virtualServerRoute)
 	}
@@ -1260,7 +2838,7 @@ func TestValidateVirtualServerRouteForVirtualServer(t *testing.T) {
 	virtualServerHost := "example.com"
 	pathPrefix := "/test"
 
-	err := ValidateVirtualServerRouteForVirtualServer(&virtualServerRoute, virtualServerHost, pathPrefix)
+	err := ValidateVirtualServerRouteForVirtualServer(&virtualServerRoute, virtualServerHost, pathPrefix, false)
 	if err != nil {
 		t.Errorf("ValidateVirtualServerRouteForVirtualServer() returned error %v for valid input %v", err, virtualServerRoute)
 	}
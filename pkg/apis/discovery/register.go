@@ -0,0 +1,5 @@
+package discovery
+
+const (
+	GroupName = "discovery.k8s.io"
+)
@@ -0,0 +1,6 @@
+// +k8s:deepcopy-gen=package
+// +groupName=discovery.k8s.io
+
+// Package v1beta1 is the v1beta1 version of the discovery.k8s.io API.
+// It mirrors the EndpointSlice type of Kubernetes 1.21, which the vendored client-go doesn't include.
+package v1beta1
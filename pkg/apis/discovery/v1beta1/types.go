@@ -0,0 +1,87 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelServiceName is the label of an EndpointSlice that references the name of its service.
+	LabelServiceName = "kubernetes.io/service-name"
+)
+
+// +genclient
+// +genclient:onlyVerbs=get,list,watch
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EndpointSlice represents a subset of the endpoints that implement a service.
+type EndpointSlice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	AddressType AddressType    `json:"addressType"`
+	Endpoints   []Endpoint     `json:"endpoints"`
+	Ports       []EndpointPort `json:"ports"`
+}
+
+// AddressType represents the type of the addresses of an EndpointSlice.
+type AddressType string
+
+const (
+	// AddressTypeIPv4 represents an IPv4 Address.
+	AddressTypeIPv4 = AddressType("IPv4")
+	// AddressTypeIPv6 represents an IPv6 Address.
+	AddressTypeIPv6 = AddressType("IPv6")
+	// AddressTypeFQDN represents a FQDN.
+	AddressTypeFQDN = AddressType("FQDN")
+)
+
+// Endpoint represents a single logical backend implementing a service.
+type Endpoint struct {
+	Addresses  []string            `json:"addresses"`
+	Conditions EndpointConditions  `json:"conditions,omitempty"`
+	Hostname   *string             `json:"hostname,omitempty"`
+	TargetRef  *v1.ObjectReference `json:"targetRef,omitempty"`
+	Topology   map[string]string   `json:"topology,omitempty"`
+	NodeName   *string             `json:"nodeName,omitempty"`
+	Hints      *EndpointHints      `json:"hints,omitempty"`
+}
+
+// EndpointConditions represents the current condition of an endpoint.
+type EndpointConditions struct {
+	// Ready indicates that the endpoint is ready to receive traffic. A nil value means the endpoint is ready.
+	Ready *bool `json:"ready,omitempty"`
+	// Serving is identical to Ready, except that it is set regardless of the terminating state of the endpoint.
+	Serving *bool `json:"serving,omitempty"`
+	// Terminating indicates that the endpoint is terminating.
+	Terminating *bool `json:"terminating,omitempty"`
+}
+
+// EndpointHints provides hints describing how an endpoint should be consumed.
+type EndpointHints struct {
+	// ForZones indicates the zones that should consume the endpoint to enable topology aware routing.
+	ForZones []ForZone `json:"forZones,omitempty"`
+}
+
+// ForZone provides information about which zones should consume an endpoint.
+type ForZone struct {
+	Name string `json:"name"`
+}
+
+// EndpointPort represents a port used by an EndpointSlice.
+type EndpointPort struct {
+	Name        *string      `json:"name,omitempty"`
+	Protocol    *v1.Protocol `json:"protocol,omitempty"`
+	Port        *int32       `json:"port,omitempty"`
+	AppProtocol *string      `json:"appProtocol,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EndpointSliceList is a list of EndpointSlice resources.
+type EndpointSliceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EndpointSlice `json:"items"`
+}
@@ -0,0 +1,5 @@
+package networking
+
+const (
+	GroupName = "networking.k8s.io"
+)
@@ -0,0 +1,8 @@
+// +k8s:deepcopy-gen=package
+// +groupName=networking.k8s.io
+
+// Package v1beta1 is the v1beta1 version of the networking.k8s.io API.
+// It mirrors the Ingress and IngressClass types of Kubernetes 1.18, which the vendored client-go doesn't include.
+// The Ingress type also has the same schema as the extensions/v1beta1 Ingress, apart from the fields that
+// were added in networking.k8s.io.
+package v1beta1
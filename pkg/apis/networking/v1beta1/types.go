@@ -0,0 +1,145 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Ingress is a collection of rules that allow inbound connections to reach the endpoints defined by a backend.
+type Ingress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IngressSpec   `json:"spec,omitempty"`
+	Status IngressStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IngressList is a list of Ingress resources.
+type IngressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Ingress `json:"items"`
+}
+
+// IngressSpec describes the Ingress the user wishes to exist.
+type IngressSpec struct {
+	// IngressClassName is the name of the IngressClass resource of the Ingress.
+	IngressClassName *string         `json:"ingressClassName,omitempty"`
+	Backend          *IngressBackend `json:"backend,omitempty"`
+	TLS              []IngressTLS    `json:"tls,omitempty"`
+	Rules            []IngressRule   `json:"rules,omitempty"`
+}
+
+// IngressTLS describes the transport layer security associated with an Ingress.
+type IngressTLS struct {
+	Hosts      []string `json:"hosts,omitempty"`
+	SecretName string   `json:"secretName,omitempty"`
+}
+
+// IngressStatus describes the current state of the Ingress.
+type IngressStatus struct {
+	LoadBalancer v1.LoadBalancerStatus `json:"loadBalancer,omitempty"`
+}
+
+// IngressRule represents the rules mapping the paths under a specified host to the related backend services.
+type IngressRule struct {
+	Host             string `json:"host,omitempty"`
+	IngressRuleValue `json:",inline,omitempty"`
+}
+
+// IngressRuleValue represents a rule to apply against incoming requests.
+type IngressRuleValue struct {
+	HTTP *HTTPIngressRuleValue `json:"http,omitempty"`
+}
+
+// HTTPIngressRuleValue is a list of http selectors pointing to backends.
+type HTTPIngressRuleValue struct {
+	Paths []HTTPIngressPath `json:"paths"`
+}
+
+// PathType represents the type of path referred to by a HTTPIngressPath.
+type PathType string
+
+const (
+	// PathTypeExact matches the URL path exactly and with case sensitivity.
+	PathTypeExact = PathType("Exact")
+	// PathTypePrefix matches based on a URL path prefix split by '/'. For example, /foo matches /foo and /foo/bar,
+	// but not /foobar.
+	PathTypePrefix = PathType("Prefix")
+	// PathTypeImplementationSpecific leaves the matching up to the Ingress Controller. The Ingress Controller
+	// treats such a path as an NGINX prefix location.
+	PathTypeImplementationSpecific = PathType("ImplementationSpecific")
+)
+
+// HTTPIngressPath associates a path with a backend.
+type HTTPIngressPath struct {
+	Path     string         `json:"path,omitempty"`
+	PathType *PathType      `json:"pathType,omitempty"`
+	Backend  IngressBackend `json:"backend"`
+}
+
+// IngressBackend describes all endpoints for a given service and port.
+type IngressBackend struct {
+	ServiceName string             `json:"serviceName"`
+	ServicePort intstr.IntOrString `json:"servicePort"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=get,list,watch
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IngressClass represents the class of the Ingress, referenced by the Ingress Spec.
+type IngressClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressClassSpec `json:"spec,omitempty"`
+}
+
+// IngressClassSpec provides information about the class of an Ingress.
+type IngressClassSpec struct {
+	// Controller is the name of the controller that should handle this class.
+	Controller string `json:"controller,omitempty"`
+	// Parameters is a link to a resource that contains additional configuration for the controller.
+	Parameters *IngressClassParametersReference `json:"parameters,omitempty"`
+}
+
+// IngressClassParametersReference identifies an API object, which can be cluster-scoped or namespace-scoped.
+// Kubernetes older than 1.21 doesn't support the Scope and the Namespace fields.
+type IngressClassParametersReference struct {
+	// APIGroup is the group of the referenced resource. If it is empty, the resource must be in the core API group.
+	APIGroup *string `json:"apiGroup,omitempty"`
+	// Kind is the type of the referenced resource.
+	Kind string `json:"kind"`
+	// Name is the name of the referenced resource.
+	Name string `json:"name"`
+	// Scope is either "Cluster" or "Namespace".
+	Scope *string `json:"scope,omitempty"`
+	// Namespace is the namespace of the referenced resource. It is set only if Scope is "Namespace".
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+const (
+	// IngressClassParametersReferenceScopeNamespace is the scope of a namespace-scoped resource.
+	IngressClassParametersReferenceScopeNamespace = "Namespace"
+	// IngressClassParametersReferenceScopeCluster is the scope of a cluster-scoped resource.
+	IngressClassParametersReferenceScopeCluster = "Cluster"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IngressClassList is a list of IngressClass resources.
+type IngressClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []IngressClass `json:"items"`
+}
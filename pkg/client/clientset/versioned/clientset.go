@@ -4,6 +4,8 @@ package versioned
 
 import (
 	k8sv1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/configuration/v1alpha1"
+	discoveryv1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/discovery/v1beta1"
+	networkingv1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/networking/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -12,13 +14,17 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	K8sV1alpha1() k8sv1alpha1.K8sV1alpha1Interface
+	DiscoveryV1beta1() discoveryv1beta1.DiscoveryV1beta1Interface
+	NetworkingV1beta1() networkingv1beta1.NetworkingV1beta1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	k8sV1alpha1 *k8sv1alpha1.K8sV1alpha1Client
+	k8sV1alpha1       *k8sv1alpha1.K8sV1alpha1Client
+	discoveryV1beta1  *discoveryv1beta1.DiscoveryV1beta1Client
+	networkingV1beta1 *networkingv1beta1.NetworkingV1beta1Client
 }
 
 // K8sV1alpha1 retrieves the K8sV1alpha1Client
@@ -26,6 +32,16 @@ func (c *Clientset) K8sV1alpha1() k8sv1alpha1.K8sV1alpha1Interface {
 	return c.k8sV1alpha1
 }
 
+// DiscoveryV1beta1 retrieves the DiscoveryV1beta1Client
+func (c *Clientset) DiscoveryV1beta1() discoveryv1beta1.DiscoveryV1beta1Interface {
+	return c.discoveryV1beta1
+}
+
+// NetworkingV1beta1 retrieves the NetworkingV1beta1Client
+func (c *Clientset) NetworkingV1beta1() networkingv1beta1.NetworkingV1beta1Interface {
+	return c.networkingV1beta1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -46,6 +62,14 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.discoveryV1beta1, err = discoveryv1beta1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.networkingV1beta1, err = networkingv1beta1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -59,6 +83,8 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.k8sV1alpha1 = k8sv1alpha1.NewForConfigOrDie(c)
+	cs.discoveryV1beta1 = discoveryv1beta1.NewForConfigOrDie(c)
+	cs.networkingV1beta1 = networkingv1beta1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -68,6 +94,8 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.k8sV1alpha1 = k8sv1alpha1.New(c)
+	cs.discoveryV1beta1 = discoveryv1beta1.New(c)
+	cs.networkingV1beta1 = networkingv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
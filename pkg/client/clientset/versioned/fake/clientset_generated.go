@@ -6,6 +6,10 @@ import (
 	clientset "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	k8sv1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/configuration/v1alpha1"
 	fakek8sv1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/configuration/v1alpha1/fake"
+	discoveryv1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/discovery/v1beta1"
+	fakediscoveryv1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/discovery/v1beta1/fake"
+	networkingv1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/networking/v1beta1"
+	fakenetworkingv1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/networking/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -59,3 +63,13 @@ var _ clientset.Interface = &Clientset{}
 func (c *Clientset) K8sV1alpha1() k8sv1alpha1.K8sV1alpha1Interface {
 	return &fakek8sv1alpha1.FakeK8sV1alpha1{Fake: &c.Fake}
 }
+
+// DiscoveryV1beta1 retrieves the DiscoveryV1beta1Client
+func (c *Clientset) DiscoveryV1beta1() discoveryv1beta1.DiscoveryV1beta1Interface {
+	return &fakediscoveryv1beta1.FakeDiscoveryV1beta1{Fake: &c.Fake}
+}
+
+// NetworkingV1beta1 retrieves the NetworkingV1beta1Client
+func (c *Clientset) NetworkingV1beta1() networkingv1beta1.NetworkingV1beta1Interface {
+	return &fakenetworkingv1beta1.FakeNetworkingV1beta1{Fake: &c.Fake}
+}
@@ -11,6 +11,8 @@ import (
 
 type K8sV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	PoliciesGetter
+	TransportServersGetter
 	VirtualServersGetter
 	VirtualServerRoutesGetter
 }
@@ -20,6 +22,14 @@ type K8sV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *K8sV1alpha1Client) Policies(namespace string) PolicyInterface {
+	return newPolicies(c, namespace)
+}
+
+func (c *K8sV1alpha1Client) TransportServers(namespace string) TransportServerInterface {
+	return newTransportServers(c, namespace)
+}
+
 func (c *K8sV1alpha1Client) VirtualServers(namespace string) VirtualServerInterface {
 	return newVirtualServers(c, namespace)
 }
@@ -12,6 +12,14 @@ type FakeK8sV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeK8sV1alpha1) Policies(namespace string) v1alpha1.PolicyInterface {
+	return &FakePolicies{c, namespace}
+}
+
+func (c *FakeK8sV1alpha1) TransportServers(namespace string) v1alpha1.TransportServerInterface {
+	return &FakeTransportServers{c, namespace}
+}
+
 func (c *FakeK8sV1alpha1) VirtualServers(namespace string) v1alpha1.VirtualServerInterface {
 	return &FakeVirtualServers{c, namespace}
 }
@@ -84,6 +84,18 @@ func (c *FakeVirtualServers) Update(virtualServer *v1alpha1.VirtualServer) (resu
 	return obj.(*v1alpha1.VirtualServer), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVirtualServers) UpdateStatus(virtualServer *v1alpha1.VirtualServer) (*v1alpha1.VirtualServer, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(virtualserversResource, "status", c.ns, virtualServer), &v1alpha1.VirtualServer{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VirtualServer), err
+}
+
 // Delete takes name of the virtualServer and deletes it. Returns an error if one occurs.
 func (c *FakeVirtualServers) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
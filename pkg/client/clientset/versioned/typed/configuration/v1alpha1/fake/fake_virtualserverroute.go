@@ -84,6 +84,18 @@ func (c *FakeVirtualServerRoutes) Update(virtualServerRoute *v1alpha1.VirtualSer
 	return obj.(*v1alpha1.VirtualServerRoute), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVirtualServerRoutes) UpdateStatus(virtualServerRoute *v1alpha1.VirtualServerRoute) (*v1alpha1.VirtualServerRoute, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(virtualserverroutesResource, "status", c.ns, virtualServerRoute), &v1alpha1.VirtualServerRoute{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VirtualServerRoute), err
+}
+
 // Delete takes name of the virtualServerRoute and deletes it. Returns an error if one occurs.
 func (c *FakeVirtualServerRoutes) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -2,6 +2,10 @@
 
 package v1alpha1
 
+type PolicyExpansion interface{}
+
+type TransportServerExpansion interface{}
+
 type VirtualServerExpansion interface{}
 
 type VirtualServerRouteExpansion interface{}
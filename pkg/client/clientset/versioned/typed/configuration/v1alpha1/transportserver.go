@@ -0,0 +1,158 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	scheme "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// TransportServersGetter has a method to return a TransportServerInterface.
+// A group's client should implement this interface.
+type TransportServersGetter interface {
+	TransportServers(namespace string) TransportServerInterface
+}
+
+// TransportServerInterface has methods to work with TransportServer resources.
+type TransportServerInterface interface {
+	Create(*v1alpha1.TransportServer) (*v1alpha1.TransportServer, error)
+	Update(*v1alpha1.TransportServer) (*v1alpha1.TransportServer, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.TransportServer, error)
+	List(opts v1.ListOptions) (*v1alpha1.TransportServerList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.TransportServer, err error)
+	TransportServerExpansion
+}
+
+// transportServers implements TransportServerInterface
+type transportServers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newTransportServers returns a TransportServers
+func newTransportServers(c *K8sV1alpha1Client, namespace string) *transportServers {
+	return &transportServers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the transportServer, and returns the corresponding transportServer object, and an error if there is any.
+func (c *transportServers) Get(name string, options v1.GetOptions) (result *v1alpha1.TransportServer, err error) {
+	result = &v1alpha1.TransportServer{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("transportservers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of TransportServers that match those selectors.
+func (c *transportServers) List(opts v1.ListOptions) (result *v1alpha1.TransportServerList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.TransportServerList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("transportservers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested transportServers.
+func (c *transportServers) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("transportservers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a transportServer and creates it.  Returns the server's representation of the transportServer, and an error, if there is any.
+func (c *transportServers) Create(transportServer *v1alpha1.TransportServer) (result *v1alpha1.TransportServer, err error) {
+	result = &v1alpha1.TransportServer{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("transportservers").
+		Body(transportServer).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a transportServer and updates it. Returns the server's representation of the transportServer, and an error, if there is any.
+func (c *transportServers) Update(transportServer *v1alpha1.TransportServer) (result *v1alpha1.TransportServer, err error) {
+	result = &v1alpha1.TransportServer{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("transportservers").
+		Name(transportServer.Name).
+		Body(transportServer).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the transportServer and deletes it. Returns an error if one occurs.
+func (c *transportServers) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("transportservers").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *transportServers) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("transportservers").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched transportServer.
+func (c *transportServers) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.TransportServer, err error) {
+	result = &v1alpha1.TransportServer{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("transportservers").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -23,6 +23,7 @@ type VirtualServersGetter interface {
 type VirtualServerInterface interface {
 	Create(*v1alpha1.VirtualServer) (*v1alpha1.VirtualServer, error)
 	Update(*v1alpha1.VirtualServer) (*v1alpha1.VirtualServer, error)
+	UpdateStatus(*v1alpha1.VirtualServer) (*v1alpha1.VirtualServer, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.VirtualServer, error)
@@ -116,6 +117,22 @@ func (c *virtualServers) Update(virtualServer *v1alpha1.VirtualServer) (result *
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *virtualServers) UpdateStatus(virtualServer *v1alpha1.VirtualServer) (result *v1alpha1.VirtualServer, err error) {
+	result = &v1alpha1.VirtualServer{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(virtualServer.Name).
+		SubResource("status").
+		Body(virtualServer).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the virtualServer and deletes it. Returns an error if one occurs.
 func (c *virtualServers) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -23,6 +23,7 @@ type VirtualServerRoutesGetter interface {
 type VirtualServerRouteInterface interface {
 	Create(*v1alpha1.VirtualServerRoute) (*v1alpha1.VirtualServerRoute, error)
 	Update(*v1alpha1.VirtualServerRoute) (*v1alpha1.VirtualServerRoute, error)
+	UpdateStatus(*v1alpha1.VirtualServerRoute) (*v1alpha1.VirtualServerRoute, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.VirtualServerRoute, error)
@@ -116,6 +117,22 @@ func (c *virtualServerRoutes) Update(virtualServerRoute *v1alpha1.VirtualServerR
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *virtualServerRoutes) UpdateStatus(virtualServerRoute *v1alpha1.VirtualServerRoute) (result *v1alpha1.VirtualServerRoute, err error) {
+	result = &v1alpha1.VirtualServerRoute{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("virtualserverroutes").
+		Name(virtualServerRoute.Name).
+		SubResource("status").
+		Body(virtualServerRoute).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the virtualServerRoute and deletes it. Returns an error if one occurs.
 func (c *virtualServerRoutes) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -0,0 +1,86 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"time"
+
+	v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/discovery/v1beta1"
+	scheme "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// EndpointSlicesGetter has a method to return a EndpointSliceInterface.
+// A group's client should implement this interface.
+type EndpointSlicesGetter interface {
+	EndpointSlices(namespace string) EndpointSliceInterface
+}
+
+// EndpointSliceInterface has methods to work with EndpointSlice resources.
+type EndpointSliceInterface interface {
+	Get(name string, options v1.GetOptions) (*v1beta1.EndpointSlice, error)
+	List(opts v1.ListOptions) (*v1beta1.EndpointSliceList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	EndpointSliceExpansion
+}
+
+// endpointSlices implements EndpointSliceInterface
+type endpointSlices struct {
+	client rest.Interface
+	ns     string
+}
+
+// newEndpointSlices returns a EndpointSlices
+func newEndpointSlices(c *DiscoveryV1beta1Client, namespace string) *endpointSlices {
+	return &endpointSlices{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the endpointSlice, and returns the corresponding endpointSlice object, and an error if there is any.
+func (c *endpointSlices) Get(name string, options v1.GetOptions) (result *v1beta1.EndpointSlice, err error) {
+	result = &v1beta1.EndpointSlice{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("endpointslices").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of EndpointSlices that match those selectors.
+func (c *endpointSlices) List(opts v1.ListOptions) (result *v1beta1.EndpointSliceList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.EndpointSliceList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("endpointslices").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested endpointSlices.
+func (c *endpointSlices) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("endpointslices").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
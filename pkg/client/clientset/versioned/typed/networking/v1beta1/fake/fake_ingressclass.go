@@ -0,0 +1,58 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/networking/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeIngressClasses implements IngressClassInterface
+type FakeIngressClasses struct {
+	Fake *FakeNetworkingV1beta1
+}
+
+var ingressclassesResource = schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingressclasses"}
+
+var ingressclassesKind = schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1beta1", Kind: "IngressClass"}
+
+// Get takes name of the ingressClass, and returns the corresponding ingressClass object, and an error if there is any.
+func (c *FakeIngressClasses) Get(name string, options v1.GetOptions) (result *v1beta1.IngressClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(ingressclassesResource, name), &v1beta1.IngressClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.IngressClass), err
+}
+
+// List takes label and field selectors, and returns the list of IngressClasses that match those selectors.
+func (c *FakeIngressClasses) List(opts v1.ListOptions) (result *v1beta1.IngressClassList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(ingressclassesResource, ingressclassesKind, opts), &v1beta1.IngressClassList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.IngressClassList{ListMeta: obj.(*v1beta1.IngressClassList).ListMeta}
+	for _, item := range obj.(*v1beta1.IngressClassList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested ingressClasses.
+func (c *FakeIngressClasses) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(ingressclassesResource, opts))
+}
@@ -0,0 +1,28 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/typed/networking/v1beta1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeNetworkingV1beta1 struct {
+	*testing.Fake
+}
+
+func (c *FakeNetworkingV1beta1) Ingresses(namespace string) v1beta1.IngressInterface {
+	return &FakeIngresses{c, namespace}
+}
+
+func (c *FakeNetworkingV1beta1) IngressClasses() v1beta1.IngressClassInterface {
+	return &FakeIngressClasses{c}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeNetworkingV1beta1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
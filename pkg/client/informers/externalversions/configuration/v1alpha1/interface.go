@@ -8,6 +8,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Policies returns a PolicyInformer.
+	Policies() PolicyInformer
+	// TransportServers returns a TransportServerInformer.
+	TransportServers() TransportServerInformer
 	// VirtualServers returns a VirtualServerInformer.
 	VirtualServers() VirtualServerInformer
 	// VirtualServerRoutes returns a VirtualServerRouteInformer.
@@ -25,6 +29,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Policies returns a PolicyInformer.
+func (v *version) Policies() PolicyInformer {
+	return &policyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TransportServers returns a TransportServerInformer.
+func (v *version) TransportServers() TransportServerInformer {
+	return &transportServerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VirtualServers returns a VirtualServerInformer.
 func (v *version) VirtualServers() VirtualServerInformer {
 	return &virtualServerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -9,7 +9,9 @@ import (
 
 	versioned "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	configuration "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions/configuration"
+	discovery "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions/discovery"
 	internalinterfaces "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions/internalinterfaces"
+	networking "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions/networking"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -157,8 +159,18 @@ type SharedInformerFactory interface {
 	WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool
 
 	K8s() configuration.Interface
+	Discovery() discovery.Interface
+	Networking() networking.Interface
 }
 
 func (f *sharedInformerFactory) K8s() configuration.Interface {
 	return configuration.New(f, f.namespace, f.tweakListOptions)
 }
+
+func (f *sharedInformerFactory) Discovery() discovery.Interface {
+	return discovery.New(f, f.namespace, f.tweakListOptions)
+}
+
+func (f *sharedInformerFactory) Networking() networking.Interface {
+	return networking.New(f, f.namespace, f.tweakListOptions)
+}
@@ -2,6 +2,22 @@
 
 package v1alpha1
 
+// PolicyListerExpansion allows custom methods to be added to
+// PolicyLister.
+type PolicyListerExpansion interface{}
+
+// PolicyNamespaceListerExpansion allows custom methods to be added to
+// PolicyNamespaceLister.
+type PolicyNamespaceListerExpansion interface{}
+
+// TransportServerListerExpansion allows custom methods to be added to
+// TransportServerLister.
+type TransportServerListerExpansion interface{}
+
+// TransportServerNamespaceListerExpansion allows custom methods to be added to
+// TransportServerNamespaceLister.
+type TransportServerNamespaceListerExpansion interface{}
+
 // VirtualServerListerExpansion allows custom methods to be added to
 // VirtualServerLister.
 type VirtualServerListerExpansion interface{}